@@ -1,23 +1,145 @@
 package app
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
 
+	"fin-go/auth"
+	"fin-go/config"
 	"fin-go/routes/accounts"
 	"fin-go/routes/analysisTrees"
+	"fin-go/routes/apiTokens"
+	"fin-go/routes/archive"
+	"fin-go/routes/backups"
 	"fin-go/routes/categories"
+	"fin-go/routes/duplicates"
+	"fin-go/routes/export"
+	"fin-go/routes/importBatches"
+	"fin-go/routes/importProfiles"
 	"fin-go/routes/itemTokens"
 	"fin-go/routes/plaid"
 	"fin-go/routes/resetDB"
 	"fin-go/routes/saltedge"
 	"fin-go/routes/transactions"
+	"fin-go/routes/users"
 )
 
 type App struct {
 	Router *mux.Router
+	Config *config.Config
+}
+
+// routeScopes overrides the scope a route needs, which is otherwise read for
+// GET and write for everything else. Keys are the method and path template.
+var routeScopes = map[string]string{
+	"GET /api/session":                         auth.Public,
+	"POST /api/session":                        auth.Public,
+	"DELETE /api/session":                      auth.Public,
+	"GET /api/itemTokensFetchTransactions":     auth.ScopeWrite,
+	"GET /api/saltEdgeCreateInteractive":       auth.ScopeWrite,
+	"GET /api/saltEdgeRefreshInteractive/{id}": auth.ScopeWrite,
+	"POST /api/resetDB":                        auth.ScopeAdmin,
+	"POST /api/resetDBFull":                    auth.ScopeAdmin,
+	"POST /api/backups/{name}/restore":         auth.ScopeAdmin,
+	"DELETE /api/backups/{name}":               auth.ScopeAdmin,
+	"POST /api/archive/export":                 auth.ScopeAdmin,
+	"POST /api/archive/import":                 auth.ScopeAdmin,
+	"GET /api/users":                           auth.ScopeAdmin,
+	"POST /api/users":                          auth.ScopeAdmin,
+	"DELETE /api/users/{id}":                   auth.ScopeAdmin,
+	"POST /api/password":                       auth.ScopeRead,
+	"POST /api/users/{id}/role":                auth.ScopeAdmin,
+	"POST /api/confirmations":                  auth.ScopeAdmin,
+	"GET /api/backups/{name}":                  auth.ScopeAdmin,
+	"POST /api/customTree":                     auth.ScopeRead,
+	"GET /api/apiTokens":                       auth.ScopeAdmin,
+	"POST /api/apiTokens":                      auth.ScopeAdmin,
+	"DELETE /api/apiTokens/{id}":               auth.ScopeAdmin,
+	"POST /api/importBatches/{id}/rollback":    auth.ScopeAdmin,
+	"DELETE /api/itemTokens/{id}":              auth.ScopeAdmin,
+}
+
+func scopeFor(req *http.Request) string {
+	path := req.URL.Path
+	if route := mux.CurrentRoute(req); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			path = tpl
+		}
+	}
+	if scope, ok := routeScopes[req.Method+" "+path]; ok {
+		return scope
+	}
+	if req.Method == "GET" || req.Method == "HEAD" {
+		return auth.ScopeRead
+	}
+	return auth.ScopeWrite
 }
 
 func (app *App) SetupRouter() {
+	app.Router.Use(auth.Middleware(app.Config.Auth, scopeFor))
+
+	app.Router.
+		Methods("GET").
+		Path("/api/session").
+		HandlerFunc(users.SessionGetFunction(app.Config))
+
+	app.Router.
+		Methods("POST").
+		Path("/api/session").
+		HandlerFunc(users.LoginFunction(app.Config))
+
+	app.Router.
+		Methods("DELETE").
+		Path("/api/session").
+		HandlerFunc(users.LogoutFunction(app.Config))
+
+	app.Router.
+		Methods("GET").
+		Path("/api/users").
+		HandlerFunc(users.GetFunction())
+
+	app.Router.
+		Methods("POST").
+		Path("/api/users").
+		HandlerFunc(users.CreateFunction(app.Config))
+
+	app.Router.
+		Methods("DELETE").
+		Path("/api/users/{id}").
+		HandlerFunc(users.DeleteFunction())
+
+	app.Router.
+		Methods("POST").
+		Path("/api/users/{id}/role").
+		HandlerFunc(users.RoleFunction())
+
+	//Confirmation tokens for the destructive routes, which are wrapped in auth.Confirmed
+	app.Router.
+		Methods("POST").
+		Path("/api/confirmations").
+		HandlerFunc(users.ConfirmationFunction())
+
+	app.Router.
+		Methods("POST").
+		Path("/api/password").
+		HandlerFunc(users.PasswordFunction())
+
+	app.Router.
+		Methods("GET").
+		Path("/api/apiTokens").
+		HandlerFunc(apiTokens.GetFunction())
+
+	app.Router.
+		Methods("POST").
+		Path("/api/apiTokens").
+		HandlerFunc(apiTokens.CreateFunction())
+
+	app.Router.
+		Methods("DELETE").
+		Path("/api/apiTokens/{id}").
+		HandlerFunc(apiTokens.DeleteFunction())
+
 	app.Router.
 		Methods("GET").
 		Path("/api/accounts").
@@ -33,6 +155,11 @@ func (app *App) SetupRouter() {
 		Path("/api/accountUpsertIgnore").
 		HandlerFunc(accounts.UpsertIgnoreFunction())
 
+	app.Router.
+		Methods("POST").
+		Path("/api/accountSharing").
+		HandlerFunc(accounts.SharingFunction())
+
 	app.Router.
 		Methods("POST").
 		Path("/api/transactionUpsert").
@@ -51,17 +178,32 @@ func (app *App) SetupRouter() {
 	app.Router.
 		Methods("GET").
 		Path("/api/itemTokensFetchTransactions").
-		HandlerFunc(itemTokens.FetchTransactionsFunction())
+		HandlerFunc(itemTokens.FetchTransactionsFunction(app.Config))
+
+	app.Router.
+		Methods("POST").
+		Path("/api/itemTokensLink").
+		HandlerFunc(itemTokens.LinkFunction(app.Config))
+
+	app.Router.
+		Methods("GET").
+		Path("/api/itemTokens/{id}/health").
+		HandlerFunc(itemTokens.HealthFunction(app.Config))
+
+	app.Router.
+		Methods("DELETE").
+		Path("/api/itemTokens/{id}").
+		HandlerFunc(auth.Confirmed(auth.ActionDeleteItemToken, itemTokens.DeleteFunction(app.Config)))
 
 	app.Router.
 		Methods("POST").
 		Path("/api/plaidItemTokens").
-		HandlerFunc(plaid.CreateFromPublicTokenFunction())
+		HandlerFunc(plaid.CreateFromPublicTokenFunction(app.Config))
 
 	app.Router.
 		Methods("POST").
 		Path("/api/plaidGeneratePublicToken").
-		HandlerFunc(plaid.GeneratePublicTokenFunction())
+		HandlerFunc(plaid.GeneratePublicTokenFunction(app.Config))
 
 	app.Router.
 		Methods("GET").
@@ -73,17 +215,129 @@ func (app *App) SetupRouter() {
 		Path("/api/transactions").
 		HandlerFunc(transactions.PutFunction())
 
+	//One step import of an uploaded file, reading CSVs with an import profile
+	app.Router.
+		Methods("POST").
+		Path("/api/importFile").
+		HandlerFunc(transactions.ImportFileFunction(app.Config))
+
+	//Past imports, with their contents and undo
+	app.Router.
+		Methods("GET").
+		Path("/api/importBatches").
+		HandlerFunc(importBatches.GetFunction())
+
+	app.Router.
+		Methods("GET").
+		Path("/api/importBatches/{id}").
+		HandlerFunc(importBatches.GetOneFunction())
+
+	app.Router.
+		Methods("POST").
+		Path("/api/importBatches/{id}/rollback").
+		HandlerFunc(auth.Confirmed(auth.ActionRollbackImport, importBatches.RollbackFunction()))
+
+	app.Router.
+		Methods("GET").
+		Path("/api/importProfiles").
+		HandlerFunc(importProfiles.GetFunction())
+
+	app.Router.
+		Methods("POST").
+		Path("/api/importProfiles").
+		HandlerFunc(importProfiles.UpsertFunction())
+
+	app.Router.
+		Methods("DELETE").
+		Path("/api/importProfiles/{name}").
+		HandlerFunc(importProfiles.DeleteFunction())
+
+	//Optional step zero of import for bank exports (OFX/QFX, camt.053, MT940, QIF)
+	app.Router.
+		Methods("POST").
+		Path("/api/parseImportFile").
+		HandlerFunc(transactions.ParseFileFunction())
+
 	//Step one of import
 	app.Router.
 		Methods("POST").
 		Path("/api/checkTransactions").
-		HandlerFunc(transactions.CheckFunction())
+		HandlerFunc(transactions.CheckFunction(app.Config))
 
 	//Step two of import
 	app.Router.
 		Methods("POST").
 		Path("/api/importTransactions").
-		HandlerFunc(transactions.ImportFunction())
+		HandlerFunc(transactions.ImportFunction(app.Config))
+
+	//Backups of both databases
+	app.Router.
+		Methods("GET").
+		Path("/api/backups").
+		HandlerFunc(backups.GetFunction())
+
+	app.Router.
+		Methods("POST").
+		Path("/api/backups").
+		HandlerFunc(backups.CreateFunction())
+
+	app.Router.
+		Methods("GET").
+		Path("/api/backups/{name}").
+		HandlerFunc(backups.DownloadFunction())
+
+	app.Router.
+		Methods("DELETE").
+		Path("/api/backups/{name}").
+		HandlerFunc(auth.Confirmed(auth.ActionDeleteBackup, backups.DeleteFunction()))
+
+	app.Router.
+		Methods("POST").
+		Path("/api/backups/{name}/restore").
+		HandlerFunc(auth.Confirmed(auth.ActionRestoreBackup, backups.RestoreFunction()))
+
+	//Portable archive of all user data, optionally encrypted
+	app.Router.
+		Methods("POST").
+		Path("/api/archive/export").
+		HandlerFunc(archive.ExportFunction(app.Config))
+
+	app.Router.
+		Methods("POST").
+		Path("/api/archive/import").
+		HandlerFunc(auth.Confirmed(auth.ActionImportArchive, archive.ImportFunction(app.Config)))
+
+	//Export of transactions as CSV, OFX or NDJSON
+	app.Router.
+		Methods("GET").
+		Path("/api/export").
+		HandlerFunc(export.GetFunction(app.Config))
+
+	//Review of suspected duplicate transactions
+	app.Router.
+		Methods("GET").
+		Path("/api/duplicates").
+		HandlerFunc(duplicates.GetFunction())
+
+	app.Router.
+		Methods("POST").
+		Path("/api/duplicates/scan").
+		HandlerFunc(duplicates.ScanFunction(app.Config))
+
+	app.Router.
+		Methods("POST").
+		Path("/api/duplicates/{id}/confirm").
+		HandlerFunc(duplicates.ResolveFunction("confirmed"))
+
+	app.Router.
+		Methods("POST").
+		Path("/api/duplicates/{id}/dismiss").
+		HandlerFunc(duplicates.ResolveFunction("dismissed"))
+
+	app.Router.
+		Methods("POST").
+		Path("/api/duplicates/{id}/merge").
+		HandlerFunc(duplicates.ResolveFunction("merged"))
 
 	app.Router.
 		Methods("GET").
@@ -93,22 +347,22 @@ func (app *App) SetupRouter() {
 	app.Router.
 		Methods("GET").
 		Path("/api/saltEdgeRefreshInteractive/{id}").
-		HandlerFunc(saltedge.RefreshConnectionInteractiveFunction())
+		HandlerFunc(saltedge.RefreshConnectionInteractiveFunction(app.Config))
 
 	app.Router.
 		Methods("GET").
 		Path("/api/saltEdgeCreateInteractive").
-		HandlerFunc(saltedge.CreateConnectionInteractiveFunction())
+		HandlerFunc(saltedge.CreateConnectionInteractiveFunction(app.Config))
 
 	app.Router.
-		Methods("GET").
+		Methods("POST").
 		Path("/api/resetDB").
-		HandlerFunc(resetDB.ForceResetDBFunction())
+		HandlerFunc(auth.Confirmed(auth.ActionResetDB, resetDB.ForceResetDBFunction()))
 
 	app.Router.
-		Methods("GET").
+		Methods("POST").
 		Path("/api/resetDBFull").
-		HandlerFunc(resetDB.ForceResetDBFullFunction())
+		HandlerFunc(auth.Confirmed(auth.ActionResetDBFull, resetDB.ForceResetDBFullFunction()))
 
 	app.Router.
 		Methods("POST").
@@ -0,0 +1,551 @@
+// Package auth is the optional built-in authentication: local users with
+// bcrypt password hashes, cookie sessions for the UI, scoped API tokens for
+// scripts and a mode that trusts a user name header set by a reverse proxy.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-go/config"
+	"fin-go/db"
+	"fin-go/types"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Scopes an API token can have, each one includes the ones before it: read
+// is every GET, write every other change and admin the destructive routes
+// and managing users and tokens. A user's role caps the scopes they get.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+var Scopes = []string{ScopeRead, ScopeWrite, ScopeAdmin}
+
+// Public marks a route that needs no login
+const Public = ""
+
+// SessionCookie holds the session token of a logged in browser
+const SessionCookie = "fin_session"
+
+// tokenPrefix makes API tokens easy to spot, in logs or a leaked script
+const tokenPrefix = "fin_"
+
+const minPasswordLength = 8
+
+var (
+	ErrBadLogin      = errors.New("wrong user name or password")
+	ErrShortPassword = errors.New("password must be at least 8 characters")
+	ErrUserExists    = errors.New("user already exists")
+	ErrNotFound      = errors.New("not found")
+	ErrBadScope      = errors.New("unknown scope")
+)
+
+// Identity is who made a request and what they may do. Without auth every
+// request has all scopes and no user.
+type Identity struct {
+	User   types.User
+	Scopes []string
+	// Token is set when the request came with an API token
+	Token *types.APIToken
+}
+
+// Can reports whether the identity has scope, directly or through a wider one
+func (id Identity) Can(scope string) bool {
+	need := scopeRank(scope)
+	for _, s := range id.Scopes {
+		if scopeRank(s) >= need {
+			return true
+		}
+	}
+	return false
+}
+
+func scopeRank(scope string) int {
+	for i, s := range Scopes {
+		if s == scope {
+			return i
+		}
+	}
+	return len(Scopes)
+}
+
+// ParseScopes checks a list of scopes, dropping duplicates
+func ParseScopes(scopes []string) ([]string, error) {
+	parsed := []string{}
+	for _, s := range scopes {
+		s = strings.TrimSpace(s)
+		if scopeRank(s) == len(Scopes) {
+			return nil, fmt.Errorf("%w %q", ErrBadScope, s)
+		}
+		if !contains(parsed, s) {
+			parsed = append(parsed, s)
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("a token needs at least one scope")
+	}
+	return parsed, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// FromContext returns the identity the middleware found for a request
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(contextKey{}).(Identity)
+	return id, ok
+}
+
+// Middleware identifies every request and refuses those that lack the scope
+// scopeFor asks of their route, Public routes let anyone through. In mode
+// none nothing is checked.
+func Middleware(cfg config.Auth, scopeFor func(*http.Request) string) mux.MiddlewareFunc {
+	trusted := parseNets(cfg.TrustedProxies)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			id, ok := identify(cfg, trusted, req)
+			if ok {
+				req = req.WithContext(context.WithValue(req.Context(), contextKey{}, id))
+			}
+			scope := scopeFor(req)
+			if scope == Public {
+				next.ServeHTTP(res, req)
+				return
+			}
+			if !ok {
+				res.Header().Set("WWW-Authenticate", `Bearer realm="fin"`)
+				http.Error(res, "Not logged in", http.StatusUnauthorized)
+				return
+			}
+			if !id.Can(scope) {
+				msg := "Missing the " + scope + " scope"
+				if id.User.Role != "" {
+					msg += ", as a " + id.User.Role
+				}
+				http.Error(res, msg, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(res, req)
+		})
+	}
+}
+
+func identify(cfg config.Auth, trusted []*net.IPNet, req *http.Request) (Identity, bool) {
+	if cfg.Mode == "none" {
+		return Identity{Scopes: Scopes}, true
+	}
+	if header := req.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return tokenIdentity(strings.TrimPrefix(header, "Bearer "))
+	}
+	switch cfg.Mode {
+	case "local":
+		if cookie, err := req.Cookie(SessionCookie); err == nil {
+			return sessionIdentity(cookie.Value)
+		}
+	case "proxy":
+		name := strings.TrimSpace(req.Header.Get(cfg.ProxyHeader))
+		if name == "" {
+			return Identity{}, false
+		}
+		if !fromTrusted(trusted, req.RemoteAddr) {
+			log.Println("Ignoring", cfg.ProxyHeader, "header from untrusted address", req.RemoteAddr)
+			return Identity{}, false
+		}
+		user, err := proxyUser(cfg, name)
+		if err != nil {
+			log.Println("Error with proxy login:", err)
+			return Identity{}, false
+		}
+		return Identity{User: user, Scopes: RoleScopes(user.Role)}, true
+	}
+	return Identity{}, false
+}
+
+func parseNets(list []string) []*net.IPNet {
+	nets := []*net.IPNet{}
+	for _, s := range list {
+		if !strings.Contains(s, "/") {
+			if strings.Contains(s, ":") {
+				s += "/128"
+			} else {
+				s += "/32"
+			}
+		}
+		if _, n, err := net.ParseCIDR(s); err == nil {
+			nets = append(nets, n)
+		}
+	}
+	return nets
+}
+
+func fromTrusted(trusted []*net.IPNet, remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// newToken returns a random token and the hash it is stored as
+func newToken(prefix string) (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := prefix + base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashPassword returns the bcrypt hash of a password
+func HashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", ErrShortPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// dummyHash is compared against when the user doesn't exist, so a login
+// takes as long either way and doesn't give away which user names exist
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a password"), bcrypt.DefaultCost)
+
+func checkPassword(user types.User, found bool, password string) bool {
+	if !found || user.PasswordHash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+}
+
+// SelectUsers returns every user
+func SelectUsers() ([]types.User, error) {
+	users := []types.User{}
+	err := db.DBCon.Select(&users, "SELECT * FROM users ORDER BY username")
+	return users, err
+}
+
+// SelectUser finds a user by name
+func SelectUser(username string) (types.User, bool, error) {
+	user := types.User{}
+	err := db.DBCon.Get(&user, "SELECT * FROM users WHERE username = $1", username)
+	if err == sql.ErrNoRows {
+		return user, false, nil
+	}
+	return user, err == nil, err
+}
+
+func selectUserByID(id int) (types.User, error) {
+	user := types.User{}
+	err := db.DBCon.Get(&user, "SELECT * FROM users WHERE id = $1", id)
+	if err == sql.ErrNoRows {
+		err = ErrNotFound
+	}
+	return user, err
+}
+
+// CreateUser adds a local user with role, or the default role of cfg if it
+// is empty
+func CreateUser(cfg config.Auth, username, password, role string) (types.User, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return types.User{}, errors.New("user name is empty")
+	}
+	if _, found, err := SelectUser(username); err != nil || found {
+		if err == nil {
+			err = ErrUserExists
+		}
+		return types.User{}, err
+	}
+	role, err := newUserRole(role, cfg.DefaultRole)
+	if err != nil {
+		return types.User{}, err
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return types.User{}, err
+	}
+	id, err := db.InsertID(db.DBCon, "INSERT INTO users(username, password_hash, role) VALUES ($1, $2, $3)", username, hash, role)
+	if err != nil {
+		return types.User{}, err
+	}
+	return selectUserByID(int(id))
+}
+
+// proxyUser returns the user a proxy logged in, adding them with the default
+// role the first time they are seen. Proxy users have no password so they
+// can't log in locally.
+func proxyUser(cfg config.Auth, username string) (types.User, error) {
+	user, found, err := SelectUser(username)
+	if err != nil || found {
+		return user, err
+	}
+	role, err := newUserRole("", cfg.DefaultRole)
+	if err != nil {
+		return user, err
+	}
+	if _, err := db.DBCon.Exec("INSERT INTO users(username, role) VALUES ($1, $2) ON CONFLICT DO NOTHING", username, role); err != nil {
+		return user, err
+	}
+	log.Println("Added user", username, "from the proxy header")
+	user, _, err = SelectUser(username)
+	return user, err
+}
+
+// SetPassword changes a user's password and logs out their sessions
+func SetPassword(userID int, password string) error {
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	res, err := db.DBCon.Exec("UPDATE users SET password_hash = $1 WHERE id = $2", hash, userID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	_, err = db.DBCon.Exec("DELETE FROM sessions WHERE user_id = $1", userID)
+	return err
+}
+
+// ChangePassword sets a new password after checking the current one
+func ChangePassword(user types.User, current, password string) error {
+	if !checkPassword(user, true, current) {
+		return ErrBadLogin
+	}
+	return SetPassword(user.ID, password)
+}
+
+// DeleteUser removes a user with their sessions and API tokens. The last
+// owner can't be removed.
+func DeleteUser(userID int) error {
+	user, err := selectUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == RoleOwner {
+		if owners, err := otherOwners(userID); err != nil || owners == 0 {
+			if err == nil {
+				err = ErrLastOwner
+			}
+			return err
+		}
+	}
+	txn, err := db.DBCon.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+	txn.MustExec("DELETE FROM sessions WHERE user_id = $1", userID)
+	txn.MustExec("DELETE FROM api_tokens WHERE user_id = $1", userID)
+	res := txn.MustExec("DELETE FROM users WHERE id = $1", userID)
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return txn.Commit()
+}
+
+// Login checks a user's password and starts a session, returning its token
+func Login(cfg config.Auth, username, password string) (string, types.Session, error) {
+	user, found, err := SelectUser(username)
+	if err != nil {
+		return "", types.Session{}, err
+	}
+	if !checkPassword(user, found, password) {
+		return "", types.Session{}, ErrBadLogin
+	}
+	token, hash, err := newToken("")
+	if err != nil {
+		return "", types.Session{}, err
+	}
+	now := time.Now().UTC()
+	session := types.Session{UserID: user.ID, TokenHash: hash, ExpiresAt: now.Add(time.Duration(cfg.SessionHours) * time.Hour)}
+	if _, err := db.DBCon.Exec("DELETE FROM sessions WHERE expires_at < $1", now); err != nil {
+		return "", session, err
+	}
+	_, err = db.DBCon.Exec("INSERT INTO sessions(user_id, token_hash, expires_at) VALUES ($1, $2, $3)", session.UserID, session.TokenHash, session.ExpiresAt)
+	return token, session, err
+}
+
+// Logout ends the session with token
+func Logout(token string) error {
+	_, err := db.DBCon.Exec("DELETE FROM sessions WHERE token_hash = $1", hashToken(token))
+	return err
+}
+
+func sessionIdentity(token string) (Identity, bool) {
+	session := types.Session{}
+	err := db.DBCon.Get(&session, "SELECT * FROM sessions WHERE token_hash = $1", hashToken(token))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error with session lookup:", err)
+		}
+		return Identity{}, false
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return Identity{}, false
+	}
+	user, err := selectUserByID(session.UserID)
+	if err != nil {
+		return Identity{}, false
+	}
+	return Identity{User: user, Scopes: RoleScopes(user.Role)}, true
+}
+
+// CreateToken adds an API token for a user, expiring after expiresDays (0
+// never expires), with no scope the user's role doesn't grant. The token
+// itself is only ever returned here.
+func CreateToken(userID int, name string, scopes []string, expiresDays int) (types.APITokenCreated, error) {
+	created := types.APITokenCreated{}
+	scopes, err := ParseScopes(scopes)
+	if err != nil {
+		return created, err
+	}
+	user, err := selectUserByID(userID)
+	if err != nil {
+		return created, err
+	}
+	if capped := capScopes(user.Role, scopes); len(capped) < len(scopes) {
+		return created, fmt.Errorf("%w: a %s can't have a token with scopes %s", ErrBadScope, user.Role, strings.Join(scopes, ","))
+	}
+	if expiresDays < 0 {
+		return created, errors.New("expiry can't be negative")
+	}
+	token, hash, err := newToken(tokenPrefix)
+	if err != nil {
+		return created, err
+	}
+	var expires *time.Time
+	if expiresDays > 0 {
+		t := time.Now().UTC().AddDate(0, 0, expiresDays)
+		expires = &t
+	}
+	id, err := db.InsertID(db.DBCon, "INSERT INTO api_tokens(user_id, name, token_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5)",
+		userID, name, hash, strings.Join(scopes, ","), expires)
+	if err != nil {
+		return created, err
+	}
+	err = db.DBCon.Get(&created.APIToken, "SELECT * FROM api_tokens WHERE id = $1", id)
+	created.Token = token
+	return created, err
+}
+
+// SelectTokens returns a user's API tokens, or everyone's for userID 0
+func SelectTokens(userID int) ([]types.APIToken, error) {
+	tokens := []types.APIToken{}
+	var err error
+	if userID == 0 {
+		err = db.DBCon.Select(&tokens, "SELECT * FROM api_tokens ORDER BY id")
+	} else {
+		err = db.DBCon.Select(&tokens, "SELECT * FROM api_tokens WHERE user_id = $1 ORDER BY id", userID)
+	}
+	return tokens, err
+}
+
+// RevokeToken deletes one of a user's API tokens, or anyone's for userID 0
+func RevokeToken(userID, tokenID int) error {
+	var res sql.Result
+	var err error
+	if userID == 0 {
+		res, err = db.DBCon.Exec("DELETE FROM api_tokens WHERE id = $1", tokenID)
+	} else {
+		res, err = db.DBCon.Exec("DELETE FROM api_tokens WHERE id = $1 AND user_id = $2", tokenID, userID)
+	}
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// lastUsedEvery limits how often using a token is written to the database
+const lastUsedEvery = time.Minute
+
+func tokenIdentity(token string) (Identity, bool) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return Identity{}, false
+	}
+	t := types.APIToken{}
+	err := db.DBCon.Get(&t, "SELECT * FROM api_tokens WHERE token_hash = $1", hashToken(token))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error with API token lookup:", err)
+		}
+		return Identity{}, false
+	}
+	now := time.Now().UTC()
+	if t.ExpiresAt != nil && now.After(*t.ExpiresAt) {
+		return Identity{}, false
+	}
+	user, err := selectUserByID(t.UserID)
+	if err != nil {
+		return Identity{}, false
+	}
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) > lastUsedEvery {
+		if _, err := db.DBCon.Exec("UPDATE api_tokens SET last_used_at = $1 WHERE id = $2", now, t.ID); err != nil {
+			log.Println("Error with API token last use:", err)
+		}
+	}
+	// A token never does more than its user's role allows now
+	return Identity{User: user, Scopes: capScopes(user.Role, strings.Split(t.Scopes, ",")), Token: &t}, true
+}
+
+// SetSessionCookie hands a browser its session token
+func SetSessionCookie(cfg config.Auth, res http.ResponseWriter, token string, expires time.Time) {
+	http.SetCookie(res, &http.Cookie{
+		Name:     SessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   cfg.SecureCookie,
+		// Strict keeps other sites from making requests with the session,
+		// which the GET routes that change data rely on
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// ClearSessionCookie removes the session cookie from a browser
+func ClearSessionCookie(cfg config.Auth, res http.ResponseWriter) {
+	SetSessionCookie(cfg, res, "", time.Unix(0, 0))
+}
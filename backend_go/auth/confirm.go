@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Destructive actions, each needing a confirmation token of its own on top
+// of the admin scope, so a stray request or replayed link can't run them
+const (
+	ActionResetDB         = "resetDB"
+	ActionResetDBFull     = "resetDBFull"
+	ActionRestoreBackup   = "restoreBackup"
+	ActionDeleteBackup    = "deleteBackup"
+	ActionImportArchive   = "importArchive"
+	ActionRollbackImport  = "rollbackImport"
+	ActionDeleteItemToken = "deleteItemToken"
+)
+
+var Actions = []string{ActionResetDB, ActionResetDBFull, ActionRestoreBackup, ActionDeleteBackup, ActionImportArchive, ActionRollbackImport, ActionDeleteItemToken}
+
+// ConfirmHeader carries the confirmation token of a destructive request
+const ConfirmHeader = "X-Fin-Confirm"
+
+// confirmFor is how long a confirmation token can be used
+const confirmFor = 5 * time.Minute
+
+type confirmation struct {
+	action  string
+	userID  int
+	expires time.Time
+}
+
+// confirmations are only kept in memory: a restart drops them, which at
+// worst means asking again
+var confirmations = struct {
+	sync.Mutex
+	byHash map[string]confirmation
+}{byHash: map[string]confirmation{}}
+
+// Confirm hands id a token for one run of action, to be sent in the
+// ConfirmHeader within a few minutes
+func Confirm(id Identity, action string) (string, time.Time, error) {
+	if !contains(Actions, action) {
+		return "", time.Time{}, fmt.Errorf("%w: no action %q", ErrNotFound, action)
+	}
+	token, hash, err := newToken("")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	now := time.Now().UTC()
+	expires := now.Add(confirmFor)
+
+	confirmations.Lock()
+	defer confirmations.Unlock()
+	for h, c := range confirmations.byHash {
+		if now.After(c.expires) {
+			delete(confirmations.byHash, h)
+		}
+	}
+	confirmations.byHash[hash] = confirmation{action: action, userID: id.User.ID, expires: expires}
+	return token, expires, nil
+}
+
+// useConfirmation checks a confirmation token was handed to id for action,
+// using it up either way
+func useConfirmation(id Identity, action, token string) bool {
+	hash := hashToken(token)
+	confirmations.Lock()
+	defer confirmations.Unlock()
+	c, ok := confirmations.byHash[hash]
+	delete(confirmations.byHash, hash)
+	return ok && c.action == action && c.userID == id.User.ID && time.Now().Before(c.expires)
+}
+
+// Confirmed only lets a request through to next with a confirmation token
+// for action, see Confirm
+func Confirmed(action string, next http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		id, _ := FromContext(req.Context())
+		token := req.Header.Get(ConfirmHeader)
+		if token == "" || !useConfirmation(id, action, token) {
+			http.Error(res, "Needs a confirmation token for "+action+" in the "+ConfirmHeader+" header, from POST /api/confirmations", http.StatusPreconditionRequired)
+			return
+		}
+		next(res, req)
+	}
+}
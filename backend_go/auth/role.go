@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fin-go/db"
+)
+
+// Roles a user can have. A role caps the scopes of the user's sessions and
+// API tokens: owners have every scope, editors read and write, and viewers
+// only read.
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+var Roles = []string{RoleOwner, RoleEditor, RoleViewer}
+
+var (
+	ErrBadRole   = errors.New("unknown role")
+	ErrLastOwner = errors.New("the last owner can't be removed or demoted")
+)
+
+// ParseRole checks a role
+func ParseRole(role string) (string, error) {
+	role = strings.TrimSpace(role)
+	if !contains(Roles, role) {
+		return "", fmt.Errorf("%w %q, not one of %s", ErrBadRole, role, strings.Join(Roles, ", "))
+	}
+	return role, nil
+}
+
+// RoleScopes returns the scopes a role grants, none for an unknown role
+func RoleScopes(role string) []string {
+	switch role {
+	case RoleOwner:
+		return Scopes
+	case RoleEditor:
+		return []string{ScopeRead, ScopeWrite}
+	case RoleViewer:
+		return []string{ScopeRead}
+	}
+	return []string{}
+}
+
+// capScopes drops the scopes role doesn't grant, so a token keeps no more
+// than its user can do now
+func capScopes(role string, scopes []string) []string {
+	capped := []string{}
+	roleID := Identity{Scopes: RoleScopes(role)}
+	for _, s := range scopes {
+		if roleID.Can(s) {
+			capped = append(capped, s)
+		}
+	}
+	return capped
+}
+
+// newUserRole is the role of a user about to be added: role if set, else
+// fallback, and always owner for the first user so someone can manage fin
+func newUserRole(role, fallback string) (string, error) {
+	var users int
+	if err := db.DBCon.Get(&users, "SELECT COUNT(*) FROM users"); err != nil {
+		return "", err
+	}
+	if users == 0 {
+		return RoleOwner, nil
+	}
+	if role == "" {
+		role = fallback
+	}
+	return ParseRole(role)
+}
+
+// otherOwners counts the owners besides userID
+func otherOwners(userID int) (int, error) {
+	var owners int
+	err := db.DBCon.Get(&owners, "SELECT COUNT(*) FROM users WHERE role = $1 AND id <> $2", RoleOwner, userID)
+	return owners, err
+}
+
+// SetRole changes a user's role. The last owner keeps theirs.
+func SetRole(userID int, role string) error {
+	role, err := ParseRole(role)
+	if err != nil {
+		return err
+	}
+	user, err := selectUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == RoleOwner && role != RoleOwner {
+		if owners, err := otherOwners(userID); err != nil || owners == 0 {
+			if err == nil {
+				err = ErrLastOwner
+			}
+			return err
+		}
+	}
+	_, err = db.DBCon.Exec("UPDATE users SET role = $1 WHERE id = $2", role, userID)
+	return err
+}
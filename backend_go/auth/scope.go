@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"net/http"
+	"strconv"
+
+	"fin-go/db"
+)
+
+// Sharing of an account with the rest of the household. Accounts of user 0
+// (everything from before there were users, and anything added without a
+// user) belong to the whole household and are always visible and writable.
+const (
+	SharingPrivate     = "private"
+	SharingSharedRead  = "shared_read"
+	SharingSharedWrite = "shared_write"
+)
+
+// ValidSharing reports whether s is one of the sharing settings
+func ValidSharing(s string) bool {
+	return s == SharingPrivate || s == SharingSharedRead || s == SharingSharedWrite
+}
+
+// Views pick which accounts a request covers: only the caller's own, or
+// every account they can see
+const (
+	ViewMine      = "mine"
+	ViewHousehold = "household"
+)
+
+// System is the identity of command line jobs and of requests in auth mode
+// none: no user, and every account in view
+var System = Identity{Scopes: Scopes}
+
+// View returns the view asked for in a request's view parameter, household
+// unless it is mine
+func View(req *http.Request) string {
+	if req.URL.Query().Get("view") == ViewMine {
+		return ViewMine
+	}
+	return ViewHousehold
+}
+
+// AccountFilter is an SQL condition on the accounts table for the accounts
+// id can see in view, or change if write is set. It holds no bindvars so it
+// can go into any query; the user ID is the only value in it.
+func (id Identity) AccountFilter(view string, write bool) string {
+	if id.User.ID == 0 {
+		return "1 = 1"
+	}
+	user := strconv.Itoa(id.User.ID)
+	if view == ViewMine {
+		return "user_id = " + user
+	}
+	shared := "sharing <> '" + SharingPrivate + "'"
+	if write {
+		shared = "sharing = '" + SharingSharedWrite + "'"
+	}
+	return "(user_id = 0 OR user_id = " + user + " OR " + shared + ")"
+}
+
+// TransactionFilter is an SQL condition on the transactions table for the
+// transactions of the accounts AccountFilter selects
+func (id Identity) TransactionFilter(view string, write bool) string {
+	if id.User.ID == 0 {
+		return "1 = 1"
+	}
+	return "account_id IN (SELECT account_id FROM accounts WHERE " + id.AccountFilter(view, write) + ")"
+}
+
+// ItemFilter is an SQL condition on the item_tokens table for the items id
+// owns, or that hold an account they can see
+func (id Identity) ItemFilter() string {
+	if id.User.ID == 0 {
+		return "1 = 1"
+	}
+	user := strconv.Itoa(id.User.ID)
+	return "(user_id = 0 OR user_id = " + user + " OR item_id IN (SELECT item_id FROM accounts WHERE " + id.AccountFilter(ViewHousehold, false) + "))"
+}
+
+// CanWriteAccounts reports whether id may change every one of the accounts
+func (id Identity) CanWriteAccounts(accountIDs ...string) (bool, error) {
+	if id.User.ID == 0 {
+		return true, nil
+	}
+	for _, accountID := range accountIDs {
+		var readOnly int
+		err := db.DBCon.Get(&readOnly, "SELECT COUNT(*) FROM accounts WHERE account_id = $1 AND NOT "+id.AccountFilter(ViewHousehold, true), accountID)
+		if err != nil || readOnly > 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// OwnsItem reports whether id may manage an item token, being its owner or
+// it belonging to the household
+func (id Identity) OwnsItem(itemID string) (bool, error) {
+	if id.User.ID == 0 {
+		return true, nil
+	}
+	var owned int
+	err := db.DBCon.Get(&owned, "SELECT COUNT(*) FROM item_tokens WHERE item_id = $1 AND (user_id = 0 OR user_id = $2)", itemID, id.User.ID)
+	return owned > 0, err
+}
+
+// TreeScope names the analysis trees kept for what id sees in view: "all"
+// for everything, otherwise the view and user ID
+func (id Identity) TreeScope(view string) string {
+	if id.User.ID == 0 {
+		return "all"
+	}
+	return view + ":" + strconv.Itoa(id.User.ID)
+}
+
+// AnalysisScope is a set of analysis trees and the transactions they cover
+type AnalysisScope struct {
+	Name   string
+	Filter string
+}
+
+// AnalysisScopes returns every scope analysis trees are kept for: all of the
+// transactions, and each user's own and household views
+func AnalysisScopes() ([]AnalysisScope, error) {
+	scopes := []AnalysisScope{{Name: System.TreeScope(ViewHousehold), Filter: System.TransactionFilter(ViewHousehold, false)}}
+	users, err := SelectUsers()
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		id := Identity{User: u}
+		for _, view := range []string{ViewMine, ViewHousehold} {
+			scopes = append(scopes, AnalysisScope{Name: id.TreeScope(view), Filter: id.TransactionFilter(view, false)})
+		}
+	}
+	return scopes, nil
+}
@@ -0,0 +1,503 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"fin-go/app"
+	"fin-go/auth"
+	"fin-go/config"
+	"fin-go/db"
+	"fin-go/exporters"
+	"fin-go/importers"
+	"fin-go/routes/accounts"
+	"fin-go/routes/analysisTrees"
+	"fin-go/routes/export"
+	"fin-go/routes/itemTokens"
+	"fin-go/routes/transactions"
+	"fin-go/secrets"
+	"fin-go/types"
+
+	"github.com/gorilla/mux"
+)
+
+// result is what a command prints: data as JSON, or rows under headers as a
+// table
+type result struct {
+	data    interface{}
+	headers []string
+	rows    [][]string
+}
+
+func (r result) print(w io.Writer, output string) error {
+	if r.data == nil {
+		return nil
+	}
+	if output == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.data)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(r.headers, "\t"))
+	for _, row := range r.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// fields is a result shown as key/value rows, for data that is one record
+func fields(data interface{}, kv ...string) result {
+	r := result{data: data, headers: []string{"FIELD", "VALUE"}}
+	for i := 0; i+1 < len(kv); i += 2 {
+		r.rows = append(r.rows, []string{kv[i], kv[i+1]})
+	}
+	return r
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	// db opens the databases before running, fx loads the currency rates too
+	db, fx bool
+	// setup defines the command's flags and returns the function that runs it
+	// once they are parsed
+	setup func(fs *flag.FlagSet) func(cfg *config.Config, args []string) (result, error)
+}
+
+var commands = []command{
+	{name: "serve", summary: "run the HTTP server (the default)", setup: serve},
+	{name: "sync", summary: "refresh connected items and download new transactions", db: true, fx: true, setup: syncItems},
+	{name: "import", args: "<file>", summary: "import a CSV export or bank statement", db: true, fx: true, setup: importFile},
+	{name: "export", summary: "export transactions to stdout or a file", db: true, fx: true, setup: exportTransactions},
+	{name: "reanalyze", summary: "rebuild the analysis trees", db: true, setup: reanalyze},
+	{name: "fx update", summary: "pull new exchange rates from the ECB", db: true, fx: true, setup: fxUpdate},
+	{name: "backup", summary: "back up both databases", db: true, setup: createBackup},
+	{name: "backup list", summary: "list backups", db: true, setup: backupList},
+	{name: "migrate", summary: "bring the database schema up to date and list its migrations", db: true, setup: migrate},
+	{name: "accounts list", summary: "list accounts", db: true, setup: accountsList},
+	{name: "users add", args: "<name>", summary: "add a user, reading the password from stdin", db: true, setup: usersAdd},
+	{name: "users list", summary: "list users", db: true, setup: usersList},
+	{name: "users delete", args: "<name>", summary: "delete a user with their sessions and API tokens", db: true, setup: usersDelete},
+	{name: "users passwd", args: "<name>", summary: "set a user's password, reading it from stdin", db: true, setup: usersPasswd},
+	{name: "users role", args: "<name> <role>", summary: "make a user an owner, editor or viewer", db: true, setup: usersRole},
+	{name: "tokens create", args: "<user>", summary: "create an API token for a user", db: true, setup: tokensCreate},
+	{name: "tokens list", summary: "list API tokens", db: true, setup: tokensList},
+	{name: "tokens revoke", args: "<id>", summary: "revoke an API token", db: true, setup: tokensRevoke},
+	{name: "keys rotate", summary: "encrypt provider access tokens with a new key", db: true, setup: keysRotate},
+}
+
+// lookup finds the command args start with, trying two word commands first
+func lookup(args []string) (command, []string, bool) {
+	for _, n := range []int{2, 1} {
+		if len(args) < n {
+			continue
+		}
+		name := strings.Join(args[:n], " ")
+		for _, c := range commands {
+			if c.name == name {
+				return c, args[n:], true
+			}
+		}
+	}
+	return command{}, args, false
+}
+
+// Usage lists the commands
+func Usage(w io.Writer) {
+	name := filepath.Base(os.Args[0])
+	fmt.Fprintf(w, "Usage: %s [flags] [command] [command flags]\n\nCommands:\n", name)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", c.name, c.args, c.summary)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nRun %s <command> -h for a command's flags.\n", name)
+}
+
+// Run runs the command at the start of args, serve if there is none
+func Run(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+	if args[0] == "help" {
+		Usage(os.Stdout)
+		return nil
+	}
+	c, rest, ok := lookup(args)
+	if !ok {
+		Usage(os.Stderr)
+		return fmt.Errorf("unknown command %q", strings.Join(args, " "))
+	}
+
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	output := fs.String("output", "table", "output format, table or json")
+	run := c.setup(fs)
+	if err := fs.Parse(rest); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if *output != "table" && *output != "json" {
+		return fmt.Errorf("output must be table or json, not %q", *output)
+	}
+
+	if c.db {
+		if _, err := db.CreateDatabase(cfg); err != nil {
+			return err
+		}
+	}
+	if c.fx {
+		if _, err := db.CreateCurrencyDatabase(); err != nil {
+			return err
+		}
+	}
+
+	// A failed command can still have something to show, such as the rows
+	// that failed
+	r, err := run(cfg, fs.Args())
+	if perr := r.print(os.Stdout, *output); err == nil {
+		err = perr
+	}
+	return err
+}
+
+func serve(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		if _, err := db.CreateDatabase(cfg); err != nil {
+			return result{}, fmt.Errorf("cannot initialize DB: %v", err)
+		}
+		if _, err := db.CreateCurrencyDatabase(); err != nil {
+			return result{}, fmt.Errorf("cannot initialize Currency DB: %v", err)
+		}
+
+		db.GetNewXML()
+
+		db.StartBackupScheduler()
+
+		if cfg.Auth.Mode == "local" {
+			if users, err := auth.SelectUsers(); err == nil && len(users) == 0 {
+				log.Println("Auth mode is local but there are no users yet, add one with the users add command")
+			}
+		} else if cfg.Auth.Mode == "none" {
+			log.Println("Auth mode is none, anyone who can reach fin can use it")
+		}
+
+		app := &app.App{
+			Router: mux.NewRouter().StrictSlash(true),
+			Config: cfg,
+		}
+
+		app.SetupRouter()
+
+		log.Println("Starting HTTP server on", cfg.Listen)
+		return result{}, http.ListenAndServe(cfg.Listen, app.Router)
+	}
+}
+
+func itemRows(r *result, items []types.ItemToken) {
+	r.headers = []string{"ID", "INSTITUTION", "PROVIDER", "NEEDS LOGIN", "LAST DOWNLOAD"}
+	for _, item := range items {
+		r.rows = append(r.rows, []string{strconv.Itoa(item.ID), item.Institution, item.Provider,
+			strconv.FormatBool(item.NeedsReLogin), formatTime(item.LastDownloadedTransactions)})
+	}
+}
+
+func syncItems(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		if !cfg.Plaid.Enabled && !cfg.SaltEdge.Enabled {
+			return result{}, errors.New("neither Plaid nor SaltEdge is turned on")
+		}
+		start := time.Now()
+		itemTokens.Sync(cfg)
+		log.Printf("Sync done in: %v", time.Since(start))
+
+		items := itemTokens.SelectAll()
+		r := result{data: items}
+		itemRows(&r, items)
+		return r, nil
+	}
+}
+
+// rowErrorResult lists the rows of a file that could not be read
+func rowErrorResult(rowErrs importers.RowErrors) (result, error) {
+	r := result{data: rowErrs, headers: []string{"ROW", "FIELD", "VALUE", "ERROR"}}
+	for _, e := range rowErrs {
+		r.rows = append(r.rows, []string{strconv.Itoa(e.Row), e.Field, e.Value, e.Error})
+	}
+	return r, fmt.Errorf("%d rows could not be read, nothing was imported", len(rowErrs))
+}
+
+func importFile(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	profile := fs.String("profile", "", "import profile for CSV files, picked by the header row if not set")
+	dryRun := fs.Bool("dry-run", false, "show what would be imported without changing anything")
+	username := fs.String("user", "", "user new accounts belong to, the household by default")
+	locale := fs.String("locale", "", "language tag CSV dates and amounts are written for, such as de-DE")
+	dateOrder := fs.String("date-order", "", "order of numeric CSV dates, mdy, dmy or ymd")
+	dateFormat := fs.String("date-format", "", "format of CSV dates, such as DD.MM.YYYY, instead of the profile's")
+	return func(cfg *config.Config, args []string) (result, error) {
+		if len(args) != 1 {
+			return result{}, errors.New("import needs one file")
+		}
+		id := auth.System
+		if *username != "" {
+			user, err := findUser(*username)
+			if err != nil {
+				return result{}, err
+			}
+			id = auth.Identity{User: user, Scopes: auth.Scopes}
+		}
+		raw, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return result{}, err
+		}
+		filename := filepath.Base(args[0])
+		opts := types.ImportOptions{Locale: *locale, DateOrder: *dateOrder, DateFormat: *dateFormat}
+		txs, profileName, _, err := transactions.ParseFile(filename, raw, *profile, opts)
+		if rowErrs, ok := err.(importers.RowErrors); ok {
+			return rowErrorResult(rowErrs)
+		}
+		if err != nil {
+			return result{}, err
+		}
+
+		res, err := transactions.Import(types.ImportPostData{
+			TxSet:    txs,
+			Filename: filename,
+			Profile:  profileName,
+			DryRun:   *dryRun,
+		}, cfg, id)
+		if rowErrs, ok := err.(importers.RowErrors); ok {
+			return rowErrorResult(rowErrs)
+		}
+		if err != nil {
+			return result{}, err
+		}
+		if !res.DryRun {
+			analysisTrees.ReAnalyze()
+		}
+
+		if res.DryRun {
+			r := result{data: res, headers: []string{"ROW", "ACTION", "DATE", "AMOUNT", "DESCRIPTION", "ACCOUNT"}}
+			for _, row := range res.Rows {
+				tx := row.Transaction
+				r.rows = append(r.rows, []string{strconv.Itoa(row.Row), row.Action, string(tx.Date), tx.Amount.String(), tx.Description, tx.AccountName})
+			}
+			return r, nil
+		}
+		return fields(res,
+			"batch", strconv.FormatInt(res.BatchID, 10),
+			"profile", res.Profile,
+			"imported", strconv.Itoa(res.Imported),
+			"duplicates", strconv.Itoa(res.Duplicates),
+			"uncategorized", strconv.Itoa(res.Uncategorized),
+			"created accounts", strings.Join(res.CreatedAccounts, ", "),
+		), nil
+	}
+}
+
+func exportTransactions(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	format := fs.String("format", "csv", "export format: csv, ofx, ndjson, beancount or ledger")
+	out := fs.String("out", "", "file to write, stdout if not set")
+	// The filter flags are the query parameters of /api/export
+	q := url.Values{}
+	param := func(name, usage string) {
+		fs.Func(name, usage, func(v string) error { q.Set(name, v); return nil })
+	}
+	param("start", "first date, YYYY-MM-DD")
+	param("end", "last date, YYYY-MM-DD")
+	param("accounts", "comma separated account IDs")
+	param("provider", "comma separated providers")
+	return func(cfg *config.Config, args []string) (result, error) {
+		f, err := exporters.LookupFormat(*format)
+		if err != nil {
+			return result{}, err
+		}
+		filter, err := export.ParseFilter(q)
+		if err != nil {
+			return result{}, err
+		}
+
+		w := io.Writer(os.Stdout)
+		if *out != "" {
+			file, err := os.Create(*out)
+			if err != nil {
+				return result{}, err
+			}
+			defer file.Close()
+			w = file
+		}
+		count, err := export.Export(w, f, filter, cfg.BaseCurrency)
+		if err != nil {
+			return result{}, err
+		}
+		log.Printf("Exported %d transactions as %s", count, f.Name)
+
+		// The export itself is the output when it goes to stdout
+		if *out == "" {
+			return result{}, nil
+		}
+		summary := struct {
+			File   string `json:"file"`
+			Format string `json:"format"`
+			Count  int    `json:"count"`
+		}{*out, f.Name, count}
+		return fields(summary, "file", *out, "format", f.Name, "count", strconv.Itoa(count)), nil
+	}
+}
+
+func reanalyze(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		analysisTrees.ReAnalyze()
+
+		trees := []struct {
+			Name      string `json:"name" db:"name"`
+			FirstDate string `json:"first_date" db:"first_date"`
+			LastDate  string `json:"last_date" db:"last_date"`
+		}{}
+		if err := db.DBCon.Select(&trees, "SELECT name, first_date, last_date FROM analysis_trees WHERE scope = $1 ORDER BY name", auth.System.TreeScope(auth.ViewHousehold)); err != nil {
+			return result{}, err
+		}
+		r := result{data: trees, headers: []string{"TREE", "FROM", "TO"}}
+		for _, t := range trees {
+			r.rows = append(r.rows, []string{t.Name, t.FirstDate, t.LastDate})
+		}
+		return r, nil
+	}
+}
+
+func fxUpdate(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		if err := db.GetNewXML(); err != nil {
+			return result{}, err
+		}
+		latest, err := db.LatestRateDate()
+		if err != nil {
+			return result{}, err
+		}
+		day := latest.Format("2006-01-02")
+		return fields(map[string]string{"latest_rates": day}, "latest rates", day), nil
+	}
+}
+
+func backupRows(backups ...types.Backup) result {
+	r := result{headers: []string{"NAME", "KIND", "CREATED", "SCHEMA", "SIZE"}}
+	for _, b := range backups {
+		var size int64
+		for _, f := range b.Files {
+			size += f.Size
+		}
+		r.rows = append(r.rows, []string{b.Name, b.Kind, formatTime(b.CreatedAt), strconv.Itoa(b.SchemaVersion),
+			fmt.Sprintf("%.1f MB", float64(size)/(1<<20))})
+	}
+	return r
+}
+
+func createBackup(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	scheduled := fs.Bool("scheduled", false, "take a scheduled backup, rotated like the server's, to schedule backups with cron instead")
+	return func(cfg *config.Config, args []string) (result, error) {
+		kind := "manual"
+		if *scheduled {
+			kind = "scheduled"
+		}
+		b, err := db.CreateBackup(kind)
+		if err != nil {
+			return result{}, err
+		}
+		if *scheduled {
+			if err := db.PruneBackups(kind, cfg.Backups.Retention); err != nil {
+				return result{}, err
+			}
+		}
+		r := backupRows(b)
+		r.data = b
+		return r, nil
+	}
+}
+
+func backupList(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		backups, err := db.ListBackups()
+		if err != nil {
+			return result{}, err
+		}
+		r := backupRows(backups...)
+		r.data = backups
+		return r, nil
+	}
+}
+
+// migrate has nothing to do itself, opening the DB applies the migrations
+func migrate(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		applied, err := db.AppliedMigrations()
+		if err != nil {
+			return result{}, err
+		}
+		r := result{data: applied, headers: []string{"VERSION", "NAME", "APPLIED"}}
+		for _, m := range applied {
+			r.rows = append(r.rows, []string{strconv.Itoa(m.Version), m.Name, formatTime(m.AppliedAt)})
+		}
+		return r, nil
+	}
+}
+
+// keysRotate replaces the key access tokens are sealed with. A running
+// server reads a rotated key file again, but can't be handed a new TOKEN_KEY,
+// so that takes -force and prints the old key to keep for older backups.
+func keysRotate(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	force := fs.Bool("force", false, "rotate a key set with TOKEN_KEY, which a running server can't pick up")
+	return func(cfg *config.Config, args []string) (result, error) {
+		if cfg.Secrets.Key != "" && !*force {
+			return result{}, errors.New("the key is set with TOKEN_KEY: stop the server, run again with -force and set TOKEN_KEY to the new key before starting fin; keep the old key, backups from before can't be read without it")
+		}
+		key, err := secrets.Rotate(db.ResealTokens)
+		if err != nil {
+			return result{}, err
+		}
+		if cfg.Secrets.Key != "" {
+			return fields(struct {
+				Key    string `json:"key"`
+				OldKey string `json:"old_key"`
+			}{key, cfg.Secrets.Key}, "KEY", key, "OLD KEY", cfg.Secrets.Key, "",
+				"Set TOKEN_KEY to the new key before starting fin again, tokens can't be read without it. Keep the old key to restore backups from before."), nil
+		}
+		return fields(struct {
+			KeyFile string `json:"key_file"`
+		}{cfg.Secrets.KeyFile}, "KEY FILE", cfg.Secrets.KeyFile, "", "The old key is kept next to it to read older backups. A running server switches to the new key by itself."), nil
+	}
+}
+
+func accountsList(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		accs := accounts.SelectAll()
+		r := result{data: accs, headers: []string{"ACCOUNT ID", "NAME", "INSTITUTION", "PROVIDER", "TYPE", "BALANCE", "CURRENCY", "IGNORED"}}
+		for _, a := range accs {
+			r.rows = append(r.rows, []string{a.AccountID, a.Name, a.Institution, a.Provider, a.Type,
+				a.Balance.StringFixed(2), a.Currency, strconv.FormatBool(a.IgnoreTransactions)})
+		}
+		return r, nil
+	}
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin-go/auth"
+	"fin-go/config"
+	"fin-go/types"
+)
+
+// readPassword reads a password from the first line of stdin, so it stays
+// out of the shell history and the process list
+func readPassword() (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "Password: ")
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no password given on stdin")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func oneArg(args []string, what string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected one %s", what)
+	}
+	return args[0], nil
+}
+
+func findUser(username string) (types.User, error) {
+	user, found, err := auth.SelectUser(username)
+	if err == nil && !found {
+		err = fmt.Errorf("no user %q", username)
+	}
+	return user, err
+}
+
+func userRows(users ...types.User) result {
+	r := result{data: users, headers: []string{"ID", "USER NAME", "ROLE", "PASSWORD", "CREATED"}}
+	for _, u := range users {
+		password := "yes"
+		if u.PasswordHash == "" {
+			password = "no (proxy login)"
+		}
+		r.rows = append(r.rows, []string{strconv.Itoa(u.ID), u.Username, u.Role, password, formatTime(u.CreatedAt)})
+	}
+	return r
+}
+
+func usersAdd(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	role := fs.String("role", "", "one of "+strings.Join(auth.Roles, ", ")+", the configured default role if not set (the first user is always an owner)")
+	return func(cfg *config.Config, args []string) (result, error) {
+		username, err := oneArg(args, "user name")
+		if err != nil {
+			return result{}, err
+		}
+		password, err := readPassword()
+		if err != nil {
+			return result{}, err
+		}
+		user, err := auth.CreateUser(cfg.Auth, username, password, *role)
+		if err != nil {
+			return result{}, err
+		}
+		r := userRows(user)
+		r.data = user
+		return r, nil
+	}
+}
+
+func usersList(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		users, err := auth.SelectUsers()
+		if err != nil {
+			return result{}, err
+		}
+		return userRows(users...), nil
+	}
+}
+
+func usersDelete(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		username, err := oneArg(args, "user name")
+		if err != nil {
+			return result{}, err
+		}
+		user, err := findUser(username)
+		if err != nil {
+			return result{}, err
+		}
+		return result{}, auth.DeleteUser(user.ID)
+	}
+}
+
+// usersPasswd sets a password without the current one, for when it is lost
+func usersPasswd(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		username, err := oneArg(args, "user name")
+		if err != nil {
+			return result{}, err
+		}
+		user, err := findUser(username)
+		if err != nil {
+			return result{}, err
+		}
+		password, err := readPassword()
+		if err != nil {
+			return result{}, err
+		}
+		return result{}, auth.SetPassword(user.ID, password)
+	}
+}
+
+func usersRole(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		if len(args) != 2 {
+			return result{}, errors.New("expected a user name and a role")
+		}
+		user, err := findUser(args[0])
+		if err != nil {
+			return result{}, err
+		}
+		if err := auth.SetRole(user.ID, args[1]); err != nil {
+			return result{}, err
+		}
+		user, err = findUser(args[0])
+		return userRows(user), err
+	}
+}
+
+func tokenRows(tokens ...types.APIToken) result {
+	r := result{data: tokens, headers: []string{"ID", "USER ID", "NAME", "SCOPES", "EXPIRES", "LAST USED"}}
+	optional := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return formatTime(*t)
+	}
+	for _, t := range tokens {
+		r.rows = append(r.rows, []string{strconv.Itoa(t.ID), strconv.Itoa(t.UserID), t.Name, t.Scopes,
+			optional(t.ExpiresAt), optional(t.LastUsedAt)})
+	}
+	return r
+}
+
+func tokensCreate(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	name := fs.String("name", "", "what the token is for")
+	scopes := fs.String("scopes", auth.ScopeRead, "comma separated scopes, out of "+strings.Join(auth.Scopes, ", "))
+	expires := fs.Int("expires-days", 0, "days until the token expires, 0 for never")
+	return func(cfg *config.Config, args []string) (result, error) {
+		username, err := oneArg(args, "user name")
+		if err != nil {
+			return result{}, err
+		}
+		user, err := findUser(username)
+		if err != nil {
+			return result{}, err
+		}
+		created, err := auth.CreateToken(user.ID, *name, strings.Split(*scopes, ","), *expires)
+		if err != nil {
+			return result{}, err
+		}
+		return fields(created, "ID", strconv.Itoa(created.ID), "SCOPES", created.Scopes,
+			"TOKEN", created.Token, "", "This is the only time the token is shown."), nil
+	}
+}
+
+func tokensList(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	username := fs.String("user", "", "only list this user's tokens")
+	return func(cfg *config.Config, args []string) (result, error) {
+		userID := 0
+		if *username != "" {
+			user, err := findUser(*username)
+			if err != nil {
+				return result{}, err
+			}
+			userID = user.ID
+		}
+		tokens, err := auth.SelectTokens(userID)
+		if err != nil {
+			return result{}, err
+		}
+		return tokenRows(tokens...), nil
+	}
+}
+
+func tokensRevoke(fs *flag.FlagSet) func(*config.Config, []string) (result, error) {
+	return func(cfg *config.Config, args []string) (result, error) {
+		arg, err := oneArg(args, "token ID")
+		if err != nil {
+			return result{}, err
+		}
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return result{}, fmt.Errorf("token ID %q is not a number", arg)
+		}
+		return result{}, auth.RevokeToken(0, id)
+	}
+}
@@ -0,0 +1,444 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config holds every setting of the server. It is loaded once at startup by
+// Load, from the defaults, a YAML file, environment variables and flags in
+// that order, each overriding the one before.
+type Config struct {
+	// Listen is the address the HTTP server listens on
+	Listen string `yaml:"listen"`
+	// BaseCurrency is the currency amounts are normalized to, one of the
+	// currencies reported by the ECB
+	BaseCurrency string `yaml:"base_currency"`
+	// BaseURL is where fin is reached from outside, SaltEdge redirects here
+	BaseURL    string     `yaml:"base_url"`
+	Database   Database   `yaml:"database"`
+	Plaid      Plaid      `yaml:"plaid"`
+	SaltEdge   SaltEdge   `yaml:"saltedge"`
+	Duplicates Duplicates `yaml:"duplicates"`
+	Pending    Pending    `yaml:"pending"`
+	Backups    Backups    `yaml:"backups"`
+	Auth       Auth       `yaml:"auth"`
+	Secrets    Secrets    `yaml:"secrets"`
+}
+
+type Database struct {
+	// DataDir holds the SQLite databases and, unless set otherwise, backups
+	DataDir string `yaml:"data_dir"`
+	// URL is a postgres:// URL to keep the data in PostgreSQL instead of
+	// SQLite, CurrencyURL puts the currency rates somewhere else
+	URL         string `yaml:"url"`
+	CurrencyURL string `yaml:"currency_url"`
+}
+
+// DataPath is the SQLite file with the data
+func (d Database) DataPath() string {
+	return filepath.Join(d.DataDir, "data-go.sqlite")
+}
+
+// CurrencyPath is the SQLite file with the currency rates
+func (d Database) CurrencyPath() string {
+	return filepath.Join(d.DataDir, "currencyData.sqlite")
+}
+
+type Plaid struct {
+	Enabled bool `yaml:"enabled"`
+	// Environment is either "sandbox" or "development"
+	Environment       string `yaml:"environment"`
+	ClientID          string `yaml:"client_id"`
+	PublicKey         string `yaml:"public_key"`
+	SecretSandbox     string `yaml:"secret_sandbox"`
+	SecretDevelopment string `yaml:"secret_development"`
+	// URL replaces the environment's API URL, to point fin at a test server
+	URL string `yaml:"url"`
+}
+
+// Secret is the secret for the configured environment
+func (p Plaid) Secret() string {
+	if p.Environment == "development" {
+		return p.SecretDevelopment
+	}
+	return p.SecretSandbox
+}
+
+type SaltEdge struct {
+	Enabled    bool   `yaml:"enabled"`
+	AppID      string `yaml:"app_id"`
+	AppSecret  string `yaml:"app_secret"`
+	CustomerID string `yaml:"customer_id"`
+}
+
+// Duplicates holds the duplicate scorer settings: how many days apart, how far
+// apart in amount (a fraction of it) and how similar (0-1) two transactions
+// must be to be flagged for review
+type Duplicates struct {
+	DateWindow      int     `yaml:"date_window"`
+	AmountTolerance float64 `yaml:"amount_tolerance"`
+	Threshold       float64 `yaml:"threshold"`
+}
+
+// Pending holds how pending transactions are settled: a posted one replaces
+// a pending one of its account up to MatchDays before it whose amount is off
+// by at most AmountTolerance (a fraction of it), when its provider doesn't
+// say which it replaces, and pending ones older than ExpiryDays are deleted
+// (0 keeps them)
+type Pending struct {
+	MatchDays       int     `yaml:"match_days"`
+	AmountTolerance float64 `yaml:"amount_tolerance"`
+	ExpiryDays      int     `yaml:"expiry_days"`
+}
+
+type Backups struct {
+	// Dir defaults to a backups folder in the data dir
+	Dir string `yaml:"dir"`
+	// IntervalHours between scheduled backups, 0 turns them off
+	IntervalHours int `yaml:"interval_hours"`
+	// Retention is how many backups of each automatic kind are kept
+	Retention int `yaml:"retention"`
+}
+
+type Auth struct {
+	// Mode is "none" (anyone who can reach fin can use it), "local" (users,
+	// sessions and API tokens kept in fin) or "proxy" (a reverse proxy in
+	// front of fin logs users in and passes the user name in a header)
+	Mode string `yaml:"mode"`
+	// ProxyHeader carries the user name in proxy mode, and is only believed
+	// from the TrustedProxies, a list of IPs and CIDR ranges
+	ProxyHeader    string   `yaml:"proxy_header"`
+	TrustedProxies []string `yaml:"trusted_proxies"`
+	// SessionHours is how long a login lasts
+	SessionHours int `yaml:"session_hours"`
+	// SecureCookie marks the session cookie HTTPS only
+	SecureCookie bool `yaml:"secure_cookie"`
+	// DefaultRole is given to users the proxy adds and to users added
+	// without a role, except the first user who is always an owner
+	DefaultRole string `yaml:"default_role"`
+}
+
+// Secrets holds the key provider access tokens are encrypted with, see the
+// secrets package: Key (base64, 32 bytes) if set, otherwise the one in
+// KeyFile, which is made on first start and defaults to the data dir
+type Secrets struct {
+	Key     string `yaml:"key"`
+	KeyFile string `yaml:"key_file"`
+}
+
+// Default returns the settings used for anything left unset
+func Default() Config {
+	return Config{
+		Listen:       ":6060",
+		BaseCurrency: "USD",
+		Database:     Database{DataDir: "db"},
+		Plaid:        Plaid{Environment: "sandbox"},
+		Duplicates:   Duplicates{DateWindow: 3, AmountTolerance: 0.01, Threshold: 0.7},
+		Pending:      Pending{MatchDays: 5, AmountTolerance: 0.2, ExpiryDays: 14},
+		Backups:      Backups{IntervalHours: 24, Retention: 7},
+		Auth: Auth{
+			Mode:           "none",
+			ProxyHeader:    "Remote-User",
+			TrustedProxies: []string{"127.0.0.1", "::1"},
+			SessionHours:   720,
+			DefaultRole:    "viewer",
+		},
+	}
+}
+
+// setting ties a Config field to the environment variable and flag that set
+// it. Secrets have no flag so they don't show up in the process list.
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	value flag.Value
+}
+
+func settings(c *Config) []setting {
+	return []setting{
+		{"LISTEN_ADDRESS", "listen", "address the HTTP server listens on", (*stringValue)(&c.Listen)},
+		{"BASE_CURRENCY", "base-currency", "currency amounts are normalized to", (*stringValue)(&c.BaseCurrency)},
+		{"BASE_URL", "base-url", "URL fin is reached at, for SaltEdge redirects", (*stringValue)(&c.BaseURL)},
+		{"DATA_DIR", "data-dir", "folder for the SQLite databases", (*stringValue)(&c.Database.DataDir)},
+		{"DATABASE_URL", "database-url", "postgres:// URL to use PostgreSQL instead of SQLite", (*stringValue)(&c.Database.URL)},
+		{"CURRENCY_DATABASE_URL", "currency-database-url", "postgres:// URL for the currency rates, the data database by default", (*stringValue)(&c.Database.CurrencyURL)},
+		{"USE_PLAID", "use-plaid", "turn on Plaid", (*boolValue)(&c.Plaid.Enabled)},
+		{"PLAID_ENVIRONMENT", "plaid-environment", "Plaid environment, sandbox or development", (*stringValue)(&c.Plaid.Environment)},
+		{"PLAID_CLIENT_ID", "", "", (*stringValue)(&c.Plaid.ClientID)},
+		{"PLAID_PUBLIC_KEY", "", "", (*stringValue)(&c.Plaid.PublicKey)},
+		{"PLAID_SECRET_SANDBOX", "", "", (*stringValue)(&c.Plaid.SecretSandbox)},
+		{"PLAID_SECRET_DEVELOPMENT", "", "", (*stringValue)(&c.Plaid.SecretDevelopment)},
+		{"PLAID_URL", "plaid-url", "Plaid API URL instead of the environment's, such as a local test server", (*stringValue)(&c.Plaid.URL)},
+		{"USE_SALTEDGE", "use-saltedge", "turn on SaltEdge", (*boolValue)(&c.SaltEdge.Enabled)},
+		{"SALTEDGE_APP_ID", "", "", (*stringValue)(&c.SaltEdge.AppID)},
+		{"SALTEDGE_APP_SECRET", "", "", (*stringValue)(&c.SaltEdge.AppSecret)},
+		{"SALTEDGE_CUSTOMER_ID", "", "", (*stringValue)(&c.SaltEdge.CustomerID)},
+		{"DUPLICATE_DATE_WINDOW", "duplicate-date-window", "days apart duplicates can be", (*intValue)(&c.Duplicates.DateWindow)},
+		{"DUPLICATE_AMOUNT_TOLERANCE", "duplicate-amount-tolerance", "fraction of the amount duplicates can differ by", (*floatValue)(&c.Duplicates.AmountTolerance)},
+		{"DUPLICATE_THRESHOLD", "duplicate-threshold", "minimum score (0-1) to flag a duplicate", (*floatValue)(&c.Duplicates.Threshold)},
+		{"PENDING_MATCH_DAYS", "pending-match-days", "days before a posted transaction the pending one it replaces can be", (*intValue)(&c.Pending.MatchDays)},
+		{"PENDING_AMOUNT_TOLERANCE", "pending-amount-tolerance", "fraction of the amount a posted transaction can differ from the pending one it replaces by", (*floatValue)(&c.Pending.AmountTolerance)},
+		{"PENDING_EXPIRY_DAYS", "pending-expiry-days", "days after which pending transactions that never posted are deleted, 0 keeps them", (*intValue)(&c.Pending.ExpiryDays)},
+		{"BACKUP_DIR", "backup-dir", "folder backups are kept in", (*stringValue)(&c.Backups.Dir)},
+		{"BACKUP_INTERVAL_HOURS", "backup-interval-hours", "hours between scheduled backups, 0 turns them off", (*intValue)(&c.Backups.IntervalHours)},
+		{"BACKUP_RETENTION", "backup-retention", "how many backups of each automatic kind to keep", (*intValue)(&c.Backups.Retention)},
+		{"AUTH_MODE", "auth-mode", "authentication, none, local or proxy", (*stringValue)(&c.Auth.Mode)},
+		{"AUTH_PROXY_HEADER", "auth-proxy-header", "header with the user name in proxy mode", (*stringValue)(&c.Auth.ProxyHeader)},
+		{"AUTH_TRUSTED_PROXIES", "auth-trusted-proxies", "comma separated IPs and CIDR ranges the proxy header is trusted from", (*listValue)(&c.Auth.TrustedProxies)},
+		{"AUTH_SESSION_HOURS", "auth-session-hours", "hours a login lasts", (*intValue)(&c.Auth.SessionHours)},
+		{"AUTH_SECURE_COOKIE", "auth-secure-cookie", "only send the session cookie over HTTPS", (*boolValue)(&c.Auth.SecureCookie)},
+		{"AUTH_DEFAULT_ROLE", "auth-default-role", "role of new users, owner, editor or viewer", (*stringValue)(&c.Auth.DefaultRole)},
+		{"TOKEN_KEY", "", "", (*stringValue)(&c.Secrets.Key)},
+		{"TOKEN_KEY_FILE", "token-key-file", "file with the key access tokens are encrypted with, made if missing", (*stringValue)(&c.Secrets.KeyFile)},
+	}
+}
+
+// flagSet returns the flags for c, plus -config which names the config file
+func flagSet(c *Config, file *string) *flag.FlagSet {
+	fs := flag.NewFlagSet("fin", flag.ContinueOnError)
+	fs.StringVar(file, "config", os.Getenv("FIN_CONFIG"), "YAML config file (FIN_CONFIG)")
+	for _, s := range settings(c) {
+		if s.flag != "" {
+			fs.Var(s.value, s.flag, s.usage+" ("+s.env+")")
+		}
+	}
+	return fs
+}
+
+// Load reads the config from the defaults, the file named by -config or
+// FIN_CONFIG, the environment and the flags at the start of args, then
+// validates it. The args after the flags are returned.
+func Load(args []string) (*Config, []string, error) {
+	var file string
+	parsed := Default()
+	fs := flagSet(&parsed, &file)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Flags (before the command):\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := Default()
+	if file != "" {
+		if err := loadFile(file, &cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Empty variables count as unset, the .env examples leave some empty
+	for _, s := range settings(&cfg) {
+		if v := os.Getenv(s.env); v != "" {
+			if err := s.value.Set(v); err != nil {
+				return nil, nil, fmt.Errorf("invalid %s %q: %v", s.env, v, err)
+			}
+		}
+	}
+
+	// Flags were parsed into their own copy above so they can go last
+	flags := flagSet(&cfg, &file)
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		if err == nil && f.Name != "config" {
+			err = flags.Set(f.Name, f.Value.String())
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg.BaseCurrency = strings.ToUpper(cfg.BaseCurrency)
+	if cfg.Backups.Dir == "" {
+		cfg.Backups.Dir = filepath.Join(cfg.Database.DataDir, "backups")
+	}
+	if cfg.Secrets.KeyFile == "" {
+		cfg.Secrets.KeyFile = filepath.Join(cfg.Database.DataDir, "token.key")
+	}
+	if cfg.Database.URL != "" && cfg.Database.CurrencyURL == "" {
+		cfg.Database.CurrencyURL = cfg.Database.URL
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return &cfg, fs.Args(), nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %v", err)
+	}
+	// Strict so a misspelt key is an error rather than silently ignored
+	if err := yaml.UnmarshalStrict(raw, cfg); err != nil {
+		return fmt.Errorf("reading config file %s: %v", path, err)
+	}
+	return nil
+}
+
+var currencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Validate checks every setting, reporting all the problems at once
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.Listen != "", "listen address is empty")
+	check(currencyRe.MatchString(c.BaseCurrency), "base currency %q is not a three letter currency code", c.BaseCurrency)
+
+	db := c.Database
+	check(db.URL != "" || db.DataDir != "", "data dir is empty")
+	check(db.URL == "" || isPostgresURL(db.URL), "database URL is not a postgres:// URL")
+	check(db.CurrencyURL == "" || isPostgresURL(db.CurrencyURL), "currency database URL is not a postgres:// URL")
+	check(db.URL != "" || db.CurrencyURL == "", "currency database URL is set but the database URL isn't")
+
+	if c.Plaid.Enabled {
+		p := c.Plaid
+		check(p.Environment == "sandbox" || p.Environment == "development", "Plaid environment %q is not either 'sandbox' or 'development'", p.Environment)
+		check(p.ClientID != "", "Plaid is turned on but its client ID is empty")
+		check(p.Secret() != "", "Plaid is turned on but its %s secret is empty", p.Environment)
+		check(p.URL == "" || strings.HasPrefix(p.URL, "http://") || strings.HasPrefix(p.URL, "https://"), "Plaid URL %q is not an http(s) URL", p.URL)
+	}
+	if c.SaltEdge.Enabled {
+		s := c.SaltEdge
+		check(s.AppID != "" && s.AppSecret != "", "SaltEdge is turned on but its app ID or secret is empty")
+		// With users each one gets their own customer
+		check(s.CustomerID != "" || c.Auth.Mode != "none", "SaltEdge is turned on but its customer ID is empty")
+		check(c.BaseURL != "", "SaltEdge is turned on but the base URL is empty")
+	}
+
+	d := c.Duplicates
+	check(d.DateWindow >= 0, "duplicate date window can't be negative")
+	check(d.AmountTolerance >= 0, "duplicate amount tolerance can't be negative")
+	check(d.Threshold > 0 && d.Threshold <= 1, "duplicate threshold must be above 0 and at most 1")
+
+	pe := c.Pending
+	check(pe.MatchDays >= 0, "pending match days can't be negative")
+	check(pe.AmountTolerance >= 0, "pending amount tolerance can't be negative")
+	check(pe.ExpiryDays >= 0, "pending expiry days can't be negative")
+
+	b := c.Backups
+	check(b.Dir != "", "backup dir is empty")
+	check(b.IntervalHours >= 0, "backup interval can't be negative")
+	check(b.Retention >= 0, "backup retention can't be negative")
+
+	a := c.Auth
+	check(a.Mode == "none" || a.Mode == "local" || a.Mode == "proxy", "auth mode %q is not one of 'none', 'local' or 'proxy'", a.Mode)
+	check(a.SessionHours > 0, "auth session hours must be above 0")
+	check(a.DefaultRole == "owner" || a.DefaultRole == "editor" || a.DefaultRole == "viewer", "auth default role %q is not one of 'owner', 'editor' or 'viewer'", a.DefaultRole)
+	if a.Mode == "proxy" {
+		check(a.ProxyHeader != "", "auth mode is proxy but the proxy header is empty")
+		check(len(a.TrustedProxies) > 0, "auth mode is proxy but there are no trusted proxies")
+	}
+	for _, p := range a.TrustedProxies {
+		check(isIPOrCIDR(p), "trusted proxy %q is not an IP or CIDR range", p)
+	}
+
+	if key := c.Secrets.Key; key != "" {
+		raw, err := base64.StdEncoding.DecodeString(key)
+		check(err == nil && len(raw) == 32, "token key must be 32 bytes, base64 encoded")
+	}
+
+	if problems != nil {
+		return errors.New("invalid config:\n  " + strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func isPostgresURL(url string) bool {
+	return strings.HasPrefix(url, "postgres://") || strings.HasPrefix(url, "postgresql://")
+}
+
+func isIPOrCIDR(s string) bool {
+	if net.ParseIP(s) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(s)
+	return err == nil
+}
+
+type stringValue string
+
+func (v *stringValue) Set(s string) error { *v = stringValue(s); return nil }
+func (v *stringValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return string(*v)
+}
+
+// boolValue takes the TRUE/FALSE the .env files use as well as Go's spellings
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	*v = boolValue(b)
+	return err
+}
+func (v *boolValue) String() string   { return strconv.FormatBool(v != nil && bool(*v)) }
+func (v *boolValue) IsBoolFlag() bool { return true }
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	*v = intValue(i)
+	return err
+}
+func (v *intValue) String() string {
+	if v == nil {
+		return "0"
+	}
+	return strconv.Itoa(int(*v))
+}
+
+type floatValue float64
+
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	*v = floatValue(f)
+	return err
+}
+func (v *floatValue) String() string {
+	if v == nil {
+		return "0"
+	}
+	return strconv.FormatFloat(float64(*v), 'g', -1, 64)
+}
+
+// listValue is a comma separated list
+type listValue []string
+
+func (v *listValue) Set(s string) error {
+	*v = nil
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*v = append(*v, item)
+		}
+	}
+	return nil
+}
+func (v *listValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return strings.Join(*v, ",")
+}
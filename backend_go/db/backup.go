@@ -0,0 +1,398 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"fin-go/config"
+	"fin-go/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	backupDataFile     = "data-go.sqlite"
+	backupCurrencyFile = "currencyData.sqlite"
+	backupManifestFile = "manifest.json"
+)
+
+var (
+	ErrBackupNotFound = errors.New("backup not found")
+	ErrBackupInvalid  = errors.New("backup is not a valid fin backup")
+	// ErrBackupUnsupported is returned by stores that are backed up with their
+	// own tools, see Store.Backups
+	ErrBackupUnsupported = errors.New("backups are only built in for SQLite, back up Postgres with pg_dump")
+)
+
+var backupNameRe = regexp.MustCompile(`^\d{8}T\d{6}Z-[a-z-]+$`)
+
+// backupMu keeps backups and restores from running at the same time
+var backupMu sync.Mutex
+
+// backupConfig holds the backup settings, set by CreateDatabase
+var backupConfig config.Backups
+
+// BackupDir is where backups are kept
+func BackupDir() string {
+	return backupConfig.Dir
+}
+
+// BackupPath returns the path of a file in a backup, checking the backup name
+// so it can't point outside the backup folder
+func BackupPath(name, file string) (string, error) {
+	if !backupNameRe.MatchString(name) {
+		return "", ErrBackupNotFound
+	}
+	return filepath.Join(BackupDir(), name, file), nil
+}
+
+// copyDatabase copies every page of src into dst with the SQLite online backup
+// API, which gives a consistent snapshot while src keeps being written to
+func copyDatabase(dst, src *sqlx.DB) error {
+	ctx := context.Background()
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+	dstConn, err := dst.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+
+	return srcConn.Raw(func(s interface{}) error {
+		return dstConn.Raw(func(d interface{}) error {
+			b, err := d.(*sqlite3.SQLiteConn).Backup("main", s.(*sqlite3.SQLiteConn), "main")
+			if err != nil {
+				return err
+			}
+			if _, err := b.Step(-1); err != nil {
+				b.Finish()
+				return err
+			}
+			return b.Finish()
+		})
+	})
+}
+
+// backupTo writes a snapshot of con to a standalone file at path
+func backupTo(path string, con *sqlx.DB) (int64, error) {
+	dst, err := sqlx.Open("sqlite3", path)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+	if err := copyDatabase(dst, con); err != nil {
+		return 0, err
+	}
+	// The live DBs run in WAL mode, the copies should be single files
+	if _, err := dst.Exec("PRAGMA journal_mode=DELETE;"); err != nil {
+		return 0, err
+	}
+	dst.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// CreateBackup snapshots both databases into a new backup folder. Kind is
+// "manual" for backups asked for through the API; other kinds are made
+// automatically and rotated, see PruneBackups.
+func CreateBackup(kind string) (types.Backup, error) {
+	if !Storage.Backups() {
+		return types.Backup{}, ErrBackupUnsupported
+	}
+	backupMu.Lock()
+	defer backupMu.Unlock()
+	return createBackup(kind)
+}
+
+func createBackup(kind string) (types.Backup, error) {
+	start := time.Now()
+	backup := types.Backup{
+		Kind:          kind,
+		CreatedAt:     start.UTC().Truncate(time.Second),
+		SchemaVersion: SchemaVersion,
+		Files:         []types.BackupFile{},
+	}
+	backup.Name = backup.CreatedAt.Format("20060102T150405Z") + "-" + kind
+
+	dir := filepath.Join(BackupDir(), backup.Name)
+	tmp := filepath.Join(BackupDir(), ".tmp-"+backup.Name)
+	if err := os.MkdirAll(tmp, 0700); err != nil {
+		return backup, err
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, f := range []struct {
+		name string
+		con  *sqlx.DB
+	}{{backupDataFile, DBCon}, {backupCurrencyFile, CurrencyDBCon}} {
+		size, err := backupTo(filepath.Join(tmp, f.name), f.con)
+		if err != nil {
+			return backup, fmt.Errorf("backing up %s: %v", f.name, err)
+		}
+		backup.Files = append(backup.Files, types.BackupFile{Name: f.name, Size: size})
+	}
+
+	manifest, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return backup, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmp, backupManifestFile), manifest, 0600); err != nil {
+		return backup, err
+	}
+	// Only complete backups ever show up under their own name
+	if err := os.Rename(tmp, dir); err != nil {
+		return backup, err
+	}
+
+	log.Printf("Backup %s done in: %v", backup.Name, time.Since(start))
+	return backup, nil
+}
+
+// SelectBackup reads the manifest of a backup
+func SelectBackup(name string) (types.Backup, error) {
+	backup := types.Backup{}
+	path, err := BackupPath(name, backupManifestFile)
+	if err != nil {
+		return backup, err
+	}
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return backup, ErrBackupNotFound
+	}
+	if err != nil {
+		return backup, err
+	}
+	if err := json.Unmarshal(raw, &backup); err != nil {
+		return backup, fmt.Errorf("%w: %v", ErrBackupInvalid, err)
+	}
+	return backup, nil
+}
+
+// ListBackups returns every backup, newest first
+func ListBackups() ([]types.Backup, error) {
+	backups := []types.Backup{}
+	entries, err := ioutil.ReadDir(BackupDir())
+	if os.IsNotExist(err) {
+		return backups, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !backupNameRe.MatchString(entry.Name()) {
+			continue
+		}
+		backup, err := SelectBackup(entry.Name())
+		if err != nil {
+			log.Printf("Skipping backup %s: %v", entry.Name(), err)
+			continue
+		}
+		backups = append(backups, backup)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name > backups[j].Name })
+	return backups, nil
+}
+
+func DeleteBackup(name string) error {
+	if _, err := SelectBackup(name); err != nil {
+		return err
+	}
+	path, err := BackupPath(name, "")
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// PruneBackups keeps the newest backups of a kind and deletes the rest
+func PruneBackups(kind string, keep int) error {
+	backups, err := ListBackups()
+	if err != nil {
+		return err
+	}
+	kept := 0
+	for _, backup := range backups {
+		if backup.Kind != kind {
+			continue
+		}
+		if kept < keep {
+			kept++
+			continue
+		}
+		if err := DeleteBackup(backup.Name); err != nil {
+			return err
+		}
+		log.Printf("Rotated out backup %s", backup.Name)
+	}
+	return nil
+}
+
+// validateBackup checks both files in a backup are intact and that the data
+// DB is a fin database this build can read, returning its schema version
+func validateBackup(name string) (int, error) {
+	version := 0
+	for _, file := range []string{backupDataFile, backupCurrencyFile} {
+		path, err := BackupPath(name, file)
+		if err != nil {
+			return version, err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return version, fmt.Errorf("%w: %s is missing", ErrBackupInvalid, file)
+		}
+		con, err := sqlx.Open("sqlite3", "file:"+path+"?mode=ro")
+		if err != nil {
+			return version, err
+		}
+		defer con.Close()
+
+		var check string
+		if err := con.Get(&check, "PRAGMA quick_check"); err != nil || check != "ok" {
+			return version, fmt.Errorf("%w: %s failed its integrity check (%s %v)", ErrBackupInvalid, file, check, err)
+		}
+		if file != backupDataFile {
+			continue
+		}
+
+		var tables int
+		err = con.Get(&tables, `SELECT count(*) FROM sqlite_master WHERE type = 'table'
+			AND name IN ('accounts', 'transactions', 'categories', 'item_tokens')`)
+		if err != nil || tables != 4 {
+			return version, fmt.Errorf("%w: %s is not a fin database", ErrBackupInvalid, file)
+		}
+		if version, err = schemaVersion(con); err != nil {
+			return version, err
+		}
+		if version > SchemaVersion {
+			return version, fmt.Errorf("%w: schema version %d is newer than this version of fin (%d)", ErrBackupInvalid, version, SchemaVersion)
+		}
+	}
+	return version, nil
+}
+
+// RestoreBackup validates a backup and copies both databases back into the
+// live ones. The current state is backed up first (kind "pre-restore"), and
+// restored data from an older schema is brought up to date by InitSchema.
+func RestoreBackup(name string) (types.Backup, error) {
+	if !Storage.Backups() {
+		return types.Backup{}, ErrBackupUnsupported
+	}
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	backup, err := SelectBackup(name)
+	if err != nil {
+		return backup, err
+	}
+	version, err := validateBackup(name)
+	if err != nil {
+		return backup, err
+	}
+
+	safety, err := createBackup("pre-restore")
+	if err != nil {
+		return backup, fmt.Errorf("backing up current data before restore: %v", err)
+	}
+
+	for _, f := range []struct {
+		name string
+		con  *sqlx.DB
+	}{{backupDataFile, DBCon}, {backupCurrencyFile, CurrencyDBCon}} {
+		path, _ := BackupPath(name, f.name)
+		src, err := sqlx.Open("sqlite3", "file:"+path+"?mode=ro")
+		if err != nil {
+			return backup, err
+		}
+		err = copyDatabase(f.con, src)
+		src.Close()
+		if err != nil {
+			return backup, fmt.Errorf("restoring %s (current data is in backup %s): %v", f.name, safety.Name, err)
+		}
+	}
+	DBCon.Exec("PRAGMA journal_mode=WAL;")
+
+	if err := InitSchema(); err != nil {
+		return backup, fmt.Errorf("upgrading restored schema from version %d: %v", version, err)
+	}
+
+	pruneAutomatic("pre-restore")
+	log.Printf("Restored backup %s (schema version %d), previous data kept in %s", name, version, safety.Name)
+	return backup, nil
+}
+
+// pruneAutomatic rotates automatic backups of a kind, keeping as many as the
+// retention setting says
+func pruneAutomatic(kind string) {
+	if err := PruneBackups(kind, backupConfig.Retention); err != nil {
+		log.Printf("Error rotating %s backups: %v", kind, err)
+	}
+}
+
+// AutomaticBackup takes a rotated backup of a kind, logging instead of
+// failing since it's only ever a safety net for something else
+func AutomaticBackup(kind string) {
+	if !Storage.Backups() {
+		return
+	}
+	if _, err := CreateBackup(kind); err != nil {
+		log.Printf("Error with %s backup: %v", kind, err)
+		return
+	}
+	pruneAutomatic(kind)
+}
+
+// StartBackupScheduler takes a "scheduled" backup every IntervalHours of the
+// backup settings (24 by default, 0 turns it off). The first one is due an interval after the
+// last scheduled backup, so restarting the server doesn't keep putting it off.
+func StartBackupScheduler() {
+	if !Storage.Backups() {
+		log.Println("Scheduled backups are off, Postgres is backed up with its own tools")
+		return
+	}
+	hours := backupConfig.IntervalHours
+	if hours == 0 {
+		log.Println("Scheduled backups are turned off")
+		return
+	}
+	interval := time.Duration(hours) * time.Hour
+
+	wait := time.Duration(0)
+	backups, err := ListBackups()
+	if err != nil {
+		log.Printf("Error listing backups: %v", err)
+	}
+	for _, backup := range backups {
+		if backup.Kind == "scheduled" {
+			wait = time.Until(backup.CreatedAt.Add(interval))
+			break
+		}
+	}
+
+	go func() {
+		for {
+			if wait > 0 {
+				time.Sleep(wait)
+			}
+			AutomaticBackup("scheduled")
+			wait = interval
+		}
+	}()
+	log.Printf("Scheduled backups every %d hours to %s", hours, BackupDir())
+}
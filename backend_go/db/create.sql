@@ -1,861 +0,0 @@
--- PRAGMA foreign_keys=OFF;
-BEGIN TRANSACTION;
-CREATE TABLE IF NOT EXISTS `accounts` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `name` VARCHAR(255), `institution` VARCHAR(255), `ignore_transactions` TINYINT(1) DEFAULT 0, `account_id` VARCHAR(255), `item_id` VARCHAR(255), `type` VARCHAR(255), `subtype` VARCHAR(255) DEFAULT '', `balance` NUMERIC DEFAULT 0, `limit` NUMERIC DEFAULT 0, `available` NUMERIC DEFAULT 0, `currency` VARCHAR(255), `provider` VARCHAR(255), `running_total` NUMERIC DEFAULT 0, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP, UNIQUE (`account_id`, `provider`));
-CREATE TRIGGER IF NOT EXISTS UpdateLastTime1 UPDATE ON accounts
-BEGIN
-    UPDATE accounts SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
-END;
-CREATE TABLE IF NOT EXISTS `categories` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `top_category` TEXT, `sub_category` TEXT, `exclude_from_analysis` TINYINT(1), `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
-CREATE TRIGGER IF NOT EXISTS UpdateLastTime2 UPDATE ON categories
-BEGIN
-    UPDATE categories SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
-END;
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(1,'Auto & Transport','Auto & Transport',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(2,'Auto & Transport','Auto Insurance',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(3,'Auto & Transport','Auto Payment',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(4,'Auto & Transport','Gas & Fuel',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(5,'Auto & Transport','Parking',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(6,'Auto & Transport','Public Transportation',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(7,'Auto & Transport','Service & Parts',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(8,'Bills & Utilities','Bills & Utilities',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(9,'Bills & Utilities','Home Phone',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(10,'Bills & Utilities','Internet',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(11,'Bills & Utilities','Mobile Phone',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(12,'Bills & Utilities','Television',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(13,'Bills & Utilities','Utilities',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(14,'Education','Education',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(15,'Education','Books & Supplies',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(16,'Education','Student Loan',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(17,'Education','Tuition',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(18,'Entertainment','Entertainment',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(19,'Entertainment','Amusement',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(20,'Entertainment','Arts',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(21,'Entertainment','Movies & DVDs',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(22,'Entertainment','Music',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(23,'Entertainment','Newspapers & Magazines',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(24,'Fees & Charges','Fees & Charges',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(25,'Fees & Charges','ATM Fee',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(26,'Fees & Charges','Bank Fee',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(27,'Fees & Charges','Finance Charge',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(28,'Fees & Charges','Late Fee',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(29,'Fees & Charges','Service Fee',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(30,'Fees & Charges','Trade Commissions',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(31,'Financial','Financial',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(32,'Financial','Financial Advisor',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(33,'Financial','Life Insurance',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(34,'Financial','Roth IRA',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(35,'Food & Dining','Food & Dining',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(36,'Food & Dining','Alcohol & Bars',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(37,'Food & Dining','Fast Food',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(38,'Food & Dining','Groceries',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(39,'Food & Dining','Restaurants',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(40,'Gifts & Donations','Gifts & Donations',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(41,'Gifts & Donations','Charity',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(42,'Gifts & Donations','Gifts',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(43,'Health & Fitness','Health & Fitness',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(45,'Health & Fitness','Dentist',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(46,'Health & Fitness','Doctor',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(47,'Health & Fitness','Eyecare',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(48,'Health & Fitness','Gym',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(49,'Health & Fitness','Health Insurance',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(50,'Health & Fitness','Pharmacy',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(51,'Health & Fitness','Sports & Recreation',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(52,'Home','Home',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(53,'Home','Furnishings',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(54,'Home','Home Improvement & Services',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(55,'Home','Home Insurance',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(57,'Home','Home Supplies',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(58,'Home','Lawn & Garden',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(59,'Home','Mortgage & Rent',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(60,'Home','Renter''s insurance',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(61,'Income','Income',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(62,'Income','Bonus',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(63,'Income','Interest/Cap Gain/Dividend',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(64,'Income','Paycheck',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(65,'Income','Paypal Income',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(66,'Income','Reimbursement',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(67,'Income','Rental Income',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(68,'Income','Returned Purchase',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(69,'Investment (Buy)','Investment (Buy)',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(70,'Kids','Kids',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(71,'Kids','Allowance',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(72,'Kids','Baby Supplies',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(73,'Kids','Babysitter & Daycare',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(74,'Kids','Kids Activities',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(75,'Kids','Toys',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(76,'Misc Expenses','Misc Expenses',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(77,'Misc Expenses','Venmo expense',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(78,'Misc Expenses','Wedding',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(79,'Personal Care','Personal Care',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(80,'Personal Care','Hair',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(81,'Personal Care','Laundry',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(82,'Personal Care','Spa & Massage',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(83,'Shopping','Shopping',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(84,'Shopping','Amazon',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(85,'Shopping','Amazon Prime Member',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(86,'Shopping','Books',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(87,'Shopping','Clothing',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(88,'Shopping','Coffee',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(89,'Shopping','Electronics & Software',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(90,'Shopping','Hobbies',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(91,'Shopping','Sporting Goods',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(92,'Taxes','Taxes',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(93,'Taxes','Federal Tax',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(94,'Taxes','Local Tax',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(95,'Taxes','Property Tax',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(96,'Taxes','Sales Tax',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(97,'Taxes','State Tax',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(98,'Transfer','Transfer',1);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(99,'Transfer','Investment Transfer In/Out',1);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(100,'Transfer','Credit Card Payment (Transfer)',1);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(101,'Travel','Travel',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(102,'Travel','Air Travel',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(103,'Travel','Hotel',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(104,'Travel','Rental Car & Taxi',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(105,'Travel','Vacation',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(106,'Uncategorized','Uncategorized',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(107,'Uncategorized','Cash & ATM',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(108,'Uncategorized','Check',0);
-INSERT OR IGNORE INTO categories (id, top_category, sub_category, exclude_from_analysis) VALUES(109,'Hide from Analysis','Hide from Analysis',1);
-CREATE TABLE IF NOT EXISTS `item_tokens` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `institution` VARCHAR(255), `access_token` VARCHAR(255) DEFAULT '', `item_id` VARCHAR(255), `provider` VARCHAR(255), `interactive` TINYINT(1) DEFAULT 0, `needs_re_login` TINYINT(1) DEFAULT 0, `last_refresh` DATETIME, `next_refresh_possible` DATETIME, `last_downloaded_transactions` DATETIME, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP, UNIQUE (`item_id`, `provider`));
-CREATE TRIGGER IF NOT EXISTS UpdateLastTime4 UPDATE ON item_tokens
-BEGIN
-    UPDATE item_tokens SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
-END;
-CREATE TABLE IF NOT EXISTS `plaid__categories` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `hierarchy` TEXT, `cat_i_d` TEXT UNIQUE, `link_to_app_cat` INTEGER, `app_cat_name` TEXT, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
-CREATE TRIGGER IF NOT EXISTS UpdateLastTime5 UPDATE ON plaid__categories
-BEGIN
-    UPDATE plaid__categories SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
-END;
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(1,'[ "Bank Fees" ]','10000000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(2,'[ "Bank Fees", "Overdraft" ]','10001000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(3,'[ "Bank Fees", "ATM" ]','10002000',25,'ATM Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(4,'[ "Bank Fees", "Late Payment" ]','10003000',28,'Late Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(5,'[ "Bank Fees", "Fraud Dispute" ]','10004000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(6,'[ "Bank Fees", "Foreign Transaction" ]','10005000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(7,'[ "Bank Fees", "Wire Transfer" ]','10006000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(8,'[ "Bank Fees", "Insufficient Funds" ]','10007000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(9,'[ "Bank Fees", "Cash Advance" ]','10008000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(10,'[ "Bank Fees", "Excess Activity" ]','10009000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(11,'[ "Cash Advance" ]','11000000',26,'Bank Fee');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(12,'[ "Community" ]','12000000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(13,'[ "Community", "Animal Shelter" ]','12001000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(14,'[ "Community", "Assisted Living Services" ]','12002000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(15,'[ "Community", "Assisted Living Services", "Facilities and Nursing Homes" ]','12002001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(16,'[ "Community", "Assisted Living Services", "Caretakers" ]','12002002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(17,'[ "Community", "Cemetery" ]','12003000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(18,'[ "Community", "Courts" ]','12004000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(19,'[ "Community", "Day Care and Preschools" ]','12005000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(20,'[ "Community", "Disabled Persons Services" ]','12006000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(21,'[ "Community", "Drug and Alcohol Services" ]','12007000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(22,'[ "Community", "Education" ]','12008000',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(23,'[ "Community", "Education", "Vocational Schools" ]','12008001',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(24,'[ "Community", "Education", "Tutoring and Educational Services" ]','12008002',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(25,'[ "Community", "Education", "Primary and Secondary Schools" ]','12008003',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(26,'[ "Community", "Education", "Fraternities and Sororities" ]','12008004',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(27,'[ "Community", "Education", "Driving Schools" ]','12008005',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(28,'[ "Community", "Education", "Dance Schools" ]','12008006',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(29,'[ "Community", "Education", "Culinary Lessons and Schools" ]','12008007',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(30,'[ "Community", "Education", "Computer Training" ]','12008008',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(31,'[ "Community", "Education", "Colleges and Universities" ]','12008009',17,'Tuition');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(32,'[ "Community", "Education", "Art School" ]','12008010',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(33,'[ "Community", "Education", "Adult Education" ]','12008011',14,'Education');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(34,'[ "Community", "Government Departments and Agencies" ]','12009000',8,'Bills & Utilities');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(35,'[ "Community", "Government Lobbyists" ]','12010000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(36,'[ "Community", "Housing Assistance and Shelters" ]','12011000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(37,'[ "Community", "Law Enforcement" ]','12012000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(38,'[ "Community", "Law Enforcement", "Police Stations" ]','12012001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(39,'[ "Community", "Law Enforcement", "Fire Stations" ]','12012002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(40,'[ "Community", "Law Enforcement", "Correctional Institutions" ]','12012003',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(41,'[ "Community", "Libraries" ]','12013000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(42,'[ "Community", "Military" ]','12014000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(43,'[ "Community", "Organizations and Associations" ]','12015000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(44,'[ "Community", "Organizations and Associations", "Youth Organizations" ]','12015001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(45,'[ "Community", "Organizations and Associations", "Environmental" ]','12015002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(46,'[ "Community", "Organizations and Associations", "Charities and Non-Profits" ]','12015003',42,'Gifts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(47,'[ "Community", "Post Offices" ]','12016000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(48,'[ "Community", "Public and Social Services" ]','12017000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(49,'[ "Community", "Religious" ]','12018000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(50,'[ "Community", "Religious", "Temple" ]','12018001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(51,'[ "Community", "Religious", "Synagogues" ]','12018002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(52,'[ "Community", "Religious", "Mosques" ]','12018003',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(53,'[ "Community", "Religious", "Churches" ]','12018004',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(54,'[ "Community", "Senior Citizen Services" ]','12019000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(55,'[ "Community", "Senior Citizen Services", "Retirement" ]','12019001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(56,'[ "Food and Drink" ]','13000000',35,'Food & Dining');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(57,'[ "Food and Drink", "Bar" ]','13001000',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(58,'[ "Food and Drink", "Bar", "Wine Bar" ]','13001001',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(59,'[ "Food and Drink", "Bar", "Sports Bar" ]','13001002',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(60,'[ "Food and Drink", "Bar", "Hotel Lounge" ]','13001003',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(61,'[ "Food and Drink", "Breweries" ]','13002000',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(62,'[ "Food and Drink", "Internet Cafes" ]','13003000',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(63,'[ "Food and Drink", "Nightlife" ]','13004000',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(64,'[ "Food and Drink", "Nightlife", "Strip Club" ]','13004001',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(65,'[ "Food and Drink", "Nightlife", "Night Clubs" ]','13004002',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(66,'[ "Food and Drink", "Nightlife", "Karaoke" ]','13004003',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(67,'[ "Food and Drink", "Nightlife", "Jazz and Blues Cafe" ]','13004004',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(68,'[ "Food and Drink", "Nightlife", "Hookah Lounges" ]','13004005',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(69,'[ "Food and Drink", "Nightlife", "Adult Entertainment" ]','13004006',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(70,'[ "Food and Drink", "Restaurants" ]','13005000',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(71,'[ "Food and Drink", "Restaurants", "Winery" ]','13005001',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(72,'[ "Food and Drink", "Restaurants", "Vegan and Vegetarian" ]','13005002',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(73,'[ "Food and Drink", "Restaurants", "Turkish" ]','13005003',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(74,'[ "Food and Drink", "Restaurants", "Thai" ]','13005004',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(75,'[ "Food and Drink", "Restaurants", "Swiss" ]','13005005',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(76,'[ "Food and Drink", "Restaurants", "Sushi" ]','13005006',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(77,'[ "Food and Drink", "Restaurants", "Steakhouses" ]','13005007',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(78,'[ "Food and Drink", "Restaurants", "Spanish" ]','13005008',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(79,'[ "Food and Drink", "Restaurants", "Seafood" ]','13005009',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(80,'[ "Food and Drink", "Restaurants", "Scandinavian" ]','13005010',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(81,'[ "Food and Drink", "Restaurants", "Portuguese" ]','13005011',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(82,'[ "Food and Drink", "Restaurants", "Pizza" ]','13005012',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(83,'[ "Food and Drink", "Restaurants", "Moroccan" ]','13005013',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(84,'[ "Food and Drink", "Restaurants", "Middle Eastern" ]','13005014',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(85,'[ "Food and Drink", "Restaurants", "Mexican" ]','13005015',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(86,'[ "Food and Drink", "Restaurants", "Mediterranean" ]','13005016',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(87,'[ "Food and Drink", "Restaurants", "Latin American" ]','13005017',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(88,'[ "Food and Drink", "Restaurants", "Korean" ]','13005018',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(89,'[ "Food and Drink", "Restaurants", "Juice Bar" ]','13005019',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(90,'[ "Food and Drink", "Restaurants", "Japanese" ]','13005020',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(91,'[ "Food and Drink", "Restaurants", "Italian" ]','13005021',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(92,'[ "Food and Drink", "Restaurants", "Indonesian" ]','13005022',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(93,'[ "Food and Drink", "Restaurants", "Indian" ]','13005023',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(94,'[ "Food and Drink", "Restaurants", "Ice Cream" ]','13005024',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(95,'[ "Food and Drink", "Restaurants", "Greek" ]','13005025',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(96,'[ "Food and Drink", "Restaurants", "German" ]','13005026',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(97,'[ "Food and Drink", "Restaurants", "Gastropub" ]','13005027',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(98,'[ "Food and Drink", "Restaurants", "French" ]','13005028',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(99,'[ "Food and Drink", "Restaurants", "Food Truck" ]','13005029',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(100,'[ "Food and Drink", "Restaurants", "Fish and Chips" ]','13005030',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(101,'[ "Food and Drink", "Restaurants", "Filipino" ]','13005031',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(102,'[ "Food and Drink", "Restaurants", "Fast Food" ]','13005032',37,'Fast Food');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(103,'[ "Food and Drink", "Restaurants", "Falafel" ]','13005033',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(104,'[ "Food and Drink", "Restaurants", "Ethiopian" ]','13005034',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(105,'[ "Food and Drink", "Restaurants", "Eastern European" ]','13005035',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(106,'[ "Food and Drink", "Restaurants", "Donuts" ]','13005036',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(107,'[ "Food and Drink", "Restaurants", "Distillery" ]','13005037',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(108,'[ "Food and Drink", "Restaurants", "Diners" ]','13005038',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(109,'[ "Food and Drink", "Restaurants", "Dessert" ]','13005039',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(110,'[ "Food and Drink", "Restaurants", "Delis" ]','13005040',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(111,'[ "Food and Drink", "Restaurants", "Cupcake Shop" ]','13005041',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(112,'[ "Food and Drink", "Restaurants", "Cuban" ]','13005042',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(113,'[ "Food and Drink", "Restaurants", "Coffee Shop" ]','13005043',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(114,'[ "Food and Drink", "Restaurants", "Chinese" ]','13005044',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(115,'[ "Food and Drink", "Restaurants", "Caribbean" ]','13005045',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(116,'[ "Food and Drink", "Restaurants", "Cajun" ]','13005046',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(117,'[ "Food and Drink", "Restaurants", "Cafe" ]','13005047',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(118,'[ "Food and Drink", "Restaurants", "Burrito" ]','13005048',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(119,'[ "Food and Drink", "Restaurants", "Burgers" ]','13005049',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(120,'[ "Food and Drink", "Restaurants", "Breakfast Spot" ]','13005050',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(121,'[ "Food and Drink", "Restaurants", "Brazilian" ]','13005051',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(122,'[ "Food and Drink", "Restaurants", "Barbecue" ]','13005052',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(123,'[ "Food and Drink", "Restaurants", "Bakery" ]','13005053',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(124,'[ "Food and Drink", "Restaurants", "Bagel Shop" ]','13005054',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(125,'[ "Food and Drink", "Restaurants", "Australian" ]','13005055',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(126,'[ "Food and Drink", "Restaurants", "Asian" ]','13005056',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(127,'[ "Food and Drink", "Restaurants", "American" ]','13005057',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(128,'[ "Food and Drink", "Restaurants", "African" ]','13005058',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(129,'[ "Food and Drink", "Restaurants", "Afghan" ]','13005059',39,'Restaurants');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(130,'[ "Healthcare" ]','14000000',43,'Health & Fitness');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(131,'[ "Healthcare", "Healthcare Services" ]','14001000',43,'Health & Fitness');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(132,'[ "Healthcare", "Healthcare Services", "Psychologists" ]','14001001',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(133,'[ "Healthcare", "Healthcare Services", "Pregnancy and Sexual Health" ]','14001002',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(134,'[ "Healthcare", "Healthcare Services", "Podiatrists" ]','14001003',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(135,'[ "Healthcare", "Healthcare Services", "Physical Therapy" ]','14001004',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(136,'[ "Healthcare", "Healthcare Services", "Optometrists" ]','14001005',47,'Eyecare');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(137,'[ "Healthcare", "Healthcare Services", "Nutritionists" ]','14001006',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(138,'[ "Healthcare", "Healthcare Services", "Nurses" ]','14001007',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(139,'[ "Healthcare", "Healthcare Services", "Mental Health" ]','14001008',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(140,'[ "Healthcare", "Healthcare Services", "Medical Supplies and Labs" ]','14001009',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(141,'[ "Healthcare", "Healthcare Services", "Hospitals, Clinics and Medical Centers" ]','14001010',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(142,'[ "Healthcare", "Healthcare Services", "Emergency Services" ]','14001011',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(143,'[ "Healthcare", "Healthcare Services", "Dentists" ]','14001012',45,'Dentist');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(144,'[ "Healthcare", "Healthcare Services", "Counseling and Therapy" ]','14001013',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(145,'[ "Healthcare", "Healthcare Services", "Chiropractors" ]','14001014',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(146,'[ "Healthcare", "Healthcare Services", "Blood Banks and Centers" ]','14001015',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(147,'[ "Healthcare", "Healthcare Services", "Alternative Medicine" ]','14001016',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(148,'[ "Healthcare", "Healthcare Services", "Acupuncture" ]','14001017',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(149,'[ "Healthcare", "Physicians" ]','14002000',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(150,'[ "Healthcare", "Physicians", "Urologists" ]','14002001',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(151,'[ "Healthcare", "Physicians", "Respiratory" ]','14002002',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(152,'[ "Healthcare", "Physicians", "Radiologists" ]','14002003',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(153,'[ "Healthcare", "Physicians", "Psychiatrists" ]','14002004',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(154,'[ "Healthcare", "Physicians", "Plastic Surgeons" ]','14002005',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(155,'[ "Healthcare", "Physicians", "Pediatricians" ]','14002006',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(156,'[ "Healthcare", "Physicians", "Pathologists" ]','14002007',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(157,'[ "Healthcare", "Physicians", "Orthopedic Surgeons" ]','14002008',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(158,'[ "Healthcare", "Physicians", "Ophthalmologists" ]','14002009',47,'Eyecare');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(159,'[ "Healthcare", "Physicians", "Oncologists" ]','14002010',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(160,'[ "Healthcare", "Physicians", "Obstetricians and Gynecologists" ]','14002011',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(161,'[ "Healthcare", "Physicians", "Neurologists" ]','14002012',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(162,'[ "Healthcare", "Physicians", "Internal Medicine" ]','14002013',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(163,'[ "Healthcare", "Physicians", "General Surgery" ]','14002014',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(164,'[ "Healthcare", "Physicians", "Gastroenterologists" ]','14002015',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(165,'[ "Healthcare", "Physicians", "Family Medicine" ]','14002016',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(166,'[ "Healthcare", "Physicians", "Ear, Nose and Throat" ]','14002017',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(167,'[ "Healthcare", "Physicians", "Dermatologists" ]','14002018',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(168,'[ "Healthcare", "Physicians", "Cardiologists" ]','14002019',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(169,'[ "Healthcare", "Physicians", "Anesthesiologists" ]','14002020',46,'Doctor');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(170,'[ "Interest" ]','15000000',63,'Interest/Cap Gain/Dividend');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(171,'[ "Interest", "Interest Earned" ]','15001000',63,'Interest/Cap Gain/Dividend');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(172,'[ "Interest", "Interest Charged" ]','15002000',63,'Interest/Cap Gain/Dividend');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(173,'[ "Payment" ]','16000000',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(174,'[ "Payment", "Credit Card" ]','16001000',100,'Credit Card Payment (Transfer)');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(175,'[ "Payment", "Rent" ]','16002000',59,'Mortgage & Rent');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(176,'[ "Payment", "Loan" ]','16003000',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(177,'[ "Recreation" ]','17000000',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(178,'[ "Recreation", "Arts and Entertainment" ]','17001000',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(179,'[ "Recreation", "Arts and Entertainment", "Theatrical Productions" ]','17001001',20,'Arts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(180,'[ "Recreation", "Arts and Entertainment", "Symphony and Opera" ]','17001002',20,'Arts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(181,'[ "Recreation", "Arts and Entertainment", "Sports Venues" ]','17001003',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(182,'[ "Recreation", "Arts and Entertainment", "Social Clubs" ]','17001004',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(183,'[ "Recreation", "Arts and Entertainment", "Psychics and Astrologers" ]','17001005',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(184,'[ "Recreation", "Arts and Entertainment", "Party Centers" ]','17001006',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(185,'[ "Recreation", "Arts and Entertainment", "Music and Show Venues" ]','17001007',20,'Arts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(186,'[ "Recreation", "Arts and Entertainment", "Museums" ]','17001008',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(187,'[ "Recreation", "Arts and Entertainment", "Movie Theatres" ]','17001009',21,'Movies & DVDs');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(188,'[ "Recreation", "Arts and Entertainment", "Fairgrounds and Rodeos" ]','17001010',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(189,'[ "Recreation", "Arts and Entertainment", "Entertainment" ]','17001011',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(190,'[ "Recreation", "Arts and Entertainment", "Dance Halls and Saloons" ]','17001012',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(191,'[ "Recreation", "Arts and Entertainment", "Circuses and Carnivals" ]','17001013',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(192,'[ "Recreation", "Arts and Entertainment", "Casinos and Gaming" ]','17001014',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(193,'[ "Recreation", "Arts and Entertainment", "Bowling" ]','17001015',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(194,'[ "Recreation", "Arts and Entertainment", "Billiards and Pool" ]','17001016',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(195,'[ "Recreation", "Arts and Entertainment", "Art Dealers and Galleries" ]','17001017',20,'Arts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(196,'[ "Recreation", "Arts and Entertainment", "Arcades and Amusement Parks" ]','17001018',19,'Amusement');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(197,'[ "Recreation", "Arts and Entertainment", "Aquarium" ]','17001019',18,'Entertainment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(198,'[ "Recreation", "Athletic Fields" ]','17002000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(199,'[ "Recreation", "Baseball" ]','17003000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(200,'[ "Recreation", "Basketball" ]','17004000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(201,'[ "Recreation", "Batting Cages" ]','17005000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(202,'[ "Recreation", "Boating" ]','17006000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(203,'[ "Recreation", "Campgrounds and RV Parks" ]','17007000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(204,'[ "Recreation", "Canoes and Kayaks" ]','17008000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(205,'[ "Recreation", "Combat Sports" ]','17009000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(206,'[ "Recreation", "Cycling" ]','17010000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(207,'[ "Recreation", "Dance" ]','17011000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(208,'[ "Recreation", "Equestrian" ]','17012000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(209,'[ "Recreation", "Football" ]','17013000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(210,'[ "Recreation", "Go Carts" ]','17014000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(211,'[ "Recreation", "Golf" ]','17015000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(212,'[ "Recreation", "Gun Ranges" ]','17016000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(213,'[ "Recreation", "Gymnastics" ]','17017000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(214,'[ "Recreation", "Gyms and Fitness Centers" ]','17018000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(215,'[ "Recreation", "Hiking" ]','17019000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(216,'[ "Recreation", "Hockey" ]','17020000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(217,'[ "Recreation", "Hot Air Balloons" ]','17021000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(218,'[ "Recreation", "Hunting and Fishing" ]','17022000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(219,'[ "Recreation", "Landmarks" ]','17023000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(220,'[ "Recreation", "Landmarks", "Monuments and Memorials" ]','17023001',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(221,'[ "Recreation", "Landmarks", "Historic Sites" ]','17023002',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(222,'[ "Recreation", "Landmarks", "Gardens" ]','17023003',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(223,'[ "Recreation", "Landmarks", "Buildings and Structures" ]','17023004',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(224,'[ "Recreation", "Miniature Golf" ]','17024000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(225,'[ "Recreation", "Outdoors" ]','17025000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(226,'[ "Recreation", "Outdoors", "Rivers" ]','17025001',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(227,'[ "Recreation", "Outdoors", "Mountains" ]','17025002',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(228,'[ "Recreation", "Outdoors", "Lakes" ]','17025003',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(229,'[ "Recreation", "Outdoors", "Forests" ]','17025004',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(230,'[ "Recreation", "Outdoors", "Beaches" ]','17025005',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(231,'[ "Recreation", "Paintball" ]','17026000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(232,'[ "Recreation", "Parks" ]','17027000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(233,'[ "Recreation", "Parks", "Playgrounds" ]','17027001',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(234,'[ "Recreation", "Parks", "Picnic Areas" ]','17027002',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(235,'[ "Recreation", "Parks", "Natural Parks" ]','17027003',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(236,'[ "Recreation", "Personal Trainers" ]','17028000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(237,'[ "Recreation", "Race Tracks" ]','17029000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(238,'[ "Recreation", "Racquet Sports" ]','17030000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(239,'[ "Recreation", "Racquetball" ]','17031000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(240,'[ "Recreation", "Rafting" ]','17032000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(241,'[ "Recreation", "Recreation Centers" ]','17033000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(242,'[ "Recreation", "Rock Climbing" ]','17034000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(243,'[ "Recreation", "Running" ]','17035000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(244,'[ "Recreation", "Scuba Diving" ]','17036000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(245,'[ "Recreation", "Skating" ]','17037000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(246,'[ "Recreation", "Skydiving" ]','17038000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(247,'[ "Recreation", "Snow Sports" ]','17039000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(248,'[ "Recreation", "Soccer" ]','17040000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(249,'[ "Recreation", "Sports and Recreation Camps" ]','17041000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(250,'[ "Recreation", "Sports Clubs" ]','17042000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(251,'[ "Recreation", "Stadiums and Arenas" ]','17043000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(252,'[ "Recreation", "Swimming" ]','17044000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(253,'[ "Recreation", "Tennis" ]','17045000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(254,'[ "Recreation", "Water Sports" ]','17046000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(255,'[ "Recreation", "Yoga and Pilates" ]','17047000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(256,'[ "Recreation", "Zoo" ]','17048000',51,'Sports & Recreation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(257,'[ "Service" ]','18000000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(258,'[ "Service", "Advertising and Marketing" ]','18001000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(259,'[ "Service", "Advertising and Marketing", "Writing, Copywriting and Technical Writing" ]','18001001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(260,'[ "Service", "Advertising and Marketing", "Search Engine Marketing and Optimization" ]','18001002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(261,'[ "Service", "Advertising and Marketing", "Public Relations" ]','18001003',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(262,'[ "Service", "Advertising and Marketing", "Promotional Items" ]','18001004',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(263,'[ "Service", "Advertising and Marketing", "Print, TV, Radio and Outdoor Advertising" ]','18001005',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(264,'[ "Service", "Advertising and Marketing", "Online Advertising" ]','18001006',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(265,'[ "Service", "Advertising and Marketing", "Market Research and Consulting" ]','18001007',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(266,'[ "Service", "Advertising and Marketing", "Direct Mail and Email Marketing Services" ]','18001008',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(267,'[ "Service", "Advertising and Marketing", "Creative Services" ]','18001009',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(268,'[ "Service", "Advertising and Marketing", "Advertising Agencies and Media Buyers" ]','18001010',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(269,'[ "Service", "Art Restoration" ]','18003000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(270,'[ "Service", "Audiovisual" ]','18004000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(271,'[ "Service", "Automation and Control Systems" ]','18005000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(272,'[ "Service", "Automotive" ]','18006000',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(273,'[ "Service", "Automotive", "Towing" ]','18006001',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(274,'[ "Service", "Automotive", "Motorcycle, Moped and Scooter Repair" ]','18006002',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(275,'[ "Service", "Automotive", "Maintenance and Repair" ]','18006003',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(276,'[ "Service", "Automotive", "Car Wash and Detail" ]','18006004',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(277,'[ "Service", "Automotive", "Car Appraisers" ]','18006005',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(278,'[ "Service", "Automotive", "Auto Transmission" ]','18006006',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(279,'[ "Service", "Automotive", "Auto Tires" ]','18006007',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(280,'[ "Service", "Automotive", "Auto Smog Check" ]','18006008',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(281,'[ "Service", "Automotive", "Auto Oil and Lube" ]','18006009',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(282,'[ "Service", "Business and Strategy Consulting" ]','18007000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(283,'[ "Service", "Business Services" ]','18008000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(284,'[ "Service", "Business Services", "Printing and Publishing" ]','18008001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(285,'[ "Service", "Cable" ]','18009000',12,'Television');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(286,'[ "Service", "Chemicals and Gasses" ]','18010000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(287,'[ "Service", "Cleaning" ]','18011000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(288,'[ "Service", "Computers" ]','18012000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(289,'[ "Service", "Computers", "Maintenance and Repair" ]','18012001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(290,'[ "Service", "Computers", "Software Development" ]','18012002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(291,'[ "Service", "Construction" ]','18013000',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(292,'[ "Service", "Construction", "Specialty" ]','18013001',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(293,'[ "Service", "Construction", "Roofers" ]','18013002',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(294,'[ "Service", "Construction", "Painting" ]','18013003',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(295,'[ "Service", "Construction", "Masonry" ]','18013004',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(296,'[ "Service", "Construction", "Infrastructure" ]','18013005',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(297,'[ "Service", "Construction", "Heating, Ventilating and Air Conditioning" ]','18013006',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(298,'[ "Service", "Construction", "Electricians" ]','18013007',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(299,'[ "Service", "Construction", "Contractors" ]','18013008',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(300,'[ "Service", "Construction", "Carpet and Flooring" ]','18013009',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(301,'[ "Service", "Construction", "Carpenters" ]','18013010',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(302,'[ "Service", "Credit Counseling and Bankruptcy Services" ]','18014000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(303,'[ "Service", "Dating and Escort" ]','18015000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(304,'[ "Service", "Employment Agencies" ]','18016000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(305,'[ "Service", "Engineering" ]','18017000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(306,'[ "Service", "Entertainment" ]','18018000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(307,'[ "Service", "Entertainment", "Media" ]','18018001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(308,'[ "Service", "Events and Event Planning" ]','18019000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(309,'[ "Service", "Financial" ]','18020000',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(310,'[ "Service", "Financial", "Taxes" ]','18020001',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(311,'[ "Service", "Financial", "Student Aid and Grants" ]','18020002',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(312,'[ "Service", "Financial", "Stock Brokers" ]','18020003',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(313,'[ "Service", "Financial", "Loans and Mortgages" ]','18020004',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(314,'[ "Service", "Financial", "Holding and Investment Offices" ]','18020005',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(315,'[ "Service", "Financial", "Fund Raising" ]','18020006',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(316,'[ "Service", "Financial", "Financial Planning and Investments" ]','18020007',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(317,'[ "Service", "Financial", "Credit Reporting" ]','18020008',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(318,'[ "Service", "Financial", "Collections" ]','18020009',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(319,'[ "Service", "Financial", "Check Cashing" ]','18020010',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(320,'[ "Service", "Financial", "Business Brokers and Franchises" ]','18020011',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(321,'[ "Service", "Financial", "Banking and Finance" ]','18020012',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(322,'[ "Service", "Financial", "ATMs" ]','18020013',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(323,'[ "Service", "Financial", "Accounting and Bookkeeping" ]','18020014',31,'Financial');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(324,'[ "Service", "Food and Beverage" ]','18021000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(325,'[ "Service", "Food and Beverage", "Distribution" ]','18021001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(326,'[ "Service", "Food and Beverage", "Catering" ]','18021002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(327,'[ "Service", "Funeral Services" ]','18022000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(328,'[ "Service", "Geological" ]','18023000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(329,'[ "Service", "Home Improvement" ]','18024000',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(330,'[ "Service", "Home Improvement", "Upholstery" ]','18024001',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(331,'[ "Service", "Home Improvement", "Tree Service" ]','18024002',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(332,'[ "Service", "Home Improvement", "Swimming Pool Maintenance and Services" ]','18024003',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(333,'[ "Service", "Home Improvement", "Storage" ]','18024004',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(334,'[ "Service", "Home Improvement", "Roofers" ]','18024005',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(335,'[ "Service", "Home Improvement", "Pools and Spas" ]','18024006',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(336,'[ "Service", "Home Improvement", "Plumbing" ]','18024007',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(337,'[ "Service", "Home Improvement", "Pest Control" ]','18024008',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(338,'[ "Service", "Home Improvement", "Painting" ]','18024009',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(339,'[ "Service", "Home Improvement", "Movers" ]','18024010',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(340,'[ "Service", "Home Improvement", "Mobile Homes" ]','18024011',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(341,'[ "Service", "Home Improvement", "Lighting Fixtures" ]','18024012',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(342,'[ "Service", "Home Improvement", "Landscaping and Gardeners" ]','18024013',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(343,'[ "Service", "Home Improvement", "Kitchens" ]','18024014',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(344,'[ "Service", "Home Improvement", "Interior Design" ]','18024015',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(345,'[ "Service", "Home Improvement", "Housewares" ]','18024016',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(346,'[ "Service", "Home Improvement", "Home Inspection Services" ]','18024017',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(347,'[ "Service", "Home Improvement", "Home Appliances" ]','18024018',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(348,'[ "Service", "Home Improvement", "Heating, Ventilation and Air Conditioning" ]','18024019',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(349,'[ "Service", "Home Improvement", "Hardware and Services" ]','18024020',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(350,'[ "Service", "Home Improvement", "Fences, Fireplaces and Garage Doors" ]','18024021',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(351,'[ "Service", "Home Improvement", "Electricians" ]','18024022',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(352,'[ "Service", "Home Improvement", "Doors and Windows" ]','18024023',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(353,'[ "Service", "Home Improvement", "Contractors" ]','18024024',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(354,'[ "Service", "Home Improvement", "Carpet and Flooring" ]','18024025',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(355,'[ "Service", "Home Improvement", "Carpenters" ]','18024026',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(356,'[ "Service", "Home Improvement", "Architects" ]','18024027',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(357,'[ "Service", "Household" ]','18025000',52,'Home');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(358,'[ "Service", "Human Resources" ]','18026000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(359,'[ "Service", "Immigration" ]','18027000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(360,'[ "Service", "Import and Export" ]','18028000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(361,'[ "Service", "Industrial Machinery and Vehicles" ]','18029000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(362,'[ "Service", "Insurance" ]','18030000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(363,'[ "Service", "Internet Services" ]','18031000',10,'Internet');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(364,'[ "Service", "Leather" ]','18032000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(365,'[ "Service", "Legal" ]','18033000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(366,'[ "Service", "Logging and Sawmills" ]','18034000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(367,'[ "Service", "Machine Shops" ]','18035000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(368,'[ "Service", "Management" ]','18036000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(369,'[ "Service", "Manufacturing" ]','18037000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(370,'[ "Service", "Manufacturing", "Apparel and Fabric Products" ]','18037001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(371,'[ "Service", "Manufacturing", "Chemicals and Gasses" ]','18037002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(372,'[ "Service", "Manufacturing", "Computers and Office Machines" ]','18037003',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(373,'[ "Service", "Manufacturing", "Electrical Equipment and Components" ]','18037004',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(374,'[ "Service", "Manufacturing", "Food and Beverage" ]','18037005',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(375,'[ "Service", "Manufacturing", "Furniture and Fixtures" ]','18037006',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(376,'[ "Service", "Manufacturing", "Glass Products" ]','18037007',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(377,'[ "Service", "Manufacturing", "Industrial Machinery and Equipment" ]','18037008',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(378,'[ "Service", "Manufacturing", "Leather Goods" ]','18037009',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(379,'[ "Service", "Manufacturing", "Metal Products" ]','18037010',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(380,'[ "Service", "Manufacturing", "Nonmetallic Mineral Products" ]','18037011',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(381,'[ "Service", "Manufacturing", "Paper Products" ]','18037012',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(382,'[ "Service", "Manufacturing", "Petroleum" ]','18037013',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(383,'[ "Service", "Manufacturing", "Plastic Products" ]','18037014',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(384,'[ "Service", "Manufacturing", "Rubber Products" ]','18037015',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(385,'[ "Service", "Manufacturing", "Service Instruments" ]','18037016',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(386,'[ "Service", "Manufacturing", "Textiles" ]','18037017',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(387,'[ "Service", "Manufacturing", "Tobacco" ]','18037018',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(388,'[ "Service", "Manufacturing", "Transportation Equipment" ]','18037019',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(389,'[ "Service", "Manufacturing", "Wood Products" ]','18037020',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(390,'[ "Service", "Media Production" ]','18038000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(391,'[ "Service", "Metals" ]','18039000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(392,'[ "Service", "Mining" ]','18040000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(393,'[ "Service", "Mining", "Coal" ]','18040001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(394,'[ "Service", "Mining", "Metal" ]','18040002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(395,'[ "Service", "Mining", "Non-Metallic Minerals" ]','18040003',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(396,'[ "Service", "News Reporting" ]','18041000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(397,'[ "Service", "Oil and Gas" ]','18042000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(398,'[ "Service", "Packaging" ]','18043000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(399,'[ "Service", "Paper" ]','18044000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(400,'[ "Service", "Personal Care" ]','18045000',79,'Personal Care');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(401,'[ "Service", "Personal Care", "Tattooing" ]','18045001',79,'Personal Care');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(402,'[ "Service", "Personal Care", "Tanning Salons" ]','18045002',82,'Spa & Massage');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(403,'[ "Service", "Personal Care", "Spas" ]','18045003',82,'Spa & Massage');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(404,'[ "Service", "Personal Care", "Skin Care" ]','18045004',79,'Personal Care');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(405,'[ "Service", "Personal Care", "Piercing" ]','18045005',79,'Personal Care');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(406,'[ "Service", "Personal Care", "Massage Clinics and Therapists" ]','18045006',82,'Spa & Massage');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(407,'[ "Service", "Personal Care", "Manicures and Pedicures" ]','18045007',82,'Spa & Massage');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(408,'[ "Service", "Personal Care", "Laundry and Garment Services" ]','18045008',79,'Personal Care');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(409,'[ "Service", "Personal Care", "Hair Salons and Barbers" ]','18045009',80,'Hair');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(410,'[ "Service", "Personal Care", "Hair Removal" ]','18045010',80,'Hair');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(411,'[ "Service", "Petroleum" ]','18046000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(412,'[ "Service", "Photography" ]','18047000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(413,'[ "Service", "Plastics" ]','18048000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(414,'[ "Service", "Rail" ]','18049000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(415,'[ "Service", "Real Estate" ]','18050000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(416,'[ "Service", "Real Estate", "Real Estate Development and Title Companies" ]','18050001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(417,'[ "Service", "Real Estate", "Real Estate Appraiser" ]','18050002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(418,'[ "Service", "Real Estate", "Real Estate Agents" ]','18050003',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(419,'[ "Service", "Real Estate", "Property Management" ]','18050004',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(420,'[ "Service", "Real Estate", "Corporate Housing" ]','18050005',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(421,'[ "Service", "Real Estate", "Commercial Real Estate" ]','18050006',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(422,'[ "Service", "Real Estate", "Building and Land Surveyors" ]','18050007',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(423,'[ "Service", "Real Estate", "Boarding Houses" ]','18050008',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(424,'[ "Service", "Real Estate", "Apartments, Condos and Houses" ]','18050009',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(425,'[ "Service", "Real Estate", "Rent" ]','18050010',59,'Mortgage & Rent');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(426,'[ "Service", "Refrigeration and Ice" ]','18051000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(427,'[ "Service", "Renewable Energy" ]','18052000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(428,'[ "Service", "Repair Services" ]','18053000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(429,'[ "Service", "Research" ]','18054000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(430,'[ "Service", "Rubber" ]','18055000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(431,'[ "Service", "Scientific" ]','18056000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(432,'[ "Service", "Security and Safety" ]','18057000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(433,'[ "Service", "Shipping and Freight" ]','18058000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(434,'[ "Service", "Software Development" ]','18059000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(435,'[ "Service", "Storage" ]','18060000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(436,'[ "Service", "Subscription" ]','18061000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(437,'[ "Service", "Tailors" ]','18062000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(438,'[ "Service", "Telecommunication Services" ]','18063000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(439,'[ "Service", "Textiles" ]','18064000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(440,'[ "Service", "Tourist Information and Services" ]','18065000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(441,'[ "Service", "Transportation" ]','18066000',1,'Auto & Transport');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(442,'[ "Service", "Travel Agents and Tour Operators" ]','18067000',101,'Travel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(443,'[ "Service", "Utilities" ]','18068000',13,'Utilities');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(444,'[ "Service", "Utilities", "Water" ]','18068001',13,'Utilities');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(445,'[ "Service", "Utilities", "Sanitary and Waste Management" ]','18068002',13,'Utilities');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(446,'[ "Service", "Utilities", "Heating, Ventilating, and Air Conditioning" ]','18068003',13,'Utilities');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(447,'[ "Service", "Utilities", "Gas" ]','18068004',13,'Utilities');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(448,'[ "Service", "Utilities", "Electric" ]','18068005',13,'Utilities');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(449,'[ "Service", "Veterinarians" ]','18069000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(450,'[ "Service", "Water and Waste Management" ]','18070000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(451,'[ "Service", "Web Design and Development" ]','18071000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(452,'[ "Service", "Welding" ]','18072000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(453,'[ "Service", "Agriculture and Forestry" ]','18073000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(454,'[ "Service", "Agriculture and Forestry", "Crop Production" ]','18073001',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(455,'[ "Service", "Agriculture and Forestry", "Forestry" ]','18073002',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(456,'[ "Service", "Agriculture and Forestry", "Livestock and Animals" ]','18073003',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(457,'[ "Service", "Agriculture and Forestry", "Services" ]','18073004',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(458,'[ "Service", "Art and Graphic Design" ]','18074000',76,'Misc Expenses');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(459,'[ "Shops" ]','19000000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(460,'[ "Shops", "Adult" ]','19001000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(461,'[ "Shops", "Antiques" ]','19002000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(462,'[ "Shops", "Arts and Crafts" ]','19003000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(463,'[ "Shops", "Auctions" ]','19004000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(464,'[ "Shops", "Automotive" ]','19005000',1,'Auto & Transport');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(465,'[ "Shops", "Automotive", "Used Car Dealers" ]','19005001',3,'Auto Payment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(466,'[ "Shops", "Automotive", "Salvage Yards" ]','19005002',1,'Auto & Transport');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(467,'[ "Shops", "Automotive", "RVs and Motor Homes" ]','19005003',1,'Auto & Transport');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(468,'[ "Shops", "Automotive", "Motorcycles, Mopeds and Scooters" ]','19005004',1,'Auto & Transport');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(469,'[ "Shops", "Automotive", "Classic and Antique Car" ]','19005005',1,'Auto & Transport');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(470,'[ "Shops", "Automotive", "Car Parts and Accessories" ]','19005006',7,'Service & Parts');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(471,'[ "Shops", "Automotive", "Car Dealers and Leasing" ]','19005007',3,'Auto Payment');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(472,'[ "Shops", "Beauty Products" ]','19006000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(473,'[ "Shops", "Bicycles" ]','19007000',91,'Sporting Goods');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(474,'[ "Shops", "Boat Dealers" ]','19008000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(475,'[ "Shops", "Bookstores" ]','19009000',86,'Books');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(476,'[ "Shops", "Cards and Stationery" ]','19010000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(477,'[ "Shops", "Children" ]','19011000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(478,'[ "Shops", "Clothing and Accessories" ]','19012000',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(479,'[ "Shops", "Clothing and Accessories", "Women''s Store" ]','19012001',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(480,'[ "Shops", "Clothing and Accessories", "Swimwear" ]','19012002',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(481,'[ "Shops", "Clothing and Accessories", "Shoe Store" ]','19012003',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(482,'[ "Shops", "Clothing and Accessories", "Men''s Store" ]','19012004',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(483,'[ "Shops", "Clothing and Accessories", "Lingerie Store" ]','19012005',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(484,'[ "Shops", "Clothing and Accessories", "Kids'' Store" ]','19012006',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(485,'[ "Shops", "Clothing and Accessories", "Boutique" ]','19012007',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(486,'[ "Shops", "Clothing and Accessories", "Accessories Store" ]','19012008',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(487,'[ "Shops", "Computers and Electronics" ]','19013000',89,'Electronics & Software');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(488,'[ "Shops", "Computers and Electronics", "Video Games" ]','19013001',89,'Electronics & Software');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(489,'[ "Shops", "Computers and Electronics", "Mobile Phones" ]','19013002',89,'Electronics & Software');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(490,'[ "Shops", "Computers and Electronics", "Cameras" ]','19013003',89,'Electronics & Software');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(491,'[ "Shops", "Construction Supplies" ]','19014000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(492,'[ "Shops", "Convenience Stores" ]','19015000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(493,'[ "Shops", "Costumes" ]','19016000',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(494,'[ "Shops", "Dance and Music" ]','19017000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(495,'[ "Shops", "Department Stores" ]','19018000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(496,'[ "Shops", "Digital Purchase" ]','19019000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(497,'[ "Shops", "Discount Stores" ]','19020000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(498,'[ "Shops", "Electrical Equipment" ]','19021000',89,'Electronics & Software');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(499,'[ "Shops", "Equipment Rental" ]','19022000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(500,'[ "Shops", "Flea Markets" ]','19023000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(501,'[ "Shops", "Florists" ]','19024000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(502,'[ "Shops", "Food and Beverage Store" ]','19025000',38,'Groceries');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(503,'[ "Shops", "Food and Beverage Store", "Specialty" ]','19025001',38,'Groceries');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(504,'[ "Shops", "Food and Beverage Store", "Health Food" ]','19025002',38,'Groceries');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(505,'[ "Shops", "Food and Beverage Store", "Farmers Markets" ]','19025003',38,'Groceries');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(506,'[ "Shops", "Food and Beverage Store", "Beer, Wine and Spirits" ]','19025004',38,'Groceries');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(507,'[ "Shops", "Fuel Dealer" ]','19026000',4,'Gas & Fuel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(508,'[ "Shops", "Furniture and Home Decor" ]','19027000',53,'Furnishings');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(509,'[ "Shops", "Gift and Novelty" ]','19028000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(510,'[ "Shops", "Glasses and Optometrist" ]','19029000',47,'Eyecare');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(511,'[ "Shops", "Hardware Store" ]','19030000',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(512,'[ "Shops", "Hobby and Collectibles" ]','19031000',90,'Hobbies');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(513,'[ "Shops", "Industrial Supplies" ]','19032000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(514,'[ "Shops", "Jewelry and Watches" ]','19033000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(515,'[ "Shops", "Luggage" ]','19034000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(516,'[ "Shops", "Marine Supplies" ]','19035000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(517,'[ "Shops", "Music, Video and DVD" ]','19036000',21,'Movies & DVDs');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(518,'[ "Shops", "Musical Instruments" ]','19037000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(519,'[ "Shops", "Newsstands" ]','19038000',23,'Newspapers & Magazines');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(520,'[ "Shops", "Office Supplies" ]','19039000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(521,'[ "Shops", "Outlet" ]','19040000',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(522,'[ "Shops", "Outlet", "Women''s Store" ]','19040001',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(523,'[ "Shops", "Outlet", "Swimwear" ]','19040002',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(524,'[ "Shops", "Outlet", "Shoe Store" ]','19040003',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(525,'[ "Shops", "Outlet", "Men''s Store" ]','19040004',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(526,'[ "Shops", "Outlet", "Lingerie Store" ]','19040005',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(527,'[ "Shops", "Outlet", "Kids'' Store" ]','19040006',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(528,'[ "Shops", "Outlet", "Boutique" ]','19040007',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(529,'[ "Shops", "Outlet", "Accessories Store" ]','19040008',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(530,'[ "Shops", "Pawn Shops" ]','19041000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(531,'[ "Shops", "Pets" ]','19042000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(532,'[ "Shops", "Pharmacies" ]','19043000',50,'Pharmacy');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(533,'[ "Shops", "Photos and Frames" ]','19044000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(534,'[ "Shops", "Shopping Centers and Malls" ]','19045000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(535,'[ "Shops", "Sporting Goods" ]','19046000',91,'Sporting Goods');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(536,'[ "Shops", "Supermarkets and Groceries" ]','19047000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(537,'[ "Shops", "Tobacco" ]','19048000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(538,'[ "Shops", "Toys" ]','19049000',75,'Toys');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(539,'[ "Shops", "Vintage and Thrift" ]','19050000',87,'Clothing');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(540,'[ "Shops", "Warehouses and Wholesale Stores" ]','19051000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(541,'[ "Shops", "Wedding and Bridal" ]','19052000',78,'Wedding');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(542,'[ "Shops", "Wholesale" ]','19053000',83,'Shopping');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(543,'[ "Shops", "Lawn and Garden" ]','19054000',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(544,'[ "Tax" ]','20000000',92,'Taxes');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(545,'[ "Tax", "Refund" ]','20001000',92,'Taxes');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(546,'[ "Tax", "Payment" ]','20002000',92,'Taxes');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(547,'[ "Transfer" ]','21000000',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(548,'[ "Transfer", "Internal Account Transfer" ]','21001000',98,'Transfer');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(549,'[ "Transfer", "ACH" ]','21002000',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(550,'[ "Transfer", "Billpay" ]','21003000',8,'Bills & Utilities');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(551,'[ "Transfer", "Check" ]','21004000',108,'Check');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(552,'[ "Transfer", "Credit" ]','21005000',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(553,'[ "Transfer", "Debit" ]','21006000',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(554,'[ "Transfer", "Deposit" ]','21007000',61,'Income');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(555,'[ "Transfer", "Deposit", "Check" ]','21007001',61,'Income');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(556,'[ "Transfer", "Deposit", "ATM" ]','21007002',61,'Income');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(557,'[ "Transfer", "Keep the Change Savings Program" ]','21008000',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(558,'[ "Transfer", "Payroll" ]','21009000',64,'Paycheck');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(559,'[ "Transfer", "Payroll", "Benefits" ]','21009001',61,'Income');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(560,'[ "Transfer", "Third Party" ]','21010000',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(561,'[ "Transfer", "Third Party", "Venmo" ]','21010001',77,'Venmo expense');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(562,'[ "Transfer", "Third Party", "Square Cash" ]','21010002',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(563,'[ "Transfer", "Third Party", "Square" ]','21010003',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(564,'[ "Transfer", "Third Party", "PayPal" ]','21010004',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(565,'[ "Transfer", "Third Party", "Dwolla" ]','21010005',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(566,'[ "Transfer", "Third Party", "Coinbase" ]','21010006',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(567,'[ "Transfer", "Third Party", "Chase QuickPay" ]','21010007',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(568,'[ "Transfer", "Third Party", "Acorns" ]','21010008',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(569,'[ "Transfer", "Third Party", "Digit" ]','21010009',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(570,'[ "Transfer", "Third Party", "Betterment" ]','21010010',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(571,'[ "Transfer", "Third Party", "Plaid" ]','21010011',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(572,'[ "Transfer", "Wire" ]','21011000',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(573,'[ "Transfer", "Withdrawal" ]','21012000',107,'Cash & ATM');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(574,'[ "Transfer", "Withdrawal", "Check" ]','21012001',108,'Check');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(575,'[ "Transfer", "Withdrawal", "ATM" ]','21012002',107,'Cash & ATM');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(576,'[ "Transfer", "Save As You Go" ]','21013000',106,'Uncategorized');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(577,'[ "Travel" ]','22000000',101,'Travel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(578,'[ "Travel", "Airlines and Aviation Services" ]','22001000',102,'Air Travel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(579,'[ "Travel", "Airports" ]','22002000',102,'Air Travel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(580,'[ "Travel", "Boat" ]','22003000',101,'Travel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(581,'[ "Travel", "Bus Stations" ]','22004000',6,'Public Transportation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(582,'[ "Travel", "Car and Truck Rentals" ]','22005000',104,'Rental Car & Taxi');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(583,'[ "Travel", "Car Service" ]','22006000',104,'Rental Car & Taxi');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(584,'[ "Travel", "Car Service", "Ride Share" ]','22006001',104,'Rental Car & Taxi');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(585,'[ "Travel", "Charter Buses" ]','22007000',104,'Rental Car & Taxi');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(586,'[ "Travel", "Cruises" ]','22008000',101,'Travel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(587,'[ "Travel", "Gas Stations" ]','22009000',4,'Gas & Fuel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(588,'[ "Travel", "Heliports" ]','22010000',101,'Travel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(589,'[ "Travel", "Limos and Chauffeurs" ]','22011000',104,'Rental Car & Taxi');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(590,'[ "Travel", "Lodging" ]','22012000',103,'Hotel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(591,'[ "Travel", "Lodging", "Resorts" ]','22012001',105,'Vacation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(592,'[ "Travel", "Lodging", "Lodges and Vacation Rentals" ]','22012002',105,'Vacation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(593,'[ "Travel", "Lodging", "Hotels and Motels" ]','22012003',103,'Hotel');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(594,'[ "Travel", "Lodging", "Hostels" ]','22012004',105,'Vacation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(595,'[ "Travel", "Lodging", "Cottages and Cabins" ]','22012005',105,'Vacation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(596,'[ "Travel", "Lodging", "Bed and Breakfasts" ]','22012006',105,'Vacation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(597,'[ "Travel", "Parking" ]','22013000',5,'Parking');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(598,'[ "Travel", "Public Transportation Services" ]','22014000',6,'Public Transportation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(599,'[ "Travel", "Rail" ]','22015000',6,'Public Transportation');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(600,'[ "Travel", "Taxi" ]','22016000',104,'Rental Car & Taxi');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(601,'[ "Travel", "Tolls and Fees" ]','22017000',1,'Auto & Transport');
-INSERT OR IGNORE INTO plaid__categories (id, hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES(602,'[ "Travel", "Transportation Centers" ]','22018000',1,'Auto & Transport');
-
-
-CREATE TABLE IF NOT EXISTS `salt_edge__categories` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `top_category` TEXT, `sub_category` TEXT, `bottom_category` TEXT, `link_to_app_cat` INTEGER, `app_cat_name` TEXT, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
-CREATE TRIGGER IF NOT EXISTS UpdateLastTime6 UPDATE ON salt_edge__categories
-BEGIN
-    UPDATE salt_edge__categories SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
-END;
-
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(1,'personal','auto_and_transport','car_rental',1,'Auto & Transport');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(2,'personal','auto_and_transport','gas_and_fuel',4,'Gas & Fuel');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(3,'personal','auto_and_transport','parking',5,'Parking');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(4,'personal','auto_and_transport','public_transportation',6,'Public Transportation');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(5,'personal','auto_and_transport','service_and_parts',7,'Service & Parts');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(6,'personal','auto_and_transport','taxi',1,'Auto & Transport');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(7,'personal','bills_and_utilities','internet',10,'Internet');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(8,'personal','bills_and_utilities','phone',11,'Mobile Phone');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(9,'personal','bills_and_utilities','television',12,'Television');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(10,'personal','bills_and_utilities','utilities',13,'Utilities');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(11,'personal','business_services','advertising',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(12,'personal','business_services','office_supplies',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(13,'personal','business_services','shipping',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(14,'personal','education','books_and_supplies',15,'Books & Supplies');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(15,'personal','education','student_loan',16,'Student Loan');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(16,'personal','education','tuition',17,'Tuition');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(17,'personal','entertainment','amusement',19,'Amusement');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(18,'personal','entertainment','arts',20,'Arts');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(19,'personal','entertainment','games',18,'Entertainment');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(20,'personal','entertainment','movies_and_music',21,'Movies & DVDs');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(21,'personal','entertainment','newspapers_and_magazines',23,'Newspapers & Magazines');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(22,'personal','fees_and_charges','provider_fee',24,'Fees & Charges');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(23,'personal','fees_and_charges','loans',24,'Fees & Charges');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(24,'personal','fees_and_charges','service_fee',29,'Service Fee');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(25,'personal','fees_and_charges','taxes',92,'Taxes');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(26,'personal','food_and_dining','alcohol_and_bars',36,'Alcohol & Bars');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(27,'personal','food_and_dining','cafes_and_restaurants',39,'Restaurants');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(28,'personal','food_and_dining','groceries',39,'Restaurants');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(29,'personal','gifts_and_donations','charity',41,'Charity');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(30,'personal','gifts_and_donations','gifts',42,'Gifts');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(31,'personal','health_and_fitness','doctor',46,'Doctor');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(32,'personal','health_and_fitness','personal_care',79,'Personal Care');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(33,'personal','health_and_fitness','pharmacy',50,'Pharmacy');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(34,'personal','health_and_fitness','sports',51,'Sports & Recreation');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(35,'personal','health_and_fitness','wellness',43,'Health & Fitness');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(36,'personal','home','home_improvement',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(37,'personal','home','home_services',54,'Home Improvement & Services');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(38,'personal','home','home_supplies',57,'Home Supplies');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(39,'personal','home','mortgage',59,'Mortgage & Rent');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(40,'personal','home','rent',59,'Mortgage & Rent');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(41,'personal','income','bonus',62,'Bonus');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(42,'personal','income','investment_income',63,'Interest/Cap Gain/Dividend');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(43,'personal','income','paycheck',64,'Paycheck');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(44,'personal','insurance','car_insurance',2,'Auto Insurance');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(45,'personal','insurance','health_insurance',49,'Health Insurance');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(46,'personal','insurance','life_insurance',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(47,'personal','insurance','property_insurance',55,'Home Insurance');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(48,'personal','kids','allowance',71,'Allowance');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(49,'personal','kids','babysitter_and_daycare',73,'Babysitter & Daycare');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(50,'personal','kids','baby_supplies',72,'Baby Supplies');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(51,'personal','kids','child_support',70,'Kids');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(52,'personal','kids','kids_activities',74,'Kids Activities');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(53,'personal','kids','toys',75,'Toys');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(54,'personal','pets','pet_food_and_supplies',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(55,'personal','pets','pet_grooming',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(56,'personal','pets','veterinary',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(57,'personal','shopping','clothing',87,'Clothing');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(58,'personal','shopping','electronics_and_software',89,'Electronics & Software');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(59,'personal','shopping','sporting_goods',91,'Sporting Goods');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(60,'personal','travel','hotel',103,'Hotel');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(61,'personal','travel','transportation',101,'Travel');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(62,'personal','travel','vacation',105,'Vacation');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(63,'business','cost_of_goods','merchandise',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(64,'business','cost_of_goods','raw_materials',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(65,'business','financials','dividends',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(66,'business','financials','donations',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(67,'business','financials','fees',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(68,'business','financials','fines',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(69,'business','financials','interest',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(70,'business','human_resources','bonus',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(71,'business','human_resources','education_and_trainings',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(72,'business','human_resources','social_security',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(73,'business','human_resources','staff_outsourcing',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(74,'business','human_resources','wages',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(75,'business','income','investment',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(76,'business','income','returns',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(77,'business','income','sales',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(78,'business','insurance','business_insurance',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(79,'business','insurance','equipment_insurance',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(80,'business','insurance','health_insurance',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(81,'business','insurance','liability_insurance',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(82,'business','insurance','professional_insurance',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(83,'business','office','equipment',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(84,'business','office','office_rent',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(85,'business','office','office_supplies',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(86,'business','office','software',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(87,'business','services','accounting',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(88,'business','services','consultancy',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(89,'business','services','contractors',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(90,'business','services','legal',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(91,'business','services','marketing',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(92,'business','services','online_subscriptions',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(93,'business','services','storage',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(94,'business','taxes','duty_taxes',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(95,'business','taxes','federal_taxes',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(96,'business','taxes','income_taxes',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(97,'business','taxes','property_taxes',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(98,'business','taxes','tax_return',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(99,'business','taxes','vat',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(100,'business','transport','gas_and_fuel',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(101,'business','transport','leasing',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(102,'business','transport','shipping',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(103,'business','transport','taxi',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(104,'business','utilities','electricity',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(105,'business','utilities','gas',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(106,'business','utilities','internet',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(107,'business','utilities','phone',76,'Misc Expenses');
-INSERT OR IGNORE INTO salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(108,'business','utilities','water',76,'Misc Expenses');
--- CREATE TABLE IF NOT EXISTS `transactions` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `date` DATE, `transaction_id` VARCHAR(255) UNIQUE, `description` TEXT, `original_description` TEXT DEFAULT '', `amount` NUMERIC DEFAULT 0, `normalized_amount` NUMERIC DEFAULT 0, `transaction_type` TEXT DEFAULT '', `category` INTEGER, `category_name` TEXT, `account_name` TEXT, `currency_code` VARCHAR(255), `account_id` VARCHAR(255), `labels` TEXT DEFAULT '', `notes` TEXT DEFAULT '', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
-CREATE TABLE IF NOT EXISTS `transactions` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `date` DATE, `transaction_id` VARCHAR(255) UNIQUE, `description` TEXT, `amount` NUMERIC DEFAULT 0, `normalized_amount` NUMERIC DEFAULT 0, `category` INTEGER, `category_name` TEXT, `account_name` TEXT, `currency_code` VARCHAR(255), `account_id` VARCHAR(255), `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
-CREATE INDEX IF NOT EXISTS tx_date ON `transactions` (`date`);
-CREATE TRIGGER IF NOT EXISTS UpdateLastTime7 UPDATE ON transactions
-BEGIN
-    UPDATE transactions SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
-END;
-CREATE TABLE IF NOT EXISTS `analysis_trees` (`name` STRING PRIMARY KEY, `first_date` STRING, `last_date` STRING, `data` STRING DEFAULT '', `data_no_invest` STRING DEFAULT '', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
-
-CREATE TRIGGER IF NOT EXISTS UpdateLastTime8 UPDATE ON analysis_trees
-BEGIN
-    UPDATE analysis_trees SET updated_at=CURRENT_TIMESTAMP WHERE name=name;
-END;
-COMMIT;
\ No newline at end of file
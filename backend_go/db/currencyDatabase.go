@@ -5,12 +5,12 @@ import (
 	"bytes"
 	"compress/gzip"
 	"database/sql"
+	_ "embed"
 	"fmt"
 	"io/ioutil"
 
 	"log"
 	"net/http"
-	"os"
 	"strings"
 
 	"time"
@@ -18,7 +18,6 @@ import (
 	"fin-go/types"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/rickb777/date"
 	"github.com/shopspring/decimal"
 	xmlparser "github.com/tamerh/xml-stream-parser"
@@ -26,24 +25,18 @@ import (
 
 var CurrencyDBCon *sqlx.DB
 
-// CreateCurrencyDatabase starts currency database and loads it with initial info from the XML
+// currencyInitial is the ECB rate history up to when it was last bundled, the
+// rest is downloaded by GetNewXML
+//go:embed currencyInitial.xml.gz
+var currencyInitial []byte
+
+// CreateCurrencyDatabase loads the currency database (connected by
+// CreateDatabase) with initial info from the XML
 func CreateCurrencyDatabase() (*sqlx.DB, error) {
 
 	start := time.Now()
 
-	var err error
-	CurrencyDBCon, err = sqlx.Open("sqlite3", "/usr/src/app/db/currencyData.sqlite")
-	if err != nil {
-		log.Fatalln(err)
-	}
-
-	raw2, err := os.Open("/usr/src/app/backend_go/db/currencyInitial.xml.gz")
-	if err != nil {
-		log.Fatalln(err)
-	}
-	defer raw2.Close()
-
-	fz, err := gzip.NewReader(raw2)
+	fz, err := gzip.NewReader(bytes.NewReader(currencyInitial))
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -66,12 +59,12 @@ func insertXMLData(bytesXML []byte, isInitialLoad bool) {
 	txn := CurrencyDBCon.MustBegin()
 
 	tblStr1 := `CREATE TABLE IF NOT EXISTS `
-	tblStr2 := ` (fx_date DATE PRIMARY KEY, rate STRING);`
+	tblStr2 := ` (fx_date DATE PRIMARY KEY, rate NUMERIC);`
 	var upsertStr1 string
 	var upsertStr2 string
 	if isInitialLoad {
-		upsertStr1 = `INSERT OR IGNORE INTO `
-		upsertStr2 = ` (fx_date, rate) VALUES($1,$2);`
+		upsertStr1 = `INSERT INTO `
+		upsertStr2 = ` (fx_date, rate) VALUES($1,$2) ON CONFLICT (fx_date) DO NOTHING;`
 	} else {
 		upsertStr1 = "INSERT INTO "
 		upsertStr2 = "(fx_date, rate) VALUES($1, $2) ON CONFLICT (fx_date) DO UPDATE SET rate = excluded.rate"
@@ -89,11 +82,11 @@ func insertXMLData(bytesXML []byte, isInitialLoad bool) {
 			for _, fxKey := range xml.Childs["generic:SeriesKey"][0].Childs["generic:Value"] {
 				if fxKey.Attrs["id"] == "CURRENCY" {
 					curr = fxKey.Attrs["value"]
-					txn.MustExec(tblStr1 + curr + tblStr2)
+					txn.MustExec(tblStr1 + quoteIdent(curr) + tblStr2)
 					break
 				}
 			}
-			txStr := upsertStr1 + curr + upsertStr2
+			txStr := upsertStr1 + quoteIdent(curr) + upsertStr2
 			fxSt := types.PrepFXTableSt(txn, txStr)
 
 			for _, fx := range xml.Childs["generic:Obs"] {
@@ -127,19 +120,23 @@ func isNumDot(s string) bool {
 	return true
 }
 
-func GetNewXML() {
+// LatestRateDate is the date of the newest rates in the currency DB
+func LatestRateDate() (time.Time, error) {
+	var fx types.Fx
+	err := CurrencyDBCon.Get(&fx, `SELECT * FROM "USD" ORDER BY fx_date DESC LIMIT 1`)
+	return fx.FxDate, err
+}
+
+// GetNewXML pulls the rates published since the newest ones in the currency
+// DB from the ECB. Errors are logged as well as returned, most callers carry
+// on with the rates they have.
+func GetNewXML() error {
 
 	//Get last updated date from fx data for search (using USD)
-	rows, err := CurrencyDBCon.Queryx("SELECT * FROM `USD` ORDER BY fx_date DESC LIMIT 1")
-	if err != nil {
-		panic(err)
-	}
 	var fx types.Fx
-	for rows.Next() {
-		err = rows.StructScan(&fx)
-		if err != nil {
-			panic(err)
-		}
+	var err error
+	if fx.FxDate, err = LatestRateDate(); err != nil && err != sql.ErrNoRows {
+		panic(err)
 	}
 	utc := time.Now().UTC()
 	daysDiff := utc.Sub(fx.FxDate).Hours() / 24
@@ -167,21 +164,23 @@ func GetNewXML() {
 		if err != nil {
 			xmlerr := fmt.Errorf("GET error: %v", err)
 			log.Println(xmlerr.Error())
+			return xmlerr
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
 			xmlerr := fmt.Errorf("Status error: %v", resp.StatusCode)
 			if resp.StatusCode == 404 {
-				return
+				// Nothing published since the last pull
+				return nil
 			}
 			if resp.StatusCode == 500 {
 				log.Println("500 Server error - ECB SDMX")
-				return
+				return xmlerr
 			}
 			if resp.StatusCode == 503 {
 				log.Println("503 Server temporarily unavailable - ECB SDMX")
-				return
+				return xmlerr
 			}
 			log.Println(xmlerr.Error())
 		}
@@ -189,12 +188,14 @@ func GetNewXML() {
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			log.Println("Read error:", err)
+			return err
 		}
 		insertXMLData(body, false)
 		log.Println("Data pulled and inserted from ECB API in: ", time.Since(start))
 
 	}
 
+	return nil
 }
 
 //GetNormalizedAmount finds and sets the correct normalized amount for transactions
@@ -208,29 +209,28 @@ func GetNormalizedAmount(code string, baseCurrency string, dt string, amt decima
 		panic(err)
 	}
 
-	var id int
-	err = CurrencyDBCon.Get(&id, "SELECT count(*) FROM sqlite_master WHERE type='table' AND name='"+CC+"'")
+	found, err := Storage.TableExists(CurrencyDBCon, CC)
 	if err != nil {
 		panic(err)
 	}
 	// want to continue if currency is EUR since there is no table in DB for it
 	if CC == "EUR" {
-		id = 1
+		found = true
 	}
 	// Setting to zero and skipping if table not found for currency (i.e. BTC)
-	if id == 0 {
+	if !found {
 		log.Println("Currency rate table not found for " + CC)
 		NormalizedAmount = decimal.Zero
 	} else {
 		firstDate := tdate.AddDate(0, 0, -10)
 		lastDate := tdate.AddDate(0, 0, 10)
 		fx := types.Fx{}
+		nearest := `SELECT * FROM %s WHERE fx_date BETWEEN $1 AND $2 ORDER BY ` + Storage.DaysApart("fx_date", "$3") + ` LIMIT 1`
 		if CC == "EUR" {
 			// finding the nearest 'EUR' rate by doing a search with USD and swapping in 1.0 for rate
 			CC = "USD"
-			query := fmt.Sprintf(`SELECT * FROM %q WHERE fx_date BETWEEN 
-					%q AND %q ORDER BY abs(%q - fx_date) LIMIT 1`, CC, firstDate, lastDate, tdate.Format("2006-01-02"))
-			err := CurrencyDBCon.Get(&fx, query)
+			query := fmt.Sprintf(nearest, quoteIdent(CC))
+			err := CurrencyDBCon.Get(&fx, query, firstDate.String(), lastDate.String(), tdate.String())
 			CC = "EUR"
 			if err != nil && err != sql.ErrNoRows {
 				panic(err)
@@ -238,9 +238,8 @@ func GetNormalizedAmount(code string, baseCurrency string, dt string, amt decima
 			fx.Rate = decimal.NewFromInt(1)
 		} else {
 			// otherwise finding the nearest rate in +/- 10 days
-			query := fmt.Sprintf(`SELECT * FROM %q WHERE fx_date BETWEEN 
-					%q AND %q ORDER BY abs(%q - fx_date) LIMIT 1`, CC, firstDate, lastDate, tdate.Format("2006-01-02"))
-			err := CurrencyDBCon.Get(&fx, query)
+			query := fmt.Sprintf(nearest, quoteIdent(CC))
+			err := CurrencyDBCon.Get(&fx, query, firstDate.String(), lastDate.String(), tdate.String())
 			if err != nil && err != sql.ErrNoRows {
 				panic(err)
 			}
@@ -256,8 +255,8 @@ func GetNormalizedAmount(code string, baseCurrency string, dt string, amt decima
 			} else {
 				// Finding second rate for base currency other than EUR
 				bfx := types.Fx{}
-				query := fmt.Sprintf(`SELECT * FROM %q WHERE fx_date = %q`, baseCurrency, fx.FxDate.Format("2006-01-02"))
-				err := CurrencyDBCon.Get(&bfx, query)
+				query := fmt.Sprintf(`SELECT * FROM %s WHERE fx_date = $1`, quoteIdent(baseCurrency))
+				err := CurrencyDBCon.Get(&bfx, query, fx.FxDate.Format("2006-01-02"))
 				if err != nil && err != sql.ErrNoRows {
 					panic(err)
 				}
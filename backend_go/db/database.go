@@ -1,32 +1,45 @@
 package db
 
 import (
-
-	"io/ioutil"
+	"fin-go/config"
+	"fin-go/secrets"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/mattn/go-sqlite3"
 )
 
 var DBCon *sqlx.DB
 
-func CreateDatabase() (*sqlx.DB, error) {
+// CreateDatabase loads the access token key, connects to the store picked by
+// the config (opening the currency database too), brings its schema up to
+// date and keeps the backup settings for later
+func CreateDatabase(cfg *config.Config) (*sqlx.DB, error) {
 
-	var err error
-	DBCon, err = sqlx.Open("sqlite3", "/usr/src/app/db/data-go.sqlite")
-	if err != nil {
+	backupConfig = cfg.Backups
+	if err := secrets.Init(cfg.Secrets); err != nil {
 		return nil, err
 	}
-	DBCon.Exec("PRAGMA journal_mode=WAL;")
-
-	raw, err := ioutil.ReadFile("/usr/src/app/backend_go/db/create.sql")
-	query := string(raw)
-	if err != nil {
+	if err := OpenStorage(cfg.Database); err != nil {
 		return nil, err
 	}
-	if _, err := DBCon.Exec(query); err != nil {
+
+	if err := InitSchema(); err != nil {
 		return nil, err
 	}
 
 	return DBCon, nil
 }
+
+// InitSchema applies any pending migrations and seed data, at startup and
+// again after a restore or reset
+func InitSchema() error {
+
+	if err := Migrate(); err != nil {
+		return err
+	}
+
+	if err := Seed(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+// ImportContentKey identifies an imported transaction by its content. The
+// description is compared case and whitespace insensitively, the date is the
+// ISO date and the amount is signed.
+func ImportContentKey(accountID, date string, amount decimal.Decimal, description string) string {
+	if len(date) > 10 {
+		date = date[:10]
+	}
+	desc := strings.Join(strings.Fields(strings.ToLower(description)), " ")
+	return strings.Join([]string{accountID, date, amount.String(), desc}, "\x1f")
+}
+
+// ImportTransactionID derives the transaction ID of an imported row from its
+// content key and how many identical rows came before it in the same file, so
+// importing the same or an overlapping export again yields the same IDs
+func ImportTransactionID(contentKey string, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x1f%d", contentKey, occurrence)))
+	return "import-" + hex.EncodeToString(sum[:16])
+}
+
+// migrateImportTransactionIDs replaces the random numeric IDs that imports
+// used to assign with content-hash IDs. Numeric IDs in Plaid accounts are
+// rewritten as well: imports used to add rows to existing accounts, Plaid ones
+// included, and Plaid's own IDs are never numeric, so such a row can only have
+// come from an import. Rows in SaltEdge accounts are left as they are, since
+// SaltEdge's own IDs are numeric too and can't be told apart.
+func migrateImportTransactionIDs(txn *sqlx.Tx) error {
+	rows := []struct {
+		ID            int             `db:"id"`
+		TransactionID string          `db:"transaction_id"`
+		Date          string          `db:"date"`
+		Description   string          `db:"description"`
+		Amount        decimal.Decimal `db:"amount"`
+		AccountID     string          `db:"account_id"`
+	}{}
+	err := txn.Select(&rows, `SELECT t.id, t.transaction_id, CAST(t.date AS TEXT) AS date, COALESCE(t.description, '') AS description, t.amount, t.account_id
+		FROM transactions t JOIN accounts a ON a.account_id = t.account_id
+		WHERE a.provider IN ('Import', 'Plaid')
+		ORDER BY t.id`)
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	occurrences := map[string]int{}
+	for _, row := range rows {
+		if !isNumericID(row.TransactionID) {
+			continue
+		}
+		key := ImportContentKey(row.AccountID, row.Date, row.Amount, row.Description)
+		newID := ImportTransactionID(key, occurrences[key])
+		occurrences[key]++
+		if _, err := txn.Exec(`UPDATE transactions SET transaction_id = $1 WHERE id = $2`, newID, row.ID); err != nil {
+			return err
+		}
+		migrated++
+	}
+
+	if migrated > 0 {
+		log.Printf("Migrated %d imported transactions to content-hash IDs", migrated)
+	}
+	return nil
+}
+
+// isNumericID reports whether a transaction ID is one of the old random ones
+func isNumericID(id string) bool {
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
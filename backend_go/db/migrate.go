@@ -0,0 +1,130 @@
+package db
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"log"
+
+	"fin-go/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*/*.sql seeds/*.sql reset/*/*.sql
+var sqlFiles embed.FS
+
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of fin")
+
+// migration is one step of the schema, either an SQL file (one per dialect,
+// in migrations/<dialect>/) or a Go function for changes that need more than SQL
+type migration struct {
+	version int
+	name    string
+	file    string
+	run     func(*sqlx.Tx) error
+}
+
+// migrations are applied in order and never changed once released; a schema
+// change is a new migration at the end
+var migrations = []migration{
+	{version: 1, name: "initial schema", file: "0001_initial_schema.sql"},
+	{version: 2, name: "content-hash IDs for imported transactions", run: migrateImportTransactionIDs},
+	{version: 3, name: "seed data tracking", file: "0003_seed_tracking.sql"},
+	{version: 4, name: "users, sessions and API tokens", file: "0004_auth.sql"},
+	{version: 5, name: "households: ownership and sharing of accounts", file: "0005_households.sql"},
+	{version: 6, name: "user roles", file: "0006_roles.sql"},
+	{version: 7, name: "encrypt provider access tokens", run: migrateSealTokens},
+	{version: 8, name: "sync cursors for provider items", file: "0008_sync_cursor.sql"},
+	{version: 9, name: "Plaid transactions sync cursors", file: "0009_plaid_sync_cursor.sql"},
+	{version: 10, name: "pending transactions", file: "0010_pending_transactions.sql"},
+}
+
+// SchemaVersion is the newest migration this build knows. Databases (and
+// backups) from an older version are migrated, newer ones are refused.
+var SchemaVersion = migrations[len(migrations)-1].version
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY, name TEXT, applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP)`
+
+// schemaVersion returns the newest migration applied to a database, 0 for one
+// from before migrations were tracked
+func schemaVersion(con sqlx.Queryer) (int, error) {
+	version := 0
+	tracked, err := Storage.TableExists(con, "schema_migrations")
+	if err != nil || !tracked {
+		return version, err
+	}
+	err = sqlx.Get(con, &version, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations")
+	return version, err
+}
+
+// Migrate brings the data DB up to SchemaVersion, each migration in its own
+// transaction together with its row in schema_migrations
+func Migrate() error {
+	if _, err := DBCon.Exec(createMigrationsTable); err != nil {
+		return err
+	}
+	current, err := schemaVersion(DBCon)
+	if err != nil {
+		return err
+	}
+	if current > SchemaVersion {
+		return fmt.Errorf("%w: it is at version %d, this build only knows up to %d", ErrSchemaTooNew, current, SchemaVersion)
+	}
+	// SQLite databases from before migrations already have most of the initial
+	// schema; Postgres support came later, so those always start out empty
+	legacy := false
+	if current == 0 && Storage.Dialect() == "sqlite" {
+		if legacy, err = Storage.TableExists(DBCon, "transactions"); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(m, legacy && m.version == 1); err != nil {
+			return fmt.Errorf("migration %d (%s): %v", m.version, m.name, err)
+		}
+		log.Printf("Applied migration %d: %s", m.version, m.name)
+	}
+	return nil
+}
+
+// AppliedMigrations lists the migrations applied to the data DB, oldest first
+func AppliedMigrations() ([]types.Migration, error) {
+	applied := []types.Migration{}
+	err := DBCon.Select(&applied, "SELECT version, name, applied_at FROM schema_migrations ORDER BY version")
+	return applied, err
+}
+
+func applyMigration(m migration, legacy bool) error {
+	txn, err := DBCon.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	if m.file != "" {
+		raw, err := sqlFiles.ReadFile("migrations/" + Storage.Dialect() + "/" + m.file)
+		if err != nil {
+			return err
+		}
+		if _, err := txn.Exec(string(raw)); err != nil {
+			return err
+		}
+	} else if err := m.run(txn); err != nil {
+		return err
+	}
+	if legacy {
+		if err := migrateTransactionColumns(txn); err != nil {
+			return err
+		}
+	}
+
+	if _, err := txn.Exec("INSERT INTO schema_migrations (version, name) VALUES($1, $2)", m.version, m.name); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
@@ -0,0 +1,42 @@
+-- The schema as it was when migrations were introduced, in PostgreSQL types.
+-- updated_at is kept up to date by a trigger, like the SQLite schema does.
+CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE accounts (id SERIAL PRIMARY KEY, name VARCHAR(255), institution VARCHAR(255), ignore_transactions BOOLEAN DEFAULT FALSE, account_id VARCHAR(255), item_id VARCHAR(255), type VARCHAR(255), subtype VARCHAR(255) DEFAULT '', balance NUMERIC DEFAULT 0, "limit" NUMERIC DEFAULT 0, available NUMERIC DEFAULT 0, currency VARCHAR(255), provider VARCHAR(255), running_total NUMERIC DEFAULT 0, created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, UNIQUE (account_id, provider));
+CREATE TRIGGER accounts_updated_at BEFORE UPDATE ON accounts FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE categories (id SERIAL PRIMARY KEY, top_category TEXT, sub_category TEXT, exclude_from_analysis BOOLEAN DEFAULT FALSE, created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER categories_updated_at BEFORE UPDATE ON categories FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE item_tokens (id SERIAL PRIMARY KEY, institution VARCHAR(255), access_token VARCHAR(255) DEFAULT '', item_id VARCHAR(255), provider VARCHAR(255), interactive BOOLEAN DEFAULT FALSE, needs_re_login BOOLEAN DEFAULT FALSE, last_refresh TIMESTAMPTZ, next_refresh_possible TIMESTAMPTZ, last_downloaded_transactions TIMESTAMPTZ, created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, UNIQUE (item_id, provider));
+CREATE TRIGGER item_tokens_updated_at BEFORE UPDATE ON item_tokens FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE plaid__categories (id SERIAL PRIMARY KEY, hierarchy TEXT, cat_i_d TEXT UNIQUE, link_to_app_cat INTEGER, app_cat_name TEXT, created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER plaid__categories_updated_at BEFORE UPDATE ON plaid__categories FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE salt_edge__categories (id SERIAL PRIMARY KEY, top_category TEXT, sub_category TEXT, bottom_category TEXT, link_to_app_cat INTEGER, app_cat_name TEXT, created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER salt_edge__categories_updated_at BEFORE UPDATE ON salt_edge__categories FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE transactions (id SERIAL PRIMARY KEY, date DATE, transaction_id VARCHAR(255) UNIQUE, description TEXT, original_description TEXT DEFAULT '', amount NUMERIC DEFAULT 0, normalized_amount NUMERIC DEFAULT 0, transaction_type TEXT DEFAULT '', category INTEGER, category_name TEXT, account_name TEXT, currency_code VARCHAR(255), account_id VARCHAR(255), labels TEXT DEFAULT '', notes TEXT DEFAULT '', created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+CREATE INDEX tx_date ON transactions (date);
+CREATE TRIGGER transactions_updated_at BEFORE UPDATE ON transactions FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE analysis_trees (name TEXT PRIMARY KEY, first_date TEXT, last_date TEXT, data TEXT DEFAULT '', data_no_invest TEXT DEFAULT '', created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER analysis_trees_updated_at BEFORE UPDATE ON analysis_trees FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE import_profiles (id SERIAL PRIMARY KEY, name VARCHAR(255) UNIQUE, delimiter VARCHAR(8) DEFAULT ',', has_header BOOLEAN DEFAULT TRUE, skip_rows INTEGER DEFAULT 0, date_column TEXT DEFAULT '', description_column TEXT DEFAULT '', original_description_column TEXT DEFAULT '', amount_column TEXT DEFAULT '', debit_column TEXT DEFAULT '', credit_column TEXT DEFAULT '', type_column TEXT DEFAULT '', category_column TEXT DEFAULT '', account_column TEXT DEFAULT '', currency_column TEXT DEFAULT '', labels_column TEXT DEFAULT '', notes_column TEXT DEFAULT '', date_format TEXT DEFAULT '1/2/2006', decimal_separator VARCHAR(1) DEFAULT '.', sign_convention TEXT DEFAULT 'signed', default_currency VARCHAR(255) DEFAULT '', default_account TEXT DEFAULT '', created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER import_profiles_updated_at BEFORE UPDATE ON import_profiles FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE import_batches (id SERIAL PRIMARY KEY, filename TEXT DEFAULT '', profile TEXT DEFAULT '', imported INTEGER DEFAULT 0, duplicates INTEGER DEFAULT 0, uncategorized INTEGER DEFAULT 0, created_accounts INTEGER DEFAULT 0, status VARCHAR(255) DEFAULT 'imported', created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER import_batches_updated_at BEFORE UPDATE ON import_batches FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+CREATE TABLE import_batch_transactions (batch_id INTEGER, transaction_id VARCHAR(255), PRIMARY KEY (batch_id, transaction_id));
+CREATE TABLE import_batch_accounts (batch_id INTEGER, account_id VARCHAR(255), PRIMARY KEY (batch_id, account_id));
+
+CREATE TABLE duplicate_pairs (id SERIAL PRIMARY KEY, transaction_id_1 VARCHAR(255), transaction_id_2 VARCHAR(255), score NUMERIC DEFAULT 0, status VARCHAR(255) DEFAULT 'pending', created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, UNIQUE (transaction_id_1, transaction_id_2));
+CREATE TRIGGER duplicate_pairs_updated_at BEFORE UPDATE ON duplicate_pairs FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
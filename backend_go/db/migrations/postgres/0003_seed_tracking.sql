@@ -0,0 +1,7 @@
+-- Seed data is versioned on its own, see seed.go. The mapping tables remember
+-- which category the seed last linked each row to, so rows changed by hand can
+-- be told apart and left alone when the seed changes.
+CREATE TABLE seed_data (version INTEGER PRIMARY KEY, applied_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+ALTER TABLE plaid__categories ADD COLUMN seed_link_to_app_cat INTEGER DEFAULT 0;
+ALTER TABLE salt_edge__categories ADD COLUMN seed_link_to_app_cat INTEGER DEFAULT 0;
+CREATE UNIQUE INDEX se_cat_path ON salt_edge__categories (top_category, sub_category, bottom_category);
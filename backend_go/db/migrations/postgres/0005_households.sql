@@ -0,0 +1,12 @@
+-- Households: item tokens and accounts belong to a user (0 is the whole
+-- household, which every existing row stays), accounts are shared with the
+-- rest of the household or not, and each user has their own SaltEdge customer.
+ALTER TABLE users ADD COLUMN saltedge_customer_id VARCHAR(255) DEFAULT '';
+ALTER TABLE item_tokens ADD COLUMN user_id INTEGER DEFAULT 0;
+ALTER TABLE accounts ADD COLUMN user_id INTEGER DEFAULT 0;
+ALTER TABLE accounts ADD COLUMN sharing VARCHAR(16) DEFAULT 'private';
+-- Analysis trees are kept per scope (see auth.AnalysisScopes), the existing
+-- ones cover everything
+ALTER TABLE analysis_trees ADD COLUMN scope VARCHAR(255) DEFAULT 'all';
+ALTER TABLE analysis_trees DROP CONSTRAINT analysis_trees_pkey;
+ALTER TABLE analysis_trees ADD PRIMARY KEY (scope, name);
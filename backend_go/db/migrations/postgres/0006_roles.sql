@@ -0,0 +1,4 @@
+-- Roles cap what a user can do, see auth.Roles. Users from before roles keep
+-- the full access they had as owners.
+ALTER TABLE users ADD COLUMN role VARCHAR(16) DEFAULT 'viewer';
+UPDATE users SET role = 'owner';
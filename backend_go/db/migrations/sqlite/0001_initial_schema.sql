@@ -0,0 +1,58 @@
+-- The schema as it was when migrations were introduced. Databases created
+-- before then already have these tables, hence IF NOT EXISTS throughout.
+CREATE TABLE IF NOT EXISTS `accounts` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `name` VARCHAR(255), `institution` VARCHAR(255), `ignore_transactions` TINYINT(1) DEFAULT 0, `account_id` VARCHAR(255), `item_id` VARCHAR(255), `type` VARCHAR(255), `subtype` VARCHAR(255) DEFAULT '', `balance` NUMERIC DEFAULT 0, `limit` NUMERIC DEFAULT 0, `available` NUMERIC DEFAULT 0, `currency` VARCHAR(255), `provider` VARCHAR(255), `running_total` NUMERIC DEFAULT 0, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP, UNIQUE (`account_id`, `provider`));
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime1 UPDATE ON accounts
+BEGIN
+    UPDATE accounts SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
+CREATE TABLE IF NOT EXISTS `categories` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `top_category` TEXT, `sub_category` TEXT, `exclude_from_analysis` TINYINT(1), `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime2 UPDATE ON categories
+BEGIN
+    UPDATE categories SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
+CREATE TABLE IF NOT EXISTS `item_tokens` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `institution` VARCHAR(255), `access_token` VARCHAR(255) DEFAULT '', `item_id` VARCHAR(255), `provider` VARCHAR(255), `interactive` TINYINT(1) DEFAULT 0, `needs_re_login` TINYINT(1) DEFAULT 0, `last_refresh` DATETIME, `next_refresh_possible` DATETIME, `last_downloaded_transactions` DATETIME, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP, UNIQUE (`item_id`, `provider`));
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime4 UPDATE ON item_tokens
+BEGIN
+    UPDATE item_tokens SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
+CREATE TABLE IF NOT EXISTS `plaid__categories` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `hierarchy` TEXT, `cat_i_d` TEXT UNIQUE, `link_to_app_cat` INTEGER, `app_cat_name` TEXT, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime5 UPDATE ON plaid__categories
+BEGIN
+    UPDATE plaid__categories SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
+
+CREATE TABLE IF NOT EXISTS `salt_edge__categories` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `top_category` TEXT, `sub_category` TEXT, `bottom_category` TEXT, `link_to_app_cat` INTEGER, `app_cat_name` TEXT, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime6 UPDATE ON salt_edge__categories
+BEGIN
+    UPDATE salt_edge__categories SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
+
+CREATE TABLE IF NOT EXISTS `transactions` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `date` DATE, `transaction_id` VARCHAR(255) UNIQUE, `description` TEXT, `original_description` TEXT DEFAULT '', `amount` NUMERIC DEFAULT 0, `normalized_amount` NUMERIC DEFAULT 0, `transaction_type` TEXT DEFAULT '', `category` INTEGER, `category_name` TEXT, `account_name` TEXT, `currency_code` VARCHAR(255), `account_id` VARCHAR(255), `labels` TEXT DEFAULT '', `notes` TEXT DEFAULT '', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+CREATE INDEX IF NOT EXISTS tx_date ON `transactions` (`date`);
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime7 UPDATE ON transactions
+BEGIN
+    UPDATE transactions SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
+CREATE TABLE IF NOT EXISTS `analysis_trees` (`name` STRING PRIMARY KEY, `first_date` STRING, `last_date` STRING, `data` STRING DEFAULT '', `data_no_invest` STRING DEFAULT '', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime8 UPDATE ON analysis_trees
+BEGIN
+    UPDATE analysis_trees SET updated_at=CURRENT_TIMESTAMP WHERE name=name;
+END;
+CREATE TABLE IF NOT EXISTS `import_profiles` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `name` VARCHAR(255) UNIQUE, `delimiter` VARCHAR(8) DEFAULT ',', `has_header` BOOLEAN DEFAULT 1, `skip_rows` INTEGER DEFAULT 0, `date_column` TEXT DEFAULT '', `description_column` TEXT DEFAULT '', `original_description_column` TEXT DEFAULT '', `amount_column` TEXT DEFAULT '', `debit_column` TEXT DEFAULT '', `credit_column` TEXT DEFAULT '', `type_column` TEXT DEFAULT '', `category_column` TEXT DEFAULT '', `account_column` TEXT DEFAULT '', `currency_column` TEXT DEFAULT '', `labels_column` TEXT DEFAULT '', `notes_column` TEXT DEFAULT '', `date_format` TEXT DEFAULT '1/2/2006', `decimal_separator` VARCHAR(1) DEFAULT '.', `sign_convention` TEXT DEFAULT 'signed', `default_currency` VARCHAR(255) DEFAULT '', `default_account` TEXT DEFAULT '', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime9 UPDATE ON import_profiles
+BEGIN
+    UPDATE import_profiles SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
+CREATE TABLE IF NOT EXISTS `import_batches` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `filename` TEXT DEFAULT '', `profile` TEXT DEFAULT '', `imported` INTEGER DEFAULT 0, `duplicates` INTEGER DEFAULT 0, `uncategorized` INTEGER DEFAULT 0, `created_accounts` INTEGER DEFAULT 0, `status` VARCHAR(255) DEFAULT 'imported', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime10 UPDATE ON import_batches
+BEGIN
+    UPDATE import_batches SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
+CREATE TABLE IF NOT EXISTS `import_batch_transactions` (`batch_id` INTEGER, `transaction_id` VARCHAR(255), PRIMARY KEY (`batch_id`, `transaction_id`));
+CREATE TABLE IF NOT EXISTS `import_batch_accounts` (`batch_id` INTEGER, `account_id` VARCHAR(255), PRIMARY KEY (`batch_id`, `account_id`));
+CREATE TABLE IF NOT EXISTS `duplicate_pairs` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `transaction_id_1` VARCHAR(255), `transaction_id_2` VARCHAR(255), `score` NUMERIC DEFAULT 0, `status` VARCHAR(255) DEFAULT 'pending', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP, UNIQUE (`transaction_id_1`, `transaction_id_2`));
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime11 UPDATE ON duplicate_pairs
+BEGIN
+    UPDATE duplicate_pairs SET updated_at=CURRENT_TIMESTAMP WHERE id=id;
+END;
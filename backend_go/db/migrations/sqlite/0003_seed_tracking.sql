@@ -0,0 +1,8 @@
+-- Seed data is versioned on its own, see seed.go. The mapping tables remember
+-- which category the seed last linked each row to, so rows changed by hand can
+-- be told apart and left alone when the seed changes.
+CREATE TABLE IF NOT EXISTS `seed_data` (`version` INTEGER PRIMARY KEY, `applied_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+ALTER TABLE `plaid__categories` ADD COLUMN `seed_link_to_app_cat` INTEGER DEFAULT 0;
+ALTER TABLE `salt_edge__categories` ADD COLUMN `seed_link_to_app_cat` INTEGER DEFAULT 0;
+DELETE FROM `salt_edge__categories` WHERE id NOT IN (SELECT MIN(id) FROM `salt_edge__categories` GROUP BY top_category, sub_category, bottom_category);
+CREATE UNIQUE INDEX IF NOT EXISTS se_cat_path ON `salt_edge__categories` (`top_category`, `sub_category`, `bottom_category`);
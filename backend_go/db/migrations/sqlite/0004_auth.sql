@@ -0,0 +1,9 @@
+-- Built-in authentication, see the auth package. Session and API tokens are
+-- only kept as SHA-256 hashes, so a copy of the database can't be used to log in.
+CREATE TABLE `users` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `username` VARCHAR(255) UNIQUE, `password_hash` TEXT DEFAULT '', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+CREATE TRIGGER IF NOT EXISTS UpdateLastTimeUsers UPDATE ON users
+BEGIN
+    UPDATE users SET updated_at=CURRENT_TIMESTAMP WHERE id=NEW.id;
+END;
+CREATE TABLE `sessions` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `user_id` INTEGER REFERENCES `users` (`id`) ON DELETE CASCADE, `token_hash` VARCHAR(64) UNIQUE, `expires_at` DATETIME, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
+CREATE TABLE `api_tokens` (`id` INTEGER PRIMARY KEY AUTOINCREMENT, `user_id` INTEGER REFERENCES `users` (`id`) ON DELETE CASCADE, `name` VARCHAR(255), `token_hash` VARCHAR(64) UNIQUE, `scopes` TEXT, `expires_at` DATETIME, `last_used_at` DATETIME, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP);
@@ -0,0 +1,17 @@
+-- Households: item tokens and accounts belong to a user (0 is the whole
+-- household, which every existing row stays), accounts are shared with the
+-- rest of the household or not, and each user has their own SaltEdge customer.
+ALTER TABLE `users` ADD COLUMN `saltedge_customer_id` VARCHAR(255) DEFAULT '';
+ALTER TABLE `item_tokens` ADD COLUMN `user_id` INTEGER DEFAULT 0;
+ALTER TABLE `accounts` ADD COLUMN `user_id` INTEGER DEFAULT 0;
+ALTER TABLE `accounts` ADD COLUMN `sharing` VARCHAR(16) DEFAULT 'private';
+-- Analysis trees are kept per scope (see auth.AnalysisScopes), the existing
+-- ones cover everything
+CREATE TABLE `analysis_trees_scoped` (`scope` VARCHAR(255) DEFAULT 'all', `name` VARCHAR(255), `first_date` STRING, `last_date` STRING, `data` STRING DEFAULT '', `data_no_invest` STRING DEFAULT '', `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY (`scope`, `name`));
+INSERT INTO `analysis_trees_scoped` (`scope`, `name`, `first_date`, `last_date`, `data`, `data_no_invest`, `created_at`, `updated_at`) SELECT 'all', `name`, `first_date`, `last_date`, `data`, `data_no_invest`, `created_at`, `updated_at` FROM `analysis_trees`;
+DROP TABLE `analysis_trees`;
+ALTER TABLE `analysis_trees_scoped` RENAME TO `analysis_trees`;
+CREATE TRIGGER IF NOT EXISTS UpdateLastTime8 UPDATE ON analysis_trees
+BEGIN
+    UPDATE analysis_trees SET updated_at=CURRENT_TIMESTAMP WHERE scope=NEW.scope AND name=NEW.name;
+END;
@@ -0,0 +1,6 @@
+-- Where the next sync of an item picks up, as its provider understands it,
+-- see providers.Provider.FetchTransactions. Plaid items carry on from the
+-- day they were last downloaded.
+ALTER TABLE `item_tokens` ADD COLUMN `sync_cursor` TEXT DEFAULT '';
+UPDATE `item_tokens` SET `sync_cursor` = substr(`last_downloaded_transactions`, 1, 10)
+	WHERE `provider` = 'Plaid' AND `last_downloaded_transactions` > '1970-01-01';
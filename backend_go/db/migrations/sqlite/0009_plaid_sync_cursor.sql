@@ -0,0 +1,4 @@
+-- Plaid items sync with /transactions/sync now, whose cursors are Plaid's own:
+-- the days kept as cursors until now can't be used, so the first sync reads
+-- the whole history again and updates the transactions already stored
+UPDATE `item_tokens` SET `sync_cursor` = '' WHERE `provider` = 'Plaid';
@@ -0,0 +1,7 @@
+-- Whether a transaction has posted or is still pending at its bank. Pending
+-- ones are replaced by the transaction they post as, see
+-- providers.SaveTransactions, and deleted once they're too old to post.
+ALTER TABLE `transactions` ADD COLUMN `status` VARCHAR(16) DEFAULT 'posted';
+-- The trees again without pending transactions, for the analysis to leave them out
+ALTER TABLE `analysis_trees` ADD COLUMN `data_posted` STRING DEFAULT '';
+ALTER TABLE `analysis_trees` ADD COLUMN `data_no_invest_posted` STRING DEFAULT '';
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// postgresStore keeps the data and currency tables in PostgreSQL, for
+// deployments with several writers or their own database backups
+type postgresStore struct {
+	data     string
+	currency string
+}
+
+func (s postgresStore) Dialect() string {
+	return "postgres"
+}
+
+func (s postgresStore) Open() (*sqlx.DB, *sqlx.DB, error) {
+	data, err := sqlx.Connect("postgres", s.data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.currency == s.data {
+		return data, data, nil
+	}
+	currency, err := sqlx.Connect("postgres", s.currency)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, currency, nil
+}
+
+func (s postgresStore) TableExists(con sqlx.Queryer, name string) (bool, error) {
+	var count int
+	err := sqlx.Get(con, &count, "SELECT count(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1", name)
+	return count > 0, err
+}
+
+// InsertID reads the id back with RETURNING, lib/pq has no LastInsertId
+func (s postgresStore) InsertID(con sqlx.Ext, query string, args ...interface{}) (int64, error) {
+	var id int64
+	err := con.QueryRowx(query+" RETURNING id", args...).Scan(&id)
+	return id, err
+}
+
+func (s postgresStore) SyncSequence(con sqlx.Execer, table string) error {
+	_, err := con.Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %s", table, quoteIdent(table)))
+	return err
+}
+
+func (s postgresStore) DaysApart(a, b string) string {
+	return "abs(CAST(" + a + " AS DATE) - CAST(" + b + " AS DATE))"
+}
+
+// Backups is false since the backups copy SQLite files; a Postgres database is
+// backed up with its own tools (pg_dump) and moved with an archive
+func (s postgresStore) Backups() bool {
+	return false
+}
@@ -0,0 +1,18 @@
+package db
+
+// Reset empties the data tables, keeping item tokens and import profiles
+// unless full is set, and applies the seed data again
+func Reset(full bool) error {
+	script := "reset.sql"
+	if full {
+		script = "fullreset.sql"
+	}
+	raw, err := sqlFiles.ReadFile("reset/" + Storage.Dialect() + "/" + script)
+	if err != nil {
+		return err
+	}
+	if _, err := DBCon.Exec(string(raw)); err != nil {
+		return err
+	}
+	return Seed()
+}
@@ -0,0 +1,5 @@
+-- Empties the data tables but keeps the schema; seed data is applied again
+-- afterwards since seed_data is emptied too
+BEGIN;
+TRUNCATE accounts, categories, salt_edge__categories, plaid__categories, item_tokens, transactions, analysis_trees, import_batches, import_batch_transactions, import_batch_accounts, duplicate_pairs, import_profiles, seed_data RESTART IDENTITY;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Empties the data tables but keeps the schema; seed data is applied again
+-- afterwards since seed_data is emptied too
+BEGIN;
+TRUNCATE accounts, categories, salt_edge__categories, plaid__categories, transactions, analysis_trees, import_batches, import_batch_transactions, import_batch_accounts, duplicate_pairs, seed_data RESTART IDENTITY;
+-- Items stay linked, but sync their transactions from the start again
+UPDATE item_tokens SET sync_cursor = '';
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- Empties the data tables but keeps the schema; seed data is applied again
+-- afterwards since seed_data is emptied too
+BEGIN TRANSACTION;
+DROP TABLE IF EXISTS `currency_rates`;
+DELETE FROM `accounts`;
+DELETE FROM `categories`;
+DELETE FROM `salt_edge__categories`;
+DELETE FROM `plaid__categories`;
+DELETE FROM `transactions`;
+-- Items stay linked, but sync their transactions from the start again
+UPDATE `item_tokens` SET `sync_cursor` = '';
+DELETE FROM `analysis_trees`;
+DELETE FROM `import_batches`;
+DELETE FROM `import_batch_transactions`;
+DELETE FROM `import_batch_accounts`;
+DELETE FROM `duplicate_pairs`;
+DELETE FROM `seed_data`;
+DELETE FROM sqlite_sequence WHERE name IN ('accounts', 'categories', 'salt_edge__categories', 'plaid__categories', 'transactions', 'analysis_trees', 'import_batches', 'import_batch_transactions', 'import_batch_accounts', 'duplicate_pairs');
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SeedVersion is bumped whenever a file in seeds/ changes, so the change
+// reaches existing installs on their next start
+const SeedVersion = 1
+
+// seedFiles are loaded into temporary staging tables, which mergeSeed then
+// folds into the real ones. The import profile seed inserts directly.
+var seedFiles = []struct {
+	file  string
+	table string
+}{
+	{"categories.sql", "CREATE TEMP TABLE seed_categories (id INTEGER, top_category TEXT, sub_category TEXT, exclude_from_analysis BOOLEAN)"},
+	{"plaid_categories.sql", "CREATE TEMP TABLE seed_plaid__categories (id INTEGER, hierarchy TEXT, cat_i_d TEXT, link_to_app_cat INTEGER, app_cat_name TEXT)"},
+	{"saltedge_categories.sql", "CREATE TEMP TABLE seed_salt_edge__categories (id INTEGER, top_category TEXT, sub_category TEXT, bottom_category TEXT, link_to_app_cat INTEGER, app_cat_name TEXT)"},
+	{"import_profiles.sql", ""},
+}
+
+// mergeSeedCategories adds seeded categories that are missing, keeping the
+// seed's ID unless a custom category took it, but never changes existing ones.
+// The first statement adds those that can keep their ID and the second the
+// rest, once the store's ID sequence has caught up.
+var mergeSeedCategories = []string{`
+INSERT INTO categories (id, top_category, sub_category, exclude_from_analysis)
+SELECT s.id, s.top_category, s.sub_category, s.exclude_from_analysis
+FROM seed_categories s
+WHERE NOT EXISTS (SELECT 1 FROM categories c WHERE c.top_category = s.top_category AND c.sub_category = s.sub_category)
+	AND s.id NOT IN (SELECT id FROM categories)
+ORDER BY s.id`, `
+INSERT INTO categories (top_category, sub_category, exclude_from_analysis)
+SELECT s.top_category, s.sub_category, s.exclude_from_analysis
+FROM seed_categories s
+WHERE NOT EXISTS (SELECT 1 FROM categories c WHERE c.top_category = s.top_category AND c.sub_category = s.sub_category)
+ORDER BY s.id`,
+}
+
+// mergeSeed adds mapping rows or lets them follow the seed only while they
+// still point where the seed last put them, so mappings changed by hand stay as
+// they are. Rows from before seed tracking count as unchanged when they match
+// the seed.
+const mergeSeed = `
+CREATE TEMP TABLE seed_links AS
+SELECT s.id AS seed_id, MIN(c.id) AS local_id, c.sub_category AS name
+FROM seed_categories s JOIN categories c ON c.top_category = s.top_category AND c.sub_category = s.sub_category
+GROUP BY s.id, c.sub_category;
+
+INSERT INTO plaid__categories (hierarchy, cat_i_d, link_to_app_cat, app_cat_name, seed_link_to_app_cat)
+SELECT s.hierarchy, s.cat_i_d, COALESCE(l.local_id, 106), COALESCE(l.name, 'Uncategorized'), COALESCE(l.local_id, 106)
+FROM seed_plaid__categories s LEFT JOIN seed_links l ON l.seed_id = s.link_to_app_cat
+WHERE true
+ORDER BY s.id
+ON CONFLICT (cat_i_d) DO UPDATE SET
+	hierarchy = excluded.hierarchy,
+	link_to_app_cat = excluded.link_to_app_cat,
+	app_cat_name = excluded.app_cat_name,
+	seed_link_to_app_cat = excluded.seed_link_to_app_cat
+WHERE plaid__categories.link_to_app_cat = plaid__categories.seed_link_to_app_cat
+	OR (plaid__categories.seed_link_to_app_cat = 0 AND plaid__categories.link_to_app_cat = excluded.link_to_app_cat);
+
+INSERT INTO salt_edge__categories (top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name, seed_link_to_app_cat)
+SELECT s.top_category, s.sub_category, s.bottom_category, COALESCE(l.local_id, 106), COALESCE(l.name, 'Uncategorized'), COALESCE(l.local_id, 106)
+FROM seed_salt_edge__categories s LEFT JOIN seed_links l ON l.seed_id = s.link_to_app_cat
+WHERE true
+ORDER BY s.id
+ON CONFLICT (top_category, sub_category, bottom_category) DO UPDATE SET
+	link_to_app_cat = excluded.link_to_app_cat,
+	app_cat_name = excluded.app_cat_name,
+	seed_link_to_app_cat = excluded.seed_link_to_app_cat
+WHERE salt_edge__categories.link_to_app_cat = salt_edge__categories.seed_link_to_app_cat
+	OR (salt_edge__categories.seed_link_to_app_cat = 0 AND salt_edge__categories.link_to_app_cat = excluded.link_to_app_cat);
+
+DROP TABLE seed_links;
+DROP TABLE seed_categories;
+DROP TABLE seed_plaid__categories;
+DROP TABLE seed_salt_edge__categories;`
+
+func seedVersion(con sqlx.Queryer) (int, error) {
+	version := 0
+	err := sqlx.Get(con, &version, "SELECT COALESCE(MAX(version), 0) FROM seed_data")
+	return version, err
+}
+
+// Seed brings the default categories, provider category mappings and import
+// profiles up to SeedVersion, in one transaction
+func Seed() error {
+	current, err := seedVersion(DBCon)
+	if err != nil || current >= SeedVersion {
+		return err
+	}
+
+	txn, err := DBCon.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	for _, f := range seedFiles {
+		if f.table != "" {
+			if _, err := txn.Exec(f.table); err != nil {
+				return err
+			}
+		}
+		raw, err := sqlFiles.ReadFile("seeds/" + f.file)
+		if err != nil {
+			return err
+		}
+		if _, err := txn.Exec(string(raw)); err != nil {
+			return err
+		}
+	}
+	if _, err := txn.Exec(mergeSeedCategories[0]); err != nil {
+		return err
+	}
+	if err := Storage.SyncSequence(txn, "categories"); err != nil {
+		return err
+	}
+	if _, err := txn.Exec(mergeSeedCategories[1]); err != nil {
+		return err
+	}
+	if _, err := txn.Exec(mergeSeed); err != nil {
+		return err
+	}
+	if _, err := txn.Exec("INSERT INTO seed_data (version) VALUES($1)", SeedVersion); err != nil {
+		return err
+	}
+	if err := txn.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("Applied seed data version %d", SeedVersion)
+	return nil
+}
@@ -0,0 +1,107 @@
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(1,'Auto & Transport','Auto & Transport',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(2,'Auto & Transport','Auto Insurance',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(3,'Auto & Transport','Auto Payment',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(4,'Auto & Transport','Gas & Fuel',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(5,'Auto & Transport','Parking',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(6,'Auto & Transport','Public Transportation',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(7,'Auto & Transport','Service & Parts',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(8,'Bills & Utilities','Bills & Utilities',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(9,'Bills & Utilities','Home Phone',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(10,'Bills & Utilities','Internet',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(11,'Bills & Utilities','Mobile Phone',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(12,'Bills & Utilities','Television',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(13,'Bills & Utilities','Utilities',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(14,'Education','Education',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(15,'Education','Books & Supplies',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(16,'Education','Student Loan',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(17,'Education','Tuition',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(18,'Entertainment','Entertainment',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(19,'Entertainment','Amusement',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(20,'Entertainment','Arts',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(21,'Entertainment','Movies & DVDs',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(22,'Entertainment','Music',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(23,'Entertainment','Newspapers & Magazines',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(24,'Fees & Charges','Fees & Charges',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(25,'Fees & Charges','ATM Fee',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(26,'Fees & Charges','Bank Fee',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(27,'Fees & Charges','Finance Charge',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(28,'Fees & Charges','Late Fee',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(29,'Fees & Charges','Service Fee',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(30,'Fees & Charges','Trade Commissions',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(31,'Financial','Financial',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(32,'Financial','Financial Advisor',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(33,'Financial','Life Insurance',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(34,'Financial','Roth IRA',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(35,'Food & Dining','Food & Dining',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(36,'Food & Dining','Alcohol & Bars',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(37,'Food & Dining','Fast Food',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(38,'Food & Dining','Groceries',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(39,'Food & Dining','Restaurants',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(40,'Gifts & Donations','Gifts & Donations',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(41,'Gifts & Donations','Charity',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(42,'Gifts & Donations','Gifts',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(43,'Health & Fitness','Health & Fitness',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(45,'Health & Fitness','Dentist',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(46,'Health & Fitness','Doctor',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(47,'Health & Fitness','Eyecare',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(48,'Health & Fitness','Gym',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(49,'Health & Fitness','Health Insurance',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(50,'Health & Fitness','Pharmacy',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(51,'Health & Fitness','Sports & Recreation',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(52,'Home','Home',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(53,'Home','Furnishings',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(54,'Home','Home Improvement & Services',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(55,'Home','Home Insurance',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(57,'Home','Home Supplies',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(58,'Home','Lawn & Garden',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(59,'Home','Mortgage & Rent',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(60,'Home','Renter''s insurance',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(61,'Income','Income',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(62,'Income','Bonus',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(63,'Income','Interest/Cap Gain/Dividend',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(64,'Income','Paycheck',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(65,'Income','Paypal Income',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(66,'Income','Reimbursement',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(67,'Income','Rental Income',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(68,'Income','Returned Purchase',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(69,'Investment (Buy)','Investment (Buy)',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(70,'Kids','Kids',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(71,'Kids','Allowance',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(72,'Kids','Baby Supplies',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(73,'Kids','Babysitter & Daycare',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(74,'Kids','Kids Activities',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(75,'Kids','Toys',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(76,'Misc Expenses','Misc Expenses',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(77,'Misc Expenses','Venmo expense',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(78,'Misc Expenses','Wedding',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(79,'Personal Care','Personal Care',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(80,'Personal Care','Hair',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(81,'Personal Care','Laundry',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(82,'Personal Care','Spa & Massage',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(83,'Shopping','Shopping',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(84,'Shopping','Amazon',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(85,'Shopping','Amazon Prime Member',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(86,'Shopping','Books',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(87,'Shopping','Clothing',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(88,'Shopping','Coffee',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(89,'Shopping','Electronics & Software',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(90,'Shopping','Hobbies',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(91,'Shopping','Sporting Goods',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(92,'Taxes','Taxes',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(93,'Taxes','Federal Tax',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(94,'Taxes','Local Tax',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(95,'Taxes','Property Tax',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(96,'Taxes','Sales Tax',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(97,'Taxes','State Tax',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(98,'Transfer','Transfer',TRUE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(99,'Transfer','Investment Transfer In/Out',TRUE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(100,'Transfer','Credit Card Payment (Transfer)',TRUE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(101,'Travel','Travel',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(102,'Travel','Air Travel',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(103,'Travel','Hotel',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(104,'Travel','Rental Car & Taxi',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(105,'Travel','Vacation',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(106,'Uncategorized','Uncategorized',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(107,'Uncategorized','Cash & ATM',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(108,'Uncategorized','Check',FALSE);
+INSERT INTO seed_categories (id, top_category, sub_category, exclude_from_analysis) VALUES(109,'Hide from Analysis','Hide from Analysis',TRUE);
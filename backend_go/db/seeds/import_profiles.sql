@@ -0,0 +1 @@
+INSERT INTO import_profiles (name, delimiter, has_header, date_column, description_column, original_description_column, amount_column, type_column, category_column, account_column, currency_column, labels_column, notes_column, date_format, sign_convention) VALUES('Mint', ',', TRUE, 'Date', 'Description', 'Original Description', 'Amount', 'Transaction Type', 'Category', 'Account Name', 'currency_code', 'Labels', 'Notes', '1/2/2006', 'type_column') ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,108 @@
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(1,'personal','auto_and_transport','car_rental',1,'Auto & Transport');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(2,'personal','auto_and_transport','gas_and_fuel',4,'Gas & Fuel');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(3,'personal','auto_and_transport','parking',5,'Parking');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(4,'personal','auto_and_transport','public_transportation',6,'Public Transportation');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(5,'personal','auto_and_transport','service_and_parts',7,'Service & Parts');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(6,'personal','auto_and_transport','taxi',1,'Auto & Transport');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(7,'personal','bills_and_utilities','internet',10,'Internet');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(8,'personal','bills_and_utilities','phone',11,'Mobile Phone');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(9,'personal','bills_and_utilities','television',12,'Television');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(10,'personal','bills_and_utilities','utilities',13,'Utilities');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(11,'personal','business_services','advertising',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(12,'personal','business_services','office_supplies',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(13,'personal','business_services','shipping',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(14,'personal','education','books_and_supplies',15,'Books & Supplies');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(15,'personal','education','student_loan',16,'Student Loan');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(16,'personal','education','tuition',17,'Tuition');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(17,'personal','entertainment','amusement',19,'Amusement');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(18,'personal','entertainment','arts',20,'Arts');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(19,'personal','entertainment','games',18,'Entertainment');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(20,'personal','entertainment','movies_and_music',21,'Movies & DVDs');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(21,'personal','entertainment','newspapers_and_magazines',23,'Newspapers & Magazines');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(22,'personal','fees_and_charges','provider_fee',24,'Fees & Charges');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(23,'personal','fees_and_charges','loans',24,'Fees & Charges');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(24,'personal','fees_and_charges','service_fee',29,'Service Fee');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(25,'personal','fees_and_charges','taxes',92,'Taxes');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(26,'personal','food_and_dining','alcohol_and_bars',36,'Alcohol & Bars');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(27,'personal','food_and_dining','cafes_and_restaurants',39,'Restaurants');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(28,'personal','food_and_dining','groceries',39,'Restaurants');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(29,'personal','gifts_and_donations','charity',41,'Charity');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(30,'personal','gifts_and_donations','gifts',42,'Gifts');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(31,'personal','health_and_fitness','doctor',46,'Doctor');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(32,'personal','health_and_fitness','personal_care',79,'Personal Care');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(33,'personal','health_and_fitness','pharmacy',50,'Pharmacy');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(34,'personal','health_and_fitness','sports',51,'Sports & Recreation');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(35,'personal','health_and_fitness','wellness',43,'Health & Fitness');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(36,'personal','home','home_improvement',54,'Home Improvement & Services');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(37,'personal','home','home_services',54,'Home Improvement & Services');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(38,'personal','home','home_supplies',57,'Home Supplies');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(39,'personal','home','mortgage',59,'Mortgage & Rent');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(40,'personal','home','rent',59,'Mortgage & Rent');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(41,'personal','income','bonus',62,'Bonus');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(42,'personal','income','investment_income',63,'Interest/Cap Gain/Dividend');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(43,'personal','income','paycheck',64,'Paycheck');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(44,'personal','insurance','car_insurance',2,'Auto Insurance');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(45,'personal','insurance','health_insurance',49,'Health Insurance');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(46,'personal','insurance','life_insurance',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(47,'personal','insurance','property_insurance',55,'Home Insurance');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(48,'personal','kids','allowance',71,'Allowance');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(49,'personal','kids','babysitter_and_daycare',73,'Babysitter & Daycare');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(50,'personal','kids','baby_supplies',72,'Baby Supplies');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(51,'personal','kids','child_support',70,'Kids');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(52,'personal','kids','kids_activities',74,'Kids Activities');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(53,'personal','kids','toys',75,'Toys');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(54,'personal','pets','pet_food_and_supplies',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(55,'personal','pets','pet_grooming',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(56,'personal','pets','veterinary',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(57,'personal','shopping','clothing',87,'Clothing');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(58,'personal','shopping','electronics_and_software',89,'Electronics & Software');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(59,'personal','shopping','sporting_goods',91,'Sporting Goods');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(60,'personal','travel','hotel',103,'Hotel');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(61,'personal','travel','transportation',101,'Travel');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(62,'personal','travel','vacation',105,'Vacation');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(63,'business','cost_of_goods','merchandise',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(64,'business','cost_of_goods','raw_materials',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(65,'business','financials','dividends',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(66,'business','financials','donations',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(67,'business','financials','fees',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(68,'business','financials','fines',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(69,'business','financials','interest',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(70,'business','human_resources','bonus',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(71,'business','human_resources','education_and_trainings',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(72,'business','human_resources','social_security',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(73,'business','human_resources','staff_outsourcing',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(74,'business','human_resources','wages',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(75,'business','income','investment',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(76,'business','income','returns',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(77,'business','income','sales',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(78,'business','insurance','business_insurance',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(79,'business','insurance','equipment_insurance',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(80,'business','insurance','health_insurance',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(81,'business','insurance','liability_insurance',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(82,'business','insurance','professional_insurance',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(83,'business','office','equipment',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(84,'business','office','office_rent',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(85,'business','office','office_supplies',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(86,'business','office','software',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(87,'business','services','accounting',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(88,'business','services','consultancy',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(89,'business','services','contractors',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(90,'business','services','legal',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(91,'business','services','marketing',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(92,'business','services','online_subscriptions',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(93,'business','services','storage',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(94,'business','taxes','duty_taxes',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(95,'business','taxes','federal_taxes',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(96,'business','taxes','income_taxes',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(97,'business','taxes','property_taxes',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(98,'business','taxes','tax_return',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(99,'business','taxes','vat',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(100,'business','transport','gas_and_fuel',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(101,'business','transport','leasing',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(102,'business','transport','shipping',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(103,'business','transport','taxi',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(104,'business','utilities','electricity',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(105,'business','utilities','gas',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(106,'business','utilities','internet',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(107,'business','utilities','phone',76,'Misc Expenses');
+INSERT INTO seed_salt_edge__categories (id, top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name) VALUES(108,'business','utilities','water',76,'Misc Expenses');
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteStore keeps both databases in SQLite files, the default
+type sqliteStore struct {
+	data     string
+	currency string
+}
+
+func (s sqliteStore) Dialect() string {
+	return "sqlite"
+}
+
+func (s sqliteStore) Open() (*sqlx.DB, *sqlx.DB, error) {
+	data, err := sqlx.Open("sqlite3", s.data)
+	if err != nil {
+		return nil, nil, err
+	}
+	data.Exec("PRAGMA journal_mode=WAL;")
+
+	currency, err := sqlx.Open("sqlite3", s.currency)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, currency, nil
+}
+
+func (s sqliteStore) TableExists(con sqlx.Queryer, name string) (bool, error) {
+	var count int
+	err := sqlx.Get(con, &count, "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = $1", name)
+	return count > 0, err
+}
+
+func (s sqliteStore) InsertID(con sqlx.Ext, query string, args ...interface{}) (int64, error) {
+	res, err := con.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// SyncSequence has nothing to do, AUTOINCREMENT already continues from the
+// highest id ever inserted
+func (s sqliteStore) SyncSequence(con sqlx.Execer, table string) error {
+	return nil
+}
+
+func (s sqliteStore) DaysApart(a, b string) string {
+	return "abs(julianday(" + a + ") - julianday(" + b + "))"
+}
+
+func (s sqliteStore) Backups() bool {
+	return true
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"fin-go/config"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Store is the database fin keeps its data in. Queries are written so they run
+// on every store (double quoted identifiers, $N or named parameters, ON
+// CONFLICT upserts), and a Store covers the few things that can't be.
+type Store interface {
+	// Dialect names the store's SQL dialect, which is also the folder its
+	// migrations and reset scripts are read from
+	Dialect() string
+	// Open connects to the data and currency databases
+	Open() (data *sqlx.DB, currency *sqlx.DB, err error)
+	// TableExists reports whether a table exists
+	TableExists(con sqlx.Queryer, name string) (bool, error)
+	// InsertID runs an INSERT into a table with an id column and returns the
+	// id of the new row
+	InsertID(con sqlx.Ext, query string, args ...interface{}) (int64, error)
+	// SyncSequence makes new ids in a table follow on from its highest id,
+	// after rows were inserted with ids of their own
+	SyncSequence(con sqlx.Execer, table string) error
+	// DaysApart is an SQL expression for the number of days between two dates
+	DaysApart(a, b string) string
+	// Backups reports whether the built-in backups work with this store
+	Backups() bool
+}
+
+// Storage is the store picked by the database URL, SQLite unless it is a
+// postgres:// URL
+var Storage Store
+
+var ErrUnknownStore = errors.New("database URL is not a postgres:// URL")
+
+// OpenStorage picks the store from the database config and connects DBCon and
+// CurrencyDBCon to it. Without a URL both SQLite files are kept in the data
+// dir, which is created if needed.
+func OpenStorage(cfg config.Database) error {
+	switch {
+	case cfg.URL == "":
+		if err := os.MkdirAll(cfg.DataDir, 0700); err != nil {
+			return err
+		}
+		Storage = sqliteStore{data: cfg.DataPath(), currency: cfg.CurrencyPath()}
+	case strings.HasPrefix(cfg.URL, "postgres://") || strings.HasPrefix(cfg.URL, "postgresql://"):
+		currency := cfg.CurrencyURL
+		if currency == "" {
+			currency = cfg.URL
+		}
+		Storage = postgresStore{data: cfg.URL, currency: currency}
+	default:
+		return ErrUnknownStore
+	}
+
+	var err error
+	DBCon, CurrencyDBCon, err = Storage.Open()
+	return err
+}
+
+// InsertID runs an INSERT on the current store and returns the new row's id
+func InsertID(con sqlx.Ext, query string, args ...interface{}) (int64, error) {
+	return Storage.InsertID(con, query, args...)
+}
+
+// quoteIdent quotes a table or column name that comes from data, such as the
+// currency tables named after their currency code
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"fin-go/config"
+	"fin-go/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+// forEachStore runs test against a new, empty database on every store: SQLite
+// always, and PostgreSQL when DATABASE_URL points at a server, in a schema of
+// its own that is dropped afterwards
+func forEachStore(t *testing.T, test func(t *testing.T)) {
+	t.Run("sqlite", func(t *testing.T) {
+		openStore(t, config.Database{DataDir: t.TempDir()})
+		test(t)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		url := os.Getenv("DATABASE_URL")
+		if url == "" {
+			t.Skip("DATABASE_URL is not set")
+		}
+		admin, err := sqlx.Connect("postgres", url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schema := fmt.Sprintf("fin_test_%d", time.Now().UnixNano())
+		admin.MustExec("CREATE SCHEMA " + schema)
+		t.Cleanup(func() {
+			admin.MustExec("DROP SCHEMA " + schema + " CASCADE")
+			admin.Close()
+		})
+		sep := "?"
+		if strings.Contains(url, "?") {
+			sep = "&"
+		}
+		openStore(t, config.Database{URL: url + sep + "search_path=" + schema})
+		test(t)
+	})
+}
+
+func openStore(t *testing.T, cfg config.Database) {
+	if err := OpenStorage(cfg); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if CurrencyDBCon != DBCon {
+			CurrencyDBCon.Close()
+		}
+		DBCon.Close()
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	forEachStore(t, func(t *testing.T) {
+		if err := InitSchema(); err != nil {
+			t.Fatal(err)
+		}
+		version, err := schemaVersion(DBCon)
+		if err != nil || version != SchemaVersion {
+			t.Fatalf("schema version %d, %v, want %d", version, err, SchemaVersion)
+		}
+		applied, err := AppliedMigrations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(applied) != len(migrations) {
+			t.Errorf("%d migrations recorded, want %d", len(applied), len(migrations))
+		}
+		for _, table := range []string{"transactions", "accounts", "item_tokens", "users", "duplicate_pairs", "import_batches", "analysis_trees"} {
+			if ok, err := Storage.TableExists(DBCon, table); err != nil || !ok {
+				t.Errorf("table %s missing: %v", table, err)
+			}
+		}
+
+		// Starting again finds nothing left to do
+		if err := InitSchema(); err != nil {
+			t.Fatalf("second run: %v", err)
+		}
+		var runs int
+		if err := DBCon.Get(&runs, "SELECT COUNT(*) FROM schema_migrations"); err != nil || runs != len(migrations) {
+			t.Errorf("%d migrations recorded after a second run, %v", runs, err)
+		}
+	})
+}
+
+func TestMigrateRefusesNewerSchema(t *testing.T) {
+	forEachStore(t, func(t *testing.T) {
+		if err := InitSchema(); err != nil {
+			t.Fatal(err)
+		}
+		DBCon.MustExec("INSERT INTO schema_migrations (version, name) VALUES($1, $2)", SchemaVersion+1, "from the future")
+		if err := Migrate(); err == nil || !strings.Contains(err.Error(), ErrSchemaTooNew.Error()) {
+			t.Errorf("Migrate() = %v, want %v", err, ErrSchemaTooNew)
+		}
+	})
+}
+
+// Both stores hand dates back as times, which have to come out as the day
+// they were written
+func TestTransactionDates(t *testing.T) {
+	forEachStore(t, func(t *testing.T) {
+		if err := InitSchema(); err != nil {
+			t.Fatal(err)
+		}
+		tx := types.Transaction{
+			Date:          "2021-01-02",
+			TransactionID: "t1",
+			Description:   "Coffee",
+			Amount:        decimal.RequireFromString("-3.5"),
+			AccountID:     "acc",
+		}
+		txn := DBCon.MustBegin()
+		types.PrepTransSt(txn).MustExec(tx)
+		if err := txn.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		stored := types.Transaction{}
+		if err := DBCon.Get(&stored, "SELECT * FROM transactions WHERE transaction_id = 't1'"); err != nil {
+			t.Fatal(err)
+		}
+		if stored.Date != "2021-01-02" {
+			t.Errorf("date scanned as %q, want 2021-01-02", stored.Date)
+		}
+		if stored.Status != "posted" {
+			t.Errorf("status %q, want posted", stored.Status)
+		}
+		want := ImportContentKey("acc", "2021-01-02", tx.Amount, "coffee")
+		if got := ImportContentKey(stored.AccountID, string(stored.Date), stored.Amount, stored.Description); got != want {
+			t.Errorf("content key %q, want %q", got, want)
+		}
+	})
+}
+
+func TestMigrateImportTransactionIDs(t *testing.T) {
+	forEachStore(t, func(t *testing.T) {
+		if err := InitSchema(); err != nil {
+			t.Fatal(err)
+		}
+		for _, acc := range [][2]string{{"imp", "Import"}, {"pl", "Plaid"}, {"se", "SaltEdge"}} {
+			DBCon.MustExec("INSERT INTO accounts (account_id, name, provider) VALUES($1, $1, $2)", acc[0], acc[1])
+		}
+		txn := DBCon.MustBegin()
+		st := types.PrepTransSt(txn)
+		rows := []struct {
+			id, account, desc string
+		}{
+			{"111", "imp", "Coffee"},
+			{"222", "imp", "coffee "},
+			{"333", "pl", "Rent"},
+			{"pl-abc", "pl", "Rent"},
+			{"444", "se", "Rent"},
+		}
+		for _, r := range rows {
+			st.MustExec(types.Transaction{Date: "2021-01-02", TransactionID: r.id, Description: r.desc, Amount: decimal.RequireFromString("-3.5"), AccountID: r.account})
+		}
+		if err := txn.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		txn = DBCon.MustBegin()
+		if err := migrateImportTransactionIDs(txn); err != nil {
+			t.Fatal(err)
+		}
+		if err := txn.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		got := []string{}
+		if err := DBCon.Select(&got, "SELECT transaction_id FROM transactions ORDER BY id"); err != nil {
+			t.Fatal(err)
+		}
+		coffee := ImportContentKey("imp", "2021-01-02", decimal.RequireFromString("-3.5"), "coffee")
+		want := []string{
+			ImportTransactionID(coffee, 0),
+			// The same content again is a second occurrence, not the same row
+			ImportTransactionID(coffee, 1),
+			ImportTransactionID(ImportContentKey("pl", "2021-01-02", decimal.RequireFromString("-3.5"), "rent"), 0),
+			"pl-abc",
+			"444",
+		}
+		for i := range want {
+			if i >= len(got) || got[i] != want[i] {
+				t.Fatalf("transaction IDs %q, want %q", got, want)
+			}
+		}
+	})
+}
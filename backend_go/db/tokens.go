@@ -0,0 +1,49 @@
+package db
+
+import (
+	"fin-go/secrets"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// resealTokens replaces every stored access token with what reseal makes of
+// it, in txn
+func resealTokens(txn *sqlx.Tx, reseal func(string) (string, error)) error {
+	rows := []struct {
+		ID          int    `db:"id"`
+		AccessToken string `db:"access_token"`
+	}{}
+	if err := txn.Select(&rows, "SELECT id, access_token FROM item_tokens WHERE access_token <> ''"); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		token, err := reseal(row.AccessToken)
+		if err != nil {
+			return err
+		}
+		if _, err := txn.Exec("UPDATE item_tokens SET access_token = $1 WHERE id = $2", token, row.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateSealTokens encrypts the access tokens stored in the clear before
+// they were sealed on write
+func migrateSealTokens(txn *sqlx.Tx) error {
+	return resealTokens(txn, secrets.Seal)
+}
+
+// ResealTokens has rewrap rewrap every stored access token in one
+// transaction, for secrets.Rotate
+func ResealTokens(rewrap func(string) (string, error)) error {
+	txn, err := DBCon.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+	if err := resealTokens(txn, rewrap); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// transactionColumns were left out of the transactions table for a while even
+// though the app kept reading and writing them
+var transactionColumns = []struct {
+	name       string
+	definition string
+}{
+	{"original_description", "TEXT DEFAULT ''"},
+	{"transaction_type", "TEXT DEFAULT ''"},
+	{"labels", "TEXT DEFAULT ''"},
+	{"notes", "TEXT DEFAULT ''"},
+}
+
+// migrateTransactionColumns adds the columns above to databases created
+// without them, setting the transaction type of existing rows from the sign of
+// their amount. Only databases from before migrations can be missing them.
+func migrateTransactionColumns(txn *sqlx.Tx) error {
+	names := []string{}
+	if err := txn.Select(&names, "SELECT name FROM pragma_table_info('transactions')"); err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	for _, col := range transactionColumns {
+		if existing[col.name] {
+			continue
+		}
+		if _, err := txn.Exec(fmt.Sprintf("ALTER TABLE transactions ADD COLUMN %s %s", col.name, col.definition)); err != nil {
+			return err
+		}
+		if col.name == "transaction_type" {
+			_, err := txn.Exec("UPDATE transactions SET transaction_type = CASE WHEN amount < 0 THEN 'debit' ELSE 'credit' END")
+			if err != nil {
+				return err
+			}
+		}
+		log.Printf("Added column %s to transactions", col.name)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package exporters
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"fin-go/types"
+)
+
+// csvHeader is the example.csv (Mint) layout, so exports import again with
+// the Mint profile, followed by the normalized amount and its currency
+var csvHeader = []string{"Date", "Description", "Original Description", "Amount", "Transaction Type",
+	"Category", "Account Name", "Labels", "Notes", "currency_code", "normalized_amount", "base_currency"}
+
+type csvWriter struct {
+	w    *csv.Writer
+	base string
+}
+
+func newCSVWriter(w io.Writer, opts Options) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), base: opts.BaseCurrency}
+	if err := cw.w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// Write adds a row with unsigned amounts and the sign in Transaction Type,
+// the way Mint exports them
+func (cw *csvWriter) Write(tx types.Transaction) error {
+	date := txDate(tx)
+	if dt, err := time.Parse("2006-01-02", date); err == nil {
+		date = dt.Format("01/02/2006")
+	}
+	original := tx.OriginalDescription
+	if original == "" {
+		original = tx.Description
+	}
+
+	return cw.w.Write([]string{
+		date,
+		tx.Description,
+		original,
+		tx.Amount.Abs().String(),
+		txType(tx),
+		tx.CategoryName,
+		tx.AccountName,
+		tx.Labels,
+		tx.Notes,
+		tx.CurrencyCode,
+		tx.NormalizedAmount.Abs().String(),
+		cw.base,
+	})
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,110 @@
+package exporters
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrUnknownFormat is returned for an export format none of the writers handle
+var ErrUnknownFormat = errors.New("unrecognized export format")
+
+// Options carries what a format needs to know about the whole export before
+// the first row is written
+type Options struct {
+	BaseCurrency string
+	Accounts     map[string]types.Account
+	Categories   map[int]types.Category
+	// Price returns the value of one unit of a currency in the base currency
+	// on a YYYY-MM-DD date, or zero when there is no rate
+	Price func(currency, date string) decimal.Decimal
+	// Start and End are the YYYY-MM-DD bounds of the exported rows
+	Start string
+	End   string
+}
+
+// Writer streams transactions out in one export format. Close finishes the
+// document but doesn't close the underlying io.Writer.
+type Writer interface {
+	Write(tx types.Transaction) error
+	Close() error
+}
+
+// Format describes an export format for the HTTP response
+type Format struct {
+	Name        string
+	ContentType string
+	Extension   string
+	// Grouped formats need rows ordered by account and currency,
+	// chronological ones oldest first
+	Grouped       bool
+	Chronological bool
+}
+
+var formats = map[string]Format{
+	"csv":       {"csv", "text/csv; charset=utf-8", ".csv", false, false},
+	"ofx":       {"ofx", "application/x-ofx", ".ofx", true, false},
+	"ndjson":    {"ndjson", "application/x-ndjson", ".ndjson", false, false},
+	"beancount": {"beancount", "text/plain; charset=utf-8", ".beancount", false, true},
+	"ledger":    {"ledger", "text/plain; charset=utf-8", ".ledger", false, true},
+}
+
+// LookupFormat returns the format for a name, with json and jsonl accepted as
+// aliases for ndjson, hledger for ledger and csv used when the name is empty
+func LookupFormat(name string) (Format, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "":
+		name = "csv"
+	case "json", "jsonl":
+		name = "ndjson"
+	case "hledger":
+		name = "ledger"
+	}
+	f, ok := formats[name]
+	if !ok {
+		return f, ErrUnknownFormat
+	}
+	return f, nil
+}
+
+// NewWriter starts an export in the given format, writing any header straight away
+func NewWriter(f Format, w io.Writer, opts Options) (Writer, error) {
+	switch f.Name {
+	case "csv":
+		return newCSVWriter(w, opts)
+	case "ofx":
+		return newOFXWriter(w, opts)
+	case "ndjson":
+		return newNDJSONWriter(w, opts), nil
+	case "beancount":
+		return newLedgerWriter(w, opts, true)
+	case "ledger":
+		return newLedgerWriter(w, opts, false)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// txDate returns the YYYY-MM-DD part of a stored transaction date
+func txDate(tx types.Transaction) string {
+	if len(tx.Date) > 10 {
+		return string(tx.Date[:10])
+	}
+	return string(tx.Date)
+}
+
+// txType is the stored transaction type, or debit/credit from the amount sign
+// for rows that don't have one
+func txType(tx types.Transaction) string {
+	if tx.TransactionType != "" {
+		return tx.TransactionType
+	}
+	if tx.Amount.IsNegative() {
+		return "debit"
+	}
+	return "credit"
+}
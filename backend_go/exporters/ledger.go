@@ -0,0 +1,284 @@
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+// ledgerWriter writes a plain-text accounting journal, either beancount or the
+// ledger syntax hledger reads as well. Accounts are opened as
+// Assets:/Liabilities:<Institution>:<Name> and categories as
+// Expenses:<Top>:<Sub> or Income:<Sub>, with fin's IDs kept as metadata so the
+// journal can be imported again. Every transaction becomes an entry with one
+// posting to the account and one to the category.
+type ledgerWriter struct {
+	w          io.Writer
+	beancount  bool
+	opts       Options
+	openDate   string
+	used       map[string]bool
+	accounts   map[string]string
+	categories map[int]string
+	prices     map[string]bool
+}
+
+func newLedgerWriter(w io.Writer, opts Options, beancount bool) (*ledgerWriter, error) {
+	lw := &ledgerWriter{
+		w:          w,
+		beancount:  beancount,
+		opts:       opts,
+		openDate:   opts.Start,
+		used:       map[string]bool{},
+		accounts:   map[string]string{},
+		categories: map[int]string{},
+		prices:     map[string]bool{},
+	}
+	today := time.Now().Format("2006-01-02")
+	if lw.openDate == "" {
+		lw.openDate = today
+	}
+
+	if beancount {
+		_, err := fmt.Fprintf(w, "; Exported from fin on %s\noption \"operating_currency\" \"%s\"\n\n", today, lw.baseCurrency())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		_, err := fmt.Fprintf(w, "; Exported from fin on %s, base currency %s\n\n", today, lw.baseCurrency())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	accs := []types.Account{}
+	for _, acc := range opts.Accounts {
+		accs = append(accs, acc)
+	}
+	sort.Slice(accs, func(i, j int) bool { return accs[i].ID < accs[j].ID })
+	for _, acc := range accs {
+		if err := lw.openAccount(acc); err != nil {
+			return nil, err
+		}
+	}
+
+	cats := []types.Category{}
+	for _, cat := range opts.Categories {
+		cats = append(cats, cat)
+	}
+	sort.Slice(cats, func(i, j int) bool { return cats[i].ID < cats[j].ID })
+	for _, cat := range cats {
+		if err := lw.openCategory(cat); err != nil {
+			return nil, err
+		}
+	}
+
+	return lw, nil
+}
+
+func (lw *ledgerWriter) baseCurrency() string {
+	if lw.opts.BaseCurrency == "" {
+		return "USD"
+	}
+	return lw.opts.BaseCurrency
+}
+
+// ledgerComponent turns a name into an account name component: letters and
+// digits with dashes between words, starting with a capital letter
+func ledgerComponent(name string) string {
+	var b strings.Builder
+	gap := false
+	for _, r := range strings.Replace(name, "'", "", -1) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			gap = true
+			continue
+		}
+		if gap && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		gap = false
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" {
+		return "Unnamed"
+	}
+	first, size := utf8.DecodeRuneInString(out)
+	return string(unicode.ToUpper(first)) + out[size:]
+}
+
+// unique makes sure two fin accounts or categories never share a journal account
+func (lw *ledgerWriter) unique(path string) string {
+	name := path
+	for n := 2; lw.used[name]; n++ {
+		name = path + "-" + strconv.Itoa(n)
+	}
+	lw.used[name] = true
+	return name
+}
+
+func (lw *ledgerWriter) quote(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if lw.beancount {
+		s = strings.Replace(s, `\`, `\\`, -1)
+		return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+	}
+	return s
+}
+
+func (lw *ledgerWriter) date(date string) string {
+	if lw.beancount {
+		return date
+	}
+	return strings.Replace(date, "-", "/", -1)
+}
+
+// open writes an open (beancount) or account (ledger) directive with metadata
+func (lw *ledgerWriter) open(account string, meta [][2]string) error {
+	var b strings.Builder
+	if lw.beancount {
+		fmt.Fprintf(&b, "%s open %s\n", lw.openDate, account)
+	} else {
+		fmt.Fprintf(&b, "account %s\n", account)
+	}
+	lw.writeMeta(&b, meta)
+	b.WriteString("\n")
+	_, err := io.WriteString(lw.w, b.String())
+	return err
+}
+
+func (lw *ledgerWriter) writeMeta(b *strings.Builder, meta [][2]string) {
+	for _, m := range meta {
+		if m[1] == "" {
+			continue
+		}
+		if lw.beancount {
+			fmt.Fprintf(b, "  %s: %s\n", m[0], lw.quote(m[1]))
+		} else {
+			fmt.Fprintf(b, "    ; %s: %s\n", m[0], lw.quote(m[1]))
+		}
+	}
+}
+
+func (lw *ledgerWriter) openAccount(acc types.Account) error {
+	root := "Assets"
+	kind := strings.ToLower(acc.Type + " " + acc.Subtype)
+	if strings.Contains(kind, "credit") || strings.Contains(kind, "loan") {
+		root = "Liabilities"
+	}
+	institution := acc.Institution
+	if institution == "" {
+		institution = acc.Provider
+	}
+	path := lw.unique(root + ":" + ledgerComponent(institution) + ":" + ledgerComponent(acc.Name))
+	lw.accounts[acc.AccountID] = path
+	return lw.open(path, [][2]string{{"account_id", acc.AccountID}, {"name", acc.Name}, {"provider", acc.Provider}})
+}
+
+func (lw *ledgerWriter) openCategory(cat types.Category) error {
+	var path, name string
+	switch {
+	case cat.TopCategory == "Income" && cat.SubCategory == "Income":
+		path, name = "Income:General", "Income"
+	case cat.TopCategory == "Income":
+		path, name = "Income:"+ledgerComponent(cat.SubCategory), "Income:"+cat.SubCategory
+	case cat.TopCategory == cat.SubCategory:
+		path, name = "Expenses:"+ledgerComponent(cat.TopCategory), cat.SubCategory
+	default:
+		path = "Expenses:" + ledgerComponent(cat.TopCategory) + ":" + ledgerComponent(cat.SubCategory)
+		name = cat.TopCategory + ":" + cat.SubCategory
+	}
+	path = lw.unique(path)
+	lw.categories[cat.ID] = path
+	return lw.open(path, [][2]string{{"category", name}})
+}
+
+// price writes the rate of a foreign currency on the transaction date, once
+// per currency and day
+func (lw *ledgerWriter) price(currency, date string) error {
+	if currency == lw.baseCurrency() || lw.opts.Price == nil || lw.prices[currency+date] {
+		return nil
+	}
+	lw.prices[currency+date] = true
+	rate := lw.opts.Price(currency, date)
+	if rate.IsZero() {
+		return nil
+	}
+	var err error
+	if lw.beancount {
+		_, err = fmt.Fprintf(lw.w, "%s price %s %s %s\n\n", date, currency, rate.Round(8).String(), lw.baseCurrency())
+	} else {
+		_, err = fmt.Fprintf(lw.w, "P %s %s %s %s\n\n", lw.date(date), currency, rate.Round(8).String(), lw.baseCurrency())
+	}
+	return err
+}
+
+func (lw *ledgerWriter) posting(b *strings.Builder, account string, amount decimal.Decimal, currency string) {
+	indent := "  "
+	if !lw.beancount {
+		indent = "    "
+	}
+	fmt.Fprintf(b, "%s%-56s  %s %s\n", indent, account, amount.String(), currency)
+}
+
+func (lw *ledgerWriter) Write(tx types.Transaction) error {
+	date := txDate(tx)
+	currency := strings.ToUpper(tx.CurrencyCode)
+	if currency == "" {
+		currency = lw.baseCurrency()
+	}
+	if err := lw.price(currency, date); err != nil {
+		return err
+	}
+
+	account, ok := lw.accounts[tx.AccountID]
+	if !ok {
+		// Transactions whose account has since been removed
+		if err := lw.openAccount(types.Account{AccountID: tx.AccountID, Name: tx.AccountName, Institution: "Unknown"}); err != nil {
+			return err
+		}
+		account = lw.accounts[tx.AccountID]
+	}
+	category, ok := lw.categories[tx.Category]
+	if !ok {
+		category, ok = lw.categories[106]
+	}
+	if !ok {
+		if err := lw.openCategory(types.Category{ID: 106, TopCategory: "Uncategorized", SubCategory: "Uncategorized"}); err != nil {
+			return err
+		}
+		category = lw.categories[106]
+	}
+
+	var b strings.Builder
+	if lw.beancount {
+		fmt.Fprintf(&b, "%s * %s\n", date, lw.quote(tx.Description))
+	} else {
+		fmt.Fprintf(&b, "%s * %s\n", lw.date(date), lw.quote(tx.Description))
+	}
+	original := tx.OriginalDescription
+	if original == tx.Description {
+		original = ""
+	}
+	lw.writeMeta(&b, [][2]string{{"transaction_id", tx.TransactionID}, {"original_description", original},
+		{"labels", tx.Labels}, {"notes", tx.Notes}})
+	lw.posting(&b, account, tx.Amount, currency)
+	lw.posting(&b, category, tx.Amount.Neg(), currency)
+	b.WriteString("\n")
+
+	_, err := io.WriteString(lw.w, b.String())
+	return err
+}
+
+func (lw *ledgerWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,39 @@
+package exporters
+
+import (
+	"encoding/json"
+	"io"
+
+	"fin-go/types"
+)
+
+// ndjsonRow is a stored transaction with the provider of its account and the
+// currency normalized_amount is in
+type ndjsonRow struct {
+	types.Transaction
+	Provider     string `json:"provider"`
+	BaseCurrency string `json:"base_currency"`
+}
+
+type ndjsonWriter struct {
+	enc      *json.Encoder
+	base     string
+	accounts map[string]types.Account
+}
+
+func newNDJSONWriter(w io.Writer, opts Options) *ndjsonWriter {
+	return &ndjsonWriter{enc: json.NewEncoder(w), base: opts.BaseCurrency, accounts: opts.Accounts}
+}
+
+// Write adds one JSON object per line, Encode ends each with a newline
+func (nw *ndjsonWriter) Write(tx types.Transaction) error {
+	return nw.enc.Encode(ndjsonRow{
+		Transaction:  tx,
+		Provider:     nw.accounts[tx.AccountID].Provider,
+		BaseCurrency: nw.base,
+	})
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nil
+}
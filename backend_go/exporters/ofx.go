@@ -0,0 +1,132 @@
+package exporters
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"fin-go/types"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+// ofxWriter writes an OFX 2.2 document with a bank statement per account and
+// currency, so rows must arrive grouped by account_id and currency_code. The
+// normalized amount goes in FIN. prefixed elements, which OFX reserves for
+// private extensions and other readers ignore.
+type ofxWriter struct {
+	w        io.Writer
+	opts     Options
+	now      string
+	open     bool
+	account  string
+	currency string
+	trnUID   int
+}
+
+func newOFXWriter(w io.Writer, opts Options) (*ofxWriter, error) {
+	ow := &ofxWriter{w: w, opts: opts, now: time.Now().UTC().Format("20060102150405")}
+	_, err := fmt.Fprintf(w, "%s<OFX>\n<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>"+
+		"<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n<BANKMSGSRSV1>\n", ofxHeader, ow.now)
+	if err != nil {
+		return nil, err
+	}
+	return ow, nil
+}
+
+func ofxEscape(s string) string {
+	return html.EscapeString(s)
+}
+
+func ofxDate(date string) string {
+	return strings.Replace(date, "-", "", -1)
+}
+
+// ofxAccountType maps Plaid and SaltEdge account types to an OFX ACCTTYPE
+func ofxAccountType(acc types.Account) string {
+	kind := strings.ToLower(acc.Type + " " + acc.Subtype)
+	switch {
+	case strings.Contains(kind, "credit"):
+		return "CREDITLINE"
+	case strings.Contains(kind, "saving"):
+		return "SAVINGS"
+	case strings.Contains(kind, "money market"):
+		return "MONEYMRKT"
+	}
+	return "CHECKING"
+}
+
+func (ow *ofxWriter) startStatement(tx types.Transaction) error {
+	ow.trnUID++
+	ow.open = true
+	ow.account = tx.AccountID
+	ow.currency = tx.CurrencyCode
+
+	acc := ow.opts.Accounts[tx.AccountID]
+	bankID := acc.Institution
+	if bankID == "" {
+		bankID = acc.Provider
+	}
+	_, err := fmt.Fprintf(ow.w, "<STMTTRNRS><TRNUID>%d</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n"+
+		"<STMTRS><CURDEF>%s</CURDEF>\n"+
+		"<BANKACCTFROM><BANKID>%s</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>%s</ACCTTYPE></BANKACCTFROM>\n"+
+		"<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n",
+		ow.trnUID, ofxEscape(tx.CurrencyCode), ofxEscape(bankID), ofxEscape(tx.AccountID), ofxAccountType(acc),
+		ofxDate(ow.opts.Start), ofxDate(ow.opts.End))
+	return err
+}
+
+// endStatement closes the open statement, the ledger balance is only known
+// for the account's own currency
+func (ow *ofxWriter) endStatement() error {
+	ow.open = false
+	acc := ow.opts.Accounts[ow.account]
+	balance := "0"
+	if acc.Currency == "" || strings.EqualFold(acc.Currency, ow.currency) {
+		balance = acc.Balance.String()
+	}
+	_, err := fmt.Fprintf(ow.w, "</BANKTRANLIST>\n<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n</STMTRS></STMTTRNRS>\n",
+		balance, ow.now)
+	return err
+}
+
+func (ow *ofxWriter) Write(tx types.Transaction) error {
+	if ow.open && (tx.AccountID != ow.account || tx.CurrencyCode != ow.currency) {
+		if err := ow.endStatement(); err != nil {
+			return err
+		}
+	}
+	if !ow.open {
+		if err := ow.startStatement(tx); err != nil {
+			return err
+		}
+	}
+
+	trnType := "CREDIT"
+	if tx.Amount.IsNegative() {
+		trnType = "DEBIT"
+	}
+	memo := tx.OriginalDescription
+	if memo == "" {
+		memo = tx.Description
+	}
+	_, err := fmt.Fprintf(ow.w, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID>"+
+		"<NAME>%s</NAME><MEMO>%s</MEMO><FIN.NORMALIZEDAMT>%s</FIN.NORMALIZEDAMT><FIN.BASECURRENCY>%s</FIN.BASECURRENCY></STMTTRN>\n",
+		trnType, ofxDate(txDate(tx)), tx.Amount.String(), ofxEscape(tx.TransactionID),
+		ofxEscape(tx.Description), ofxEscape(memo), tx.NormalizedAmount.String(), ofxEscape(ow.opts.BaseCurrency))
+	return err
+}
+
+func (ow *ofxWriter) Close() error {
+	if ow.open {
+		if err := ow.endStatement(); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(ow.w, "</BANKMSGSRSV1>\n</OFX>\n")
+	return err
+}
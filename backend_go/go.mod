@@ -1,6 +1,6 @@
 module fin-go
 
-go 1.14
+go 1.16
 
 require (
 	github.com/go-delve/delve v1.4.1 // indirect
@@ -9,14 +9,16 @@ require (
 	github.com/gorilla/websocket v1.4.2 // indirect
 	github.com/jmoiron/sqlx v1.2.0
 	github.com/joho/godotenv v1.3.0 // indirect
+	github.com/lib/pq v1.10.0
 	github.com/mattn/go-sqlite3 v2.0.3+incompatible
 	github.com/plaid/plaid-go v0.0.0-20200515230911-ec588277465e
 	github.com/rickb777/date v1.12.5
 	github.com/shopspring/decimal v1.2.0
 	github.com/tamerh/xml-stream-parser v1.4.0
 	github.com/tamerh/xpath v1.0.0 // indirect
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	golang.org/x/sync v0.0.0-20200317015054-43a5402ce75a // indirect
 	google.golang.org/appengine v1.6.6 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
-	gopkg.in/yaml.v2 v2.3.0 // indirect
+	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,262 @@
+package importers
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+	xmlparser "github.com/tamerh/xml-stream-parser"
+)
+
+var camtPrefixRe = regexp.MustCompile(`<([A-Za-z0-9_]+:)?BkToCstmrStmt[\s>]`)
+
+// camtDoc carries the namespace prefix used in the file, since the stream
+// parser matches element names literally
+type camtDoc struct {
+	prefix string
+}
+
+func (d camtDoc) el(el *xmlparser.XMLElement, names ...string) *xmlparser.XMLElement {
+	cur := el
+	for _, name := range names {
+		childs := cur.Childs[d.prefix+name]
+		if len(childs) == 0 {
+			return nil
+		}
+		cur = &childs[0]
+	}
+	return cur
+}
+
+func (d camtDoc) all(el *xmlparser.XMLElement, names ...string) []xmlparser.XMLElement {
+	if len(names) == 0 {
+		return nil
+	}
+	parent := d.el(el, names[:len(names)-1]...)
+	if parent == nil {
+		return nil
+	}
+	return parent.Childs[d.prefix+names[len(names)-1]]
+}
+
+func (d camtDoc) text(el *xmlparser.XMLElement, names ...string) string {
+	if found := d.el(el, names...); found != nil {
+		return strings.TrimSpace(html.UnescapeString(found.InnerText))
+	}
+	return ""
+}
+
+// date reads either a Dt or DtTm child, returning only the date part
+func (d camtDoc) date(el *xmlparser.XMLElement, name string) string {
+	if dt := d.text(el, name, "Dt"); dt != "" {
+		return dt
+	}
+	if dt := d.text(el, name, "DtTm"); len(dt) >= 10 {
+		return dt[:10]
+	}
+	return ""
+}
+
+// party reads a party name from both the camt.053.001.02 layout (Cdtr/Nm)
+// and the newer layout (Cdtr/Pty/Nm)
+func (d camtDoc) party(el *xmlparser.XMLElement, role string) string {
+	if nm := d.text(el, "RltdPties", role, "Nm"); nm != "" {
+		return nm
+	}
+	return d.text(el, "RltdPties", role, "Pty", "Nm")
+}
+
+// ParseCAMT053 reads an ISO 20022 camt.053 bank-to-customer statement. Each
+// booked entry becomes an ImportTransaction (or one per transaction for batch
+// entries) using the statement IBAN as account name and the entry currency.
+func ParseCAMT053(r io.Reader) ([]types.ImportTransaction, error) {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	m := camtPrefixRe.FindSubmatch(raw)
+	if m == nil {
+		return nil, errors.New("no BkToCstmrStmt element found in camt.053 file")
+	}
+	d := camtDoc{prefix: string(m[1])}
+
+	br := bufio.NewReaderSize(bytes.NewReader(raw), 65536)
+	parser := xmlparser.NewXMLParser(br, d.prefix+"Stmt")
+
+	txs := []types.ImportTransaction{}
+	for stmt := range parser.Stream() {
+		if stmt.Err != nil {
+			return nil, stmt.Err
+		}
+
+		acctID := d.text(stmt, "Acct", "Id", "IBAN")
+		if acctID == "" {
+			acctID = d.text(stmt, "Acct", "Id", "Othr", "Id")
+		}
+		if acctID == "" {
+			return nil, errors.New("camt.053 statement is missing its account ID")
+		}
+		acctCcy := d.text(stmt, "Acct", "Ccy")
+
+		for i := range stmt.Childs[d.prefix+"Ntry"] {
+			ntryTxs, err := d.entry(&stmt.Childs[d.prefix+"Ntry"][i], acctID, acctCcy)
+			if err != nil {
+				return nil, err
+			}
+			txs = append(txs, ntryTxs...)
+		}
+	}
+
+	return txs, nil
+}
+
+func (d camtDoc) entry(ntry *xmlparser.XMLElement, acctID, acctCcy string) ([]types.ImportTransaction, error) {
+	status := d.text(ntry, "Sts")
+	if status == "" {
+		status = d.text(ntry, "Sts", "Cd")
+	}
+	if status != "" && status != "BOOK" {
+		// Pending and informational entries show up again once booked
+		return nil, nil
+	}
+
+	ref := d.text(ntry, "AcctSvcrRef")
+	if ref == "" {
+		ref = d.text(ntry, "NtryRef")
+	}
+
+	base := types.ImportTransaction{}
+	base.AccountName = acctID
+	base.Date = d.date(ntry, "BookgDt")
+	base.ValueDate = d.date(ntry, "ValDt")
+	if base.Date == "" {
+		base.Date = base.ValueDate
+	}
+	if base.Date == "" {
+		return nil, fmt.Errorf("camt.053 entry %q has no booking or value date", ref)
+	}
+	if d.text(ntry, "CdtDbtInd") == "DBIT" {
+		base.TransactionType = "debit"
+	} else {
+		base.TransactionType = "credit"
+	}
+	addtl := d.text(ntry, "AddtlNtryInf")
+
+	txDtls := d.all(ntry, "NtryDtls", "TxDtls")
+	if amts := d.txAmounts(txDtls); len(txDtls) > 1 && amts != nil {
+		// Batch booking: split into the individual transactions
+		txs := []types.ImportTransaction{}
+		for i, amt := range amts {
+			itx := base
+			if err := d.amount(&itx, amt, acctCcy); err != nil {
+				return nil, fmt.Errorf("camt.053 entry %q: %v", ref, err)
+			}
+			if ind := d.text(&txDtls[i], "CdtDbtInd"); ind == "DBIT" {
+				itx.TransactionType = "debit"
+			} else if ind == "CRDT" {
+				itx.TransactionType = "credit"
+			}
+			d.details(&itx, &txDtls[i], addtl)
+			if txRef := d.text(&txDtls[i], "Refs", "AcctSvcrRef"); txRef != "" {
+				itx.ExternalID = txRef
+			} else if ref != "" {
+				itx.ExternalID = ref + "-" + strconv.Itoa(i+1)
+			}
+			txs = append(txs, itx)
+		}
+		return txs, nil
+	}
+
+	itx := base
+	if err := d.amount(&itx, d.el(ntry, "Amt"), acctCcy); err != nil {
+		return nil, fmt.Errorf("camt.053 entry %q: %v", ref, err)
+	}
+	var dtl *xmlparser.XMLElement
+	if len(txDtls) > 0 {
+		dtl = &txDtls[0]
+	}
+	d.details(&itx, dtl, addtl)
+	if ref == "" && dtl != nil {
+		ref = d.text(dtl, "Refs", "AcctSvcrRef")
+	}
+	itx.ExternalID = ref
+	return []types.ImportTransaction{itx}, nil
+}
+
+// txAmounts returns the amount of every transaction in a batch entry, or nil
+// if any of them lacks one
+func (d camtDoc) txAmounts(txDtls []xmlparser.XMLElement) []*xmlparser.XMLElement {
+	amts := make([]*xmlparser.XMLElement, len(txDtls))
+	for i := range txDtls {
+		amts[i] = d.el(&txDtls[i], "AmtDtls", "TxAmt", "Amt")
+		if amts[i] == nil {
+			amts[i] = d.el(&txDtls[i], "Amt")
+		}
+		if amts[i] == nil {
+			return nil
+		}
+	}
+	return amts
+}
+
+func (d camtDoc) amount(itx *types.ImportTransaction, amt *xmlparser.XMLElement, acctCcy string) error {
+	if amt == nil {
+		return errors.New("missing amount")
+	}
+	value, err := decimal.NewFromString(strings.TrimSpace(amt.InnerText))
+	if err != nil {
+		return fmt.Errorf("invalid amount %q", amt.InnerText)
+	}
+	itx.Amount = value.Abs()
+	itx.CurrencyCode = strings.ToUpper(amt.Attrs["Ccy"])
+	if itx.CurrencyCode == "" {
+		itx.CurrencyCode = strings.ToUpper(acctCcy)
+	}
+	return nil
+}
+
+// details fills counterparty and remittance information, and notes the
+// instructed amount when the transaction was made in another currency
+func (d camtDoc) details(itx *types.ImportTransaction, dtl *xmlparser.XMLElement, addtl string) {
+	remittance := addtl
+	if dtl != nil {
+		if itx.TransactionType == "debit" {
+			itx.Counterparty = d.party(dtl, "Cdtr")
+		} else {
+			itx.Counterparty = d.party(dtl, "Dbtr")
+		}
+		ustrd := []string{}
+		for _, line := range d.all(dtl, "RmtInf", "Ustrd") {
+			ustrd = append(ustrd, strings.TrimSpace(html.UnescapeString(line.InnerText)))
+		}
+		if len(ustrd) > 0 {
+			remittance = strings.Join(ustrd, " ")
+		} else if info := d.text(dtl, "AddtlTxInf"); info != "" {
+			remittance = info
+		}
+
+		if instd := d.el(dtl, "AmtDtls", "InstdAmt", "Amt"); instd != nil {
+			if ccy := strings.ToUpper(instd.Attrs["Ccy"]); ccy != "" && ccy != itx.CurrencyCode {
+				itx.Notes = "Original amount: " + strings.TrimSpace(instd.InnerText) + " " + ccy
+			}
+		}
+	}
+
+	itx.OriginalDescription = remittance
+	if itx.Counterparty != "" {
+		itx.Description = itx.Counterparty
+	} else {
+		itx.Description = remittance
+	}
+}
@@ -0,0 +1,102 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+)
+
+const camtStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+<BkToCstmrStmt>
+<Stmt>
+  <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+  <Ntry>
+    <Amt Ccy="EUR">12.50</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts>BOOK</Sts>
+    <BookgDt><Dt>2021-03-01</Dt></BookgDt><ValDt><Dt>2021-03-02</Dt></ValDt>
+    <AcctSvcrRef>REF1</AcctSvcrRef>
+    <NtryDtls><TxDtls>
+      <RltdPties><Cdtr><Nm>Bakery &amp; Co</Nm></Cdtr></RltdPties>
+      <RmtInf><Ustrd>Bread</Ustrd></RmtInf>
+      <AmtDtls><InstdAmt><Amt Ccy="CHF">13.40</Amt></InstdAmt></AmtDtls>
+    </TxDtls></NtryDtls>
+  </Ntry>
+  <Ntry>
+    <Amt Ccy="EUR">99.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts>PDNG</Sts>
+    <BookgDt><Dt>2021-03-03</Dt></BookgDt>
+  </Ntry>
+  <Ntry>
+    <Amt Ccy="EUR">300.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts>
+    <BookgDt><DtTm>2021-03-04T10:00:00</DtTm></BookgDt>
+    <AcctSvcrRef>BATCH</AcctSvcrRef>
+    <NtryDtls>
+      <TxDtls><AmtDtls><TxAmt><Amt Ccy="EUR">100.00</Amt></TxAmt></AmtDtls><RltdPties><Dbtr><Pty><Nm>Alice</Nm></Pty></Dbtr></RltdPties></TxDtls>
+      <TxDtls><Refs><AcctSvcrRef>B2</AcctSvcrRef></Refs><AmtDtls><TxAmt><Amt Ccy="EUR">200.00</Amt></TxAmt></AmtDtls><AddtlTxInf>Refund</AddtlTxInf></TxDtls>
+    </NtryDtls>
+  </Ntry>
+</Stmt>
+</BkToCstmrStmt>
+</Document>`
+
+func TestParseCAMT053(t *testing.T) {
+	const iban = "DE89370400440532013000"
+	tests := []struct {
+		name    string
+		data    string
+		want    []imported
+		wantErr bool
+	}{
+		{
+			name: "booked, pending and batch entries",
+			data: camtStatement,
+			want: []imported{
+				{"2021-03-01", "12.5", "debit", iban, "EUR", "Bakery & Co", "REF1"},
+				{"2021-03-04", "100", "credit", iban, "EUR", "Alice", "BATCH-1"},
+				{"2021-03-04", "200", "credit", iban, "EUR", "Refund", "B2"},
+			},
+		},
+		{
+			name: "namespace prefix and other account ID",
+			data: `<d:Document xmlns:d="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"><d:BkToCstmrStmt><d:Stmt>
+				<d:Acct><d:Id><d:Othr><d:Id>12345</d:Id></d:Othr></d:Id></d:Acct>
+				<d:Ntry><d:Amt Ccy="usd">5</d:Amt><d:CdtDbtInd>DBIT</d:CdtDbtInd><d:ValDt><d:Dt>2021-01-01</d:Dt></d:ValDt><d:NtryRef>N1</d:NtryRef><d:AddtlNtryInf>Fee</d:AddtlNtryInf></d:Ntry>
+				</d:Stmt></d:BkToCstmrStmt></d:Document>`,
+			want: []imported{{"2021-01-01", "5", "debit", "12345", "USD", "Fee", "N1"}},
+		},
+		{
+			name:    "no statement",
+			data:    `<Document></Document>`,
+			wantErr: true,
+		},
+		{
+			name:    "no account",
+			data:    `<Document><BkToCstmrStmt><Stmt></Stmt></BkToCstmrStmt></Document>`,
+			wantErr: true,
+		},
+		{
+			name:    "no date",
+			data:    `<Document><BkToCstmrStmt><Stmt><Acct><Id><IBAN>X</IBAN></Id></Acct><Ntry><Amt Ccy="EUR">1</Amt></Ntry></Stmt></BkToCstmrStmt></Document>`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseCAMT053(strings.NewReader(tt.data))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		checkImported(t, tt.name, got, tt.want)
+	}
+
+	got, _ := ParseCAMT053(strings.NewReader(camtStatement))
+	if len(got) > 0 {
+		if got[0].Notes != "Original amount: 13.40 CHF" || got[0].OriginalDescription != "Bread" || got[0].ValueDate != "2021-03-02" {
+			t.Errorf("details = %q, %q, %q", got[0].Notes, got[0].OriginalDescription, got[0].ValueDate)
+		}
+	}
+}
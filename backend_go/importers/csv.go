@@ -0,0 +1,285 @@
+package importers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fin-go/types"
+)
+
+// csvColumns maps the profile column specs to indexes in a record, -1 when unset
+type csvColumns struct {
+	date, description, originalDescription, amount, debit, credit, txType int
+	category, account, currency, labels, notes                            int
+}
+
+func csvReader(data []byte, delimiter string) *csv.Reader {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	if delimiter == `\t` || delimiter == "tab" {
+		r.Comma = '\t'
+	} else if delimiter != "" {
+		r.Comma = []rune(delimiter)[0]
+	}
+	return r
+}
+
+// csvColumn resolves a column spec, which is either a header name or a
+// zero-based index
+func csvColumn(spec string, header []string) (int, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return -1, nil
+	}
+	if i, err := strconv.Atoi(spec); err == nil {
+		return i, nil
+	}
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), spec) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("column %q not found in CSV header", spec)
+}
+
+func csvResolve(p types.ImportProfile, header []string) (csvColumns, error) {
+	c := csvColumns{}
+	specs := []struct {
+		dst  *int
+		spec string
+	}{
+		{&c.date, p.DateColumn},
+		{&c.description, p.DescriptionColumn},
+		{&c.originalDescription, p.OriginalDescriptionColumn},
+		{&c.amount, p.AmountColumn},
+		{&c.debit, p.DebitColumn},
+		{&c.credit, p.CreditColumn},
+		{&c.txType, p.TypeColumn},
+		{&c.category, p.CategoryColumn},
+		{&c.account, p.AccountColumn},
+		{&c.currency, p.CurrencyColumn},
+		{&c.labels, p.LabelsColumn},
+		{&c.notes, p.NotesColumn},
+	}
+	for _, s := range specs {
+		i, err := csvColumn(s.spec, header)
+		if err != nil {
+			return c, err
+		}
+		*s.dst = i
+	}
+
+	if c.date < 0 {
+		return c, errors.New("import profile has no date column")
+	}
+	if p.SignConvention == "debit_credit" {
+		if c.debit < 0 || c.credit < 0 {
+			return c, errors.New("import profile needs debit and credit columns")
+		}
+	} else if c.amount < 0 {
+		return c, errors.New("import profile has no amount column")
+	}
+	if p.SignConvention == "type_column" && c.txType < 0 {
+		return c, errors.New("import profile has no transaction type column")
+	}
+	return c, nil
+}
+
+func csvField(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// nonZero reports whether an amount has a digit other than 0, whichever its
+// decimal separator
+func nonZero(amount string) bool {
+	return strings.ContainsAny(amount, "123456789")
+}
+
+// ParseCSV reads a CSV export using the column mapping of an import profile.
+// Dates and amounts are read by Normalize with opts, the profile's date
+// format and decimal separator filling in for those opts leave unset. Rows
+// that can't be read are all returned as RowErrors, numbered by their line in
+// the file, and so is a file whose dates could be either day or month first
+// when neither opts nor the profile say which.
+func ParseCSV(data []byte, p types.ImportProfile, opts types.ImportOptions) ([]types.ImportTransaction, types.ImportParseInfo, error) {
+	info := types.ImportParseInfo{}
+	records, err := csvReader(data, p.Delimiter).ReadAll()
+	if err != nil {
+		return nil, info, err
+	}
+	if p.SkipRows > 0 {
+		if p.SkipRows >= len(records) {
+			return []types.ImportTransaction{}, info, nil
+		}
+		records = records[p.SkipRows:]
+	}
+
+	header := []string{}
+	if p.HasHeader && len(records) > 0 {
+		header = records[0]
+		records = records[1:]
+	}
+	cols, err := csvResolve(p, header)
+	if err != nil {
+		return nil, info, err
+	}
+
+	if opts.DateFormat == "" && opts.DateOrder == "" {
+		opts.DateFormat = p.DateFormat
+	}
+	if opts.DecimalSeparator == "" {
+		opts.DecimalSeparator = p.DecimalSeparator
+	}
+
+	txs := []types.ImportTransaction{}
+	// lines holds the line in the file of each transaction
+	lines := []int{}
+	rowErrs := RowErrors{}
+	for n, record := range records {
+		// Line numbers count the skipped rows and header
+		line := n + 1 + p.SkipRows
+		if p.HasHeader {
+			line++
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		itx := types.ImportTransaction{}
+		itx.Date = csvField(record, cols.date)
+
+		switch p.SignConvention {
+		case "debit_credit":
+			// Many banks fill the other column with zero rather than nothing
+			debit, credit := csvField(record, cols.debit), csvField(record, cols.credit)
+			switch {
+			case nonZero(debit) && nonZero(credit):
+				rowErrs = append(rowErrs, types.ImportRowError{Row: line, Field: "amount", Value: debit + " / " + credit, Error: "both debit and credit"})
+				continue
+			case nonZero(credit) || debit == "":
+				itx.RawAmount = credit
+				itx.TransactionType = "credit"
+			default:
+				itx.RawAmount = debit
+				itx.TransactionType = "debit"
+			}
+		case "type_column":
+			itx.RawAmount = csvField(record, cols.amount)
+			txType := strings.ToLower(csvField(record, cols.txType))
+			if strings.HasPrefix(txType, "d") || txType == "withdrawal" {
+				itx.TransactionType = "debit"
+			} else {
+				itx.TransactionType = "credit"
+			}
+		default:
+			itx.RawAmount = csvField(record, cols.amount)
+		}
+		if itx.RawAmount == "" {
+			rowErrs = append(rowErrs, types.ImportRowError{Row: line, Field: "amount", Error: "no amount"})
+			continue
+		}
+
+		itx.Description = csvField(record, cols.description)
+		itx.OriginalDescription = csvField(record, cols.originalDescription)
+		if itx.OriginalDescription == "" {
+			itx.OriginalDescription = itx.Description
+		}
+		itx.Category = csvField(record, cols.category)
+		itx.AccountName = csvField(record, cols.account)
+		if itx.AccountName == "" {
+			itx.AccountName = p.DefaultAccount
+		}
+		itx.CurrencyCode = strings.ToUpper(csvField(record, cols.currency))
+		if itx.CurrencyCode == "" {
+			itx.CurrencyCode = strings.ToUpper(p.DefaultCurrency)
+		}
+		itx.Labels = csvField(record, cols.labels)
+		itx.Notes = csvField(record, cols.notes)
+
+		txs = append(txs, itx)
+		lines = append(lines, line)
+	}
+
+	txs, info, normErrs := Normalize(txs, opts)
+	for _, e := range normErrs {
+		if e.Row > 0 {
+			e.Row = lines[e.Row-1]
+		}
+		rowErrs = append(rowErrs, e)
+	}
+	if len(rowErrs) > 0 {
+		sort.SliceStable(rowErrs, func(i, j int) bool { return rowErrs[i].Row < rowErrs[j].Row })
+		return nil, info, rowErrs
+	}
+	if info.DateAmbiguous && opts.Locale == "" {
+		return nil, info, RowErrors{{Field: "date", Error: "dates could be day or month first, give a locale, date order or date format"}}
+	}
+
+	if p.SignConvention == "inverted" {
+		for i := range txs {
+			if txs[i].TransactionType == "debit" {
+				txs[i].TransactionType = "credit"
+			} else {
+				txs[i].TransactionType = "debit"
+			}
+		}
+	}
+	return txs, info, nil
+}
+
+// DetectProfile returns the profile whose named header columns best match the
+// first row of the file. Only profiles with a header are considered, and
+// every column they name has to be present.
+func DetectProfile(data []byte, profiles []types.ImportProfile) (types.ImportProfile, bool) {
+	best := types.ImportProfile{}
+	bestScore := 0
+	for _, p := range profiles {
+		if !p.HasHeader {
+			continue
+		}
+		r := csvReader(data, p.Delimiter)
+		var header []string
+		var err error
+		for i := 0; i <= p.SkipRows; i++ {
+			header, err = r.Read()
+			if err != nil {
+				break
+			}
+		}
+		if err != nil && err != io.EOF || len(header) < 2 {
+			continue
+		}
+
+		score := 0
+		matched := true
+		for _, spec := range []string{p.DateColumn, p.DescriptionColumn, p.OriginalDescriptionColumn,
+			p.AmountColumn, p.DebitColumn, p.CreditColumn, p.TypeColumn, p.CategoryColumn, p.AccountColumn,
+			p.CurrencyColumn, p.LabelsColumn, p.NotesColumn} {
+			if spec == "" {
+				continue
+			}
+			if _, err := strconv.Atoi(spec); err == nil {
+				continue
+			}
+			if _, err := csvColumn(spec, header); err != nil {
+				matched = false
+				break
+			}
+			score++
+		}
+		if matched && score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+	return best, bestScore > 0
+}
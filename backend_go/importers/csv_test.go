@@ -0,0 +1,246 @@
+package importers
+
+import (
+	"testing"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseCSVNormalizes(t *testing.T) {
+	profile := types.ImportProfile{
+		Name:              "bank",
+		Delimiter:         ";",
+		HasHeader:         true,
+		DateColumn:        "Datum",
+		DescriptionColumn: "Text",
+		AmountColumn:      "Betrag",
+		DefaultCurrency:   "eur",
+	}
+	type want struct {
+		date, amount, txType string
+	}
+	tests := []struct {
+		name    string
+		data    string
+		profile func(types.ImportProfile) types.ImportProfile
+		opts    types.ImportOptions
+		want    []want
+		errRows []int
+	}{
+		{
+			name: "detected order and decimal",
+			data: "Datum;Text;Betrag\n13.01.2020;Rent;-1.234,56\n14.01.2020;Pay;2.000,00\n",
+			want: []want{{"2020-01-13", "1234.56", "debit"}, {"2020-01-14", "2000", "credit"}},
+		},
+		{
+			name: "locale",
+			data: "Datum;Text;Betrag\n01.02.2020;Rent;-12,50\n",
+			opts: types.ImportOptions{Locale: "de-DE"},
+			want: []want{{"2020-02-01", "12.5", "debit"}},
+		},
+		{
+			name: "date order",
+			data: "Datum;Text;Betrag\n01/02/2020;Rent;-12.50\n",
+			opts: types.ImportOptions{DateOrder: OrderDMY},
+			want: []want{{"2020-02-01", "12.5", "debit"}},
+		},
+		{
+			name:    "ambiguous without locale",
+			data:    "Datum;Text;Betrag\n01/02/2020;Rent;-12.50\n",
+			errRows: []int{0},
+		},
+		{
+			name:    "profile date format",
+			data:    "Datum;Text;Betrag\n01/02/2020;Rent;-12.50\n",
+			profile: func(p types.ImportProfile) types.ImportProfile { p.DateFormat = "DD/MM/YYYY"; return p },
+			want:    []want{{"2020-02-01", "12.5", "debit"}},
+		},
+		{
+			name:    "bad rows by line",
+			data:    "Datum;Text;Betrag\n13.01.2020;Rent;x\n14.01.2020;Pay;1\n33.01.2020;Pay;2\n15.01.2020;Pay;\n",
+			errRows: []int{2, 4, 5},
+		},
+		{
+			name: "type column",
+			data: "Datum;Text;Betrag;Art\n2020-01-13;Rent;-12.00;debit\n2020-01-14;Pay;-3;credit\n",
+			profile: func(p types.ImportProfile) types.ImportProfile {
+				p.TypeColumn, p.SignConvention = "Art", "type_column"
+				return p
+			},
+			want: []want{{"2020-01-13", "12", "debit"}, {"2020-01-14", "3", "credit"}},
+		},
+		{
+			name: "debit and credit columns",
+			data: "Datum;Text;Soll;Haben\n2020-01-13;Rent;12,00;\n2020-01-14;Pay;;3,00\n",
+			profile: func(p types.ImportProfile) types.ImportProfile {
+				p.AmountColumn, p.DebitColumn, p.CreditColumn, p.SignConvention = "", "Soll", "Haben", "debit_credit"
+				return p
+			},
+			want: []want{{"2020-01-13", "12", "debit"}, {"2020-01-14", "3", "credit"}},
+		},
+		{
+			name: "zero in the other column",
+			data: "Datum;Text;Soll;Haben\n2020-01-13;Rent;12,00;0,00\n2020-01-14;Pay;0,00;3,00\n2020-01-15;Fee;0,00;\n",
+			profile: func(p types.ImportProfile) types.ImportProfile {
+				p.AmountColumn, p.DebitColumn, p.CreditColumn, p.SignConvention = "", "Soll", "Haben", "debit_credit"
+				return p
+			},
+			want: []want{{"2020-01-13", "12", "debit"}, {"2020-01-14", "3", "credit"}, {"2020-01-15", "0", "debit"}},
+		},
+		{
+			name: "debit and credit both set",
+			data: "Datum;Text;Soll;Haben\n2020-01-13;Rent;12,00;0,00\n2020-01-14;Pay;1,00;3,00\n",
+			profile: func(p types.ImportProfile) types.ImportProfile {
+				p.AmountColumn, p.DebitColumn, p.CreditColumn, p.SignConvention = "", "Soll", "Haben", "debit_credit"
+				return p
+			},
+			errRows: []int{3},
+		},
+		{
+			name: "inverted",
+			data: "Datum;Text;Betrag\n2020-01-13;Card;12.00\n2020-01-14;Refund;-3\n",
+			profile: func(p types.ImportProfile) types.ImportProfile {
+				p.SignConvention = "inverted"
+				return p
+			},
+			want: []want{{"2020-01-13", "12", "debit"}, {"2020-01-14", "3", "credit"}},
+		},
+	}
+	for _, tt := range tests {
+		p := profile
+		if tt.profile != nil {
+			p = tt.profile(p)
+		}
+		got, _, err := ParseCSV([]byte(tt.data), p, tt.opts)
+		if tt.errRows != nil {
+			rowErrs, ok := err.(RowErrors)
+			if !ok {
+				t.Errorf("%s: err = %v, want row errors", tt.name, err)
+				continue
+			}
+			rows := []int{}
+			for _, e := range rowErrs {
+				rows = append(rows, e.Row)
+			}
+			if len(rows) != len(tt.errRows) {
+				t.Errorf("%s: errors on rows %v, want %v", tt.name, rows, tt.errRows)
+				continue
+			}
+			for i := range rows {
+				if rows[i] != tt.errRows[i] {
+					t.Errorf("%s: errors on rows %v, want %v", tt.name, rows, tt.errRows)
+					break
+				}
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: %d transactions, want %d", tt.name, len(got), len(tt.want))
+			continue
+		}
+		for i, w := range tt.want {
+			g := got[i]
+			if g.Date != w.date || !g.Amount.Equal(decimal.RequireFromString(w.amount)) || g.TransactionType != w.txType {
+				t.Errorf("%s: row %d = %s %s %s, want %s %s %s", tt.name, i+1, g.Date, g.Amount, g.TransactionType, w.date, w.amount, w.txType)
+			}
+			if g.CurrencyCode != "EUR" {
+				t.Errorf("%s: row %d currency %q, want EUR", tt.name, i+1, g.CurrencyCode)
+			}
+		}
+	}
+}
+
+func TestParseCSVMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		profile types.ImportProfile
+		want    []imported
+		wantErr bool
+	}{
+		{
+			name: "skipped rows, tabs and column indexes",
+			data: "Export of 2020\n\n13.01.2020\tRent\t-12,50\tGiro\tchf\n",
+			profile: types.ImportProfile{Delimiter: `\t`, SkipRows: 1, DateColumn: "0", DescriptionColumn: "1", AmountColumn: "2",
+				AccountColumn: "3", CurrencyColumn: "4"},
+			want: []imported{{"2020-01-13", "12.5", "debit", "Giro", "CHF", "Rent", ""}},
+		},
+		{
+			name: "header names ignore case and defaults fill in",
+			data: "\xef\xbb\xbfDATE,Memo,Amount\n2020-01-13,Pay,3\n",
+			profile: types.ImportProfile{Delimiter: ",", HasHeader: true, DateColumn: "date", DescriptionColumn: "memo", AmountColumn: " amount ",
+				DefaultAccount: "Cash", DefaultCurrency: "usd"},
+			want: []imported{{"2020-01-13", "3", "credit", "Cash", "USD", "Pay", ""}},
+		},
+		{
+			name:    "missing header column",
+			data:    "Date,Amount\n2020-01-13,3\n",
+			profile: types.ImportProfile{Delimiter: ",", HasHeader: true, DateColumn: "Date", AmountColumn: "Betrag"},
+			wantErr: true,
+		},
+		{
+			name:    "no amount column",
+			data:    "2020-01-13,3\n",
+			profile: types.ImportProfile{Delimiter: ",", DateColumn: "0"},
+			wantErr: true,
+		},
+		{
+			name:    "debit and credit need both columns",
+			data:    "2020-01-13,3\n",
+			profile: types.ImportProfile{Delimiter: ",", DateColumn: "0", DebitColumn: "1", SignConvention: "debit_credit"},
+			wantErr: true,
+		},
+		{
+			name:    "type column missing",
+			data:    "2020-01-13,3\n",
+			profile: types.ImportProfile{Delimiter: ",", DateColumn: "0", AmountColumn: "1", SignConvention: "type_column"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		got, _, err := ParseCSV([]byte(tt.data), tt.profile, types.ImportOptions{})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		checkImported(t, tt.name, got, tt.want)
+	}
+}
+
+func TestDetectProfile(t *testing.T) {
+	profiles := []types.ImportProfile{
+		{Name: "no header", DateColumn: "0", AmountColumn: "1"},
+		{Name: "short", Delimiter: ";", HasHeader: true, DateColumn: "Datum", AmountColumn: "Betrag"},
+		{Name: "long", Delimiter: ";", HasHeader: true, DateColumn: "Datum", AmountColumn: "Betrag", DescriptionColumn: "Text"},
+		{Name: "other bank", Delimiter: ",", HasHeader: true, DateColumn: "Date", AmountColumn: "Amount"},
+		{Name: "after a title", Delimiter: ",", HasHeader: true, SkipRows: 1, DateColumn: "Booked", AmountColumn: "Value"},
+	}
+	tests := []struct {
+		data, want string
+	}{
+		{"Datum;Betrag;Text\n", "long"},
+		{"datum;betrag\n", "short"},
+		{"Date,Amount,Balance\n", "other bank"},
+		{"Statement\nBooked,Value\n", "after a title"},
+		{"Date;Amount\n", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, ok := DetectProfile([]byte(tt.data), profiles)
+		if ok != (tt.want != "") || got.Name != tt.want {
+			t.Errorf("DetectProfile(%q) = %q, %v, want %q", tt.data, got.Name, ok, tt.want)
+		}
+	}
+}
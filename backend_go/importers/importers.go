@@ -0,0 +1,63 @@
+package importers
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"fin-go/types"
+)
+
+// ErrUnknownFormat is returned when an uploaded file matches none of the parsers
+var ErrUnknownFormat = errors.New("unrecognized import file format")
+
+var (
+	beancountSniff = regexp.MustCompile(`(?m)^(option "|\d{4}-\d\d-\d\d (open|txn|\*|!) )`)
+	ledgerSniff    = regexp.MustCompile(`(?m)^(account [A-Z]|\d{4}[/-]\d\d?[/-]\d\d?(=\S+)? [*!]? ?\S)`)
+)
+
+// Parse picks a parser based on the file extension, falling back to sniffing
+// the contents, and returns the transactions found in the file
+func Parse(filename string, data []byte) ([]types.ImportTransaction, error) {
+	ext := filepath.Ext(filename)
+	// QIF files without !Account blocks get an account named after the file
+	qifAccount := strings.TrimSuffix(filepath.Base(filename), ext)
+
+	switch strings.ToLower(ext) {
+	case ".qif":
+		return ParseQIF(bytes.NewReader(data), qifAccount)
+	case ".ofx", ".qfx":
+		return ParseOFX(bytes.NewReader(data))
+	case ".sta", ".mt940", ".940":
+		return ParseMT940(bytes.NewReader(data))
+	case ".beancount", ".bean":
+		return ParseBeancount(bytes.NewReader(data))
+	case ".ledger", ".journal", ".hledger":
+		return ParseLedger(bytes.NewReader(data))
+	}
+
+	head := data
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	upper := bytes.ToUpper(head)
+	switch {
+	case bytes.Contains(upper, []byte("OFXHEADER")) || bytes.Contains(upper, []byte("<OFX>")):
+		return ParseOFX(bytes.NewReader(data))
+	case bytes.HasPrefix(bytes.TrimSpace(head), []byte("!")):
+		// QIF files always open with a !Type, !Account or !Option header
+		return ParseQIF(bytes.NewReader(data), qifAccount)
+	case bytes.Contains(head, []byte("BkToCstmrStmt")):
+		return ParseCAMT053(bytes.NewReader(data))
+	case bytes.Contains(head, []byte(":25:")) && bytes.Contains(data, []byte(":61:")):
+		return ParseMT940(bytes.NewReader(data))
+	case beancountSniff.Match(head):
+		return ParseBeancount(bytes.NewReader(data))
+	case ledgerSniff.Match(head):
+		return ParseLedger(bytes.NewReader(data))
+	}
+
+	return nil, ErrUnknownFormat
+}
@@ -0,0 +1,33 @@
+package importers
+
+import (
+	"testing"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+// imported is the part of an ImportTransaction the parser tests look at
+type imported struct {
+	date, amount, txType, account, currency, description, externalID string
+}
+
+func checkImported(t *testing.T, name string, got []types.ImportTransaction, want []imported) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: %d transactions, want %d: %+v", name, len(got), len(want), got)
+		return
+	}
+	for i, w := range want {
+		g := got[i]
+		gw := imported{g.Date, g.Amount.String(), g.TransactionType, g.AccountName, g.CurrencyCode, g.Description, g.ExternalID}
+		if !g.Amount.Equal(decimal.RequireFromString(w.amount)) {
+			t.Errorf("%s: row %d amount %s, want %s", name, i+1, g.Amount, w.amount)
+		}
+		gw.amount = w.amount
+		if gw != w {
+			t.Errorf("%s: row %d = %+v, want %+v", name, i+1, gw, w)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package importers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	journalDate    = regexp.MustCompile(`^(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})$`)
+	journalMetaKey = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*):\s*(.*)$`)
+	journalSymbols = map[string]string{"$": "USD", "€": "EUR", "£": "GBP", "¥": "JPY"}
+)
+
+type journalPosting struct {
+	account  string
+	amount   decimal.Decimal
+	currency string
+	elided   bool
+}
+
+type journalEntry struct {
+	line      int
+	date      string
+	payee     string
+	narration string
+	meta      map[string]string
+	postings  []journalPosting
+}
+
+// ParseBeancount reads a beancount journal, see parseJournal
+func ParseBeancount(r io.Reader) ([]types.ImportTransaction, error) {
+	return parseJournal(r, true)
+}
+
+// ParseLedger reads a ledger or hledger journal, see parseJournal
+func ParseLedger(r io.Reader) ([]types.ImportTransaction, error) {
+	return parseJournal(r, false)
+}
+
+// parseJournal reads the journals fin exports, and ones edited or written by
+// hand. Postings to Assets: and Liabilities: accounts, or to accounts opened
+// with an account_id, are fin accounts and every other posting is a category.
+// An entry with one fin account posting becomes one transaction per category
+// posting, an entry touching several fin accounts one transaction per account
+// posting. The account_id, name, category and transaction_id metadata fin
+// writes map everything back to the same accounts, categories and rows.
+func parseJournal(r io.Reader, beancount bool) ([]types.ImportTransaction, error) {
+	accounts := map[string]map[string]string{}
+	entries := []*journalEntry{}
+
+	var meta map[string]string
+	var entry *journalEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\xef\xbb\xbf")
+		}
+		if strings.TrimSpace(line) == "" {
+			meta, entry = nil, nil
+			continue
+		}
+
+		if line[0] != ' ' && line[0] != '\t' {
+			meta, entry = nil, nil
+			fields := strings.Fields(line)
+			switch {
+			case !beancount && fields[0] == "account" && len(fields) > 1:
+				name := strings.TrimSpace(strings.SplitN(strings.TrimSpace(line[len("account"):]), ";", 2)[0])
+				name = strings.SplitN(name, "  ", 2)[0]
+				meta = map[string]string{}
+				accounts[name] = meta
+			case beancount && len(fields) > 2 && fields[1] == "open":
+				meta = map[string]string{}
+				accounts[fields[2]] = meta
+			case line[0] >= '0' && line[0] <= '9':
+				var err error
+				entry, err = journalHeader(line, beancount)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", lineNo, err)
+				}
+				if entry != nil {
+					entry.line = lineNo
+					meta = entry.meta
+					entries = append(entries, entry)
+				}
+			}
+			continue
+		}
+
+		if meta == nil {
+			continue
+		}
+		text := strings.TrimSpace(line)
+		if key, value, ok := journalMeta(text, beancount); ok {
+			meta[key] = value
+			continue
+		}
+		if entry == nil || text[0] == ';' || text[0] == '#' {
+			continue
+		}
+		posting, err := journalParsePosting(text, beancount)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+		if posting.account != "" {
+			entry.postings = append(entry.postings, posting)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	txs := []types.ImportTransaction{}
+	for _, e := range entries {
+		etxs, err := journalTransactions(e, accounts)
+		if err != nil {
+			return nil, fmt.Errorf("entry on line %d: %v", e.line, err)
+		}
+		txs = append(txs, etxs...)
+	}
+	if len(txs) == 0 {
+		return nil, errors.New("no transactions found in journal")
+	}
+	return txs, nil
+}
+
+// journalHeader parses the first line of an entry, returning nil for dated
+// directives that aren't transactions
+func journalHeader(line string, beancount bool) (*journalEntry, error) {
+	e := &journalEntry{meta: map[string]string{}}
+	fields := strings.Fields(line)
+	dateField := fields[0]
+	if !beancount {
+		// ledger's auxiliary date comes after an =
+		dateField = strings.SplitN(dateField, "=", 2)[0]
+	}
+	m := journalDate.FindStringSubmatch(dateField)
+	if m == nil {
+		return nil, fmt.Errorf("invalid date %q", fields[0])
+	}
+	month, _ := strconv.Atoi(m[2])
+	day, _ := strconv.Atoi(m[3])
+	e.date = fmt.Sprintf("%s-%02d-%02d", m[1], month, day)
+	rest := strings.TrimSpace(line[len(fields[0]):])
+
+	if beancount {
+		if len(fields) < 2 || (fields[1] != "*" && fields[1] != "!" && fields[1] != "txn") {
+			return nil, nil
+		}
+		strs := journalStrings(rest[len(fields[1]):])
+		switch len(strs) {
+		case 0:
+		case 1:
+			e.narration = strs[0]
+		default:
+			e.payee, e.narration = strs[0], strs[1]
+		}
+		return e, nil
+	}
+
+	if strings.HasPrefix(rest, "*") || strings.HasPrefix(rest, "!") {
+		rest = strings.TrimSpace(rest[1:])
+	}
+	if strings.HasPrefix(rest, "(") {
+		if i := strings.Index(rest, ")"); i >= 0 {
+			rest = strings.TrimSpace(rest[i+1:])
+		}
+	}
+	if i := strings.Index(rest, ";"); i >= 0 {
+		if key, value, ok := journalMeta(rest[i:], false); ok {
+			e.meta[key] = value
+		}
+		rest = strings.TrimSpace(rest[:i])
+	}
+	e.narration = rest
+	return e, nil
+}
+
+// journalStrings returns the double quoted strings in a beancount line
+func journalStrings(s string) []string {
+	strs := []string{}
+	for {
+		start := strings.IndexByte(s, '"')
+		if start < 0 {
+			return strs
+		}
+		var b strings.Builder
+		i := start + 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+			}
+			b.WriteByte(s[i])
+		}
+		strs = append(strs, b.String())
+		if i >= len(s) {
+			return strs
+		}
+		s = s[i+1:]
+	}
+}
+
+// journalMeta reads a metadata line, `key: "value"` in beancount and
+// `; key: value` in ledger
+func journalMeta(text string, beancount bool) (string, string, bool) {
+	if !beancount {
+		if !strings.HasPrefix(text, ";") {
+			return "", "", false
+		}
+		text = strings.TrimSpace(strings.TrimLeft(text, ";"))
+	} else if text == "" || text[0] < 'a' || text[0] > 'z' {
+		return "", "", false
+	}
+	m := journalMetaKey.FindStringSubmatch(text)
+	if m == nil {
+		return "", "", false
+	}
+	value := strings.TrimSpace(m[2])
+	if beancount && strings.HasPrefix(value, `"`) {
+		if strs := journalStrings(value); len(strs) > 0 {
+			value = strs[0]
+		}
+	}
+	return m[1], value, true
+}
+
+// journalParsePosting reads an account and amount, ignoring costs, prices,
+// balance assertions and comments. Virtual (parenthesised) ledger postings
+// don't have to balance, so they're returned without an account and skipped.
+func journalParsePosting(text string, beancount bool) (journalPosting, error) {
+	p := journalPosting{}
+	if i := strings.Index(text, ";"); i >= 0 {
+		text = strings.TrimSpace(text[:i])
+	}
+	if strings.HasPrefix(text, "* ") || strings.HasPrefix(text, "! ") {
+		text = strings.TrimSpace(text[2:])
+	}
+
+	var account, amount string
+	if beancount {
+		parts := strings.Fields(text)
+		if len(parts) == 0 {
+			return p, nil
+		}
+		account = parts[0]
+		amount = strings.TrimSpace(text[len(parts[0]):])
+	} else {
+		i := strings.Index(text, "  ")
+		if j := strings.IndexByte(text, '\t'); j >= 0 && (i < 0 || j < i) {
+			i = j
+		}
+		account = text
+		if i >= 0 {
+			account, amount = text[:i], strings.TrimSpace(text[i:])
+		}
+	}
+
+	if strings.HasPrefix(account, "(") {
+		return p, nil
+	}
+	p.account = strings.Trim(account, "[]")
+
+	if i := strings.IndexAny(amount, "@{="); i >= 0 {
+		amount = strings.TrimSpace(amount[:i])
+	}
+	if amount == "" {
+		p.elided = true
+		return p, nil
+	}
+
+	neg := false
+	if strings.HasPrefix(amount, "-") {
+		neg = true
+		amount = strings.TrimSpace(amount[1:])
+	}
+	start := strings.IndexAny(amount, "-0123456789.")
+	if start < 0 {
+		return p, fmt.Errorf("invalid amount %q", amount)
+	}
+	end := start
+	for end < len(amount) && strings.IndexByte("-0123456789.,", amount[end]) >= 0 {
+		end++
+	}
+	prefix := strings.TrimSpace(amount[:start])
+	suffix := strings.TrimSpace(amount[end:])
+	// beancount numbers always use a decimal point, ledger's follow the commodity format
+	separator := "."
+	if !beancount {
+		separator = detectDecimal([]string{amount[start:end]})
+	}
+	value, err := ParseAmount(amount[start:end], separator)
+	if err != nil {
+		return p, err
+	}
+	if neg {
+		value = value.Neg()
+	}
+	p.amount = value
+
+	commodity := strings.Trim(prefix+suffix, `"`)
+	if code, ok := journalSymbols[commodity]; ok {
+		commodity = code
+	}
+	p.currency = strings.ToUpper(commodity)
+	return p, nil
+}
+
+func journalName(account string) string {
+	parts := strings.Split(account, ":")
+	return strings.Replace(parts[len(parts)-1], "-", " ", -1)
+}
+
+func journalTransactions(e *journalEntry, accounts map[string]map[string]string) ([]types.ImportTransaction, error) {
+	// Fill in the one posting the journal may leave without an amount
+	elided := -1
+	sum := decimal.Zero
+	currency := ""
+	for i, p := range e.postings {
+		if p.elided {
+			if elided >= 0 {
+				return nil, errors.New("more than one posting without an amount")
+			}
+			elided = i
+			continue
+		}
+		sum = sum.Add(p.amount)
+		if currency == "" {
+			currency = p.currency
+		}
+	}
+	if elided >= 0 {
+		e.postings[elided].amount = sum.Neg()
+		e.postings[elided].currency = currency
+	}
+
+	own := []journalPosting{}
+	other := []journalPosting{}
+	for _, p := range e.postings {
+		if accounts[p.account]["account_id"] != "" || strings.HasPrefix(p.account, "Assets:") || strings.HasPrefix(p.account, "Liabilities:") {
+			own = append(own, p)
+		} else {
+			other = append(other, p)
+		}
+	}
+
+	category := func(account string) string {
+		if c := accounts[account]["category"]; c != "" {
+			return c
+		}
+		return journalName(account)
+	}
+
+	base := types.ImportTransaction{
+		Date:                e.date,
+		Description:         e.narration,
+		OriginalDescription: e.meta["original_description"],
+		Labels:              e.meta["labels"],
+		Notes:               e.meta["notes"],
+	}
+	if e.payee != "" {
+		base.Description = e.payee
+		if base.OriginalDescription == "" {
+			base.OriginalDescription = e.narration
+		}
+	}
+	if base.OriginalDescription == "" {
+		base.OriginalDescription = base.Description
+	}
+
+	txs := []types.ImportTransaction{}
+	add := func(account journalPosting, amount decimal.Decimal, currency, cat string) {
+		itx := base
+		itx.AccountID = accounts[account.account]["account_id"]
+		itx.AccountName = accounts[account.account]["name"]
+		if itx.AccountName == "" {
+			itx.AccountName = journalName(account.account)
+		}
+		if amount.IsNegative() {
+			itx.TransactionType = "debit"
+		} else {
+			itx.TransactionType = "credit"
+		}
+		itx.Amount = amount.Abs()
+		itx.CurrencyCode = currency
+		itx.Category = cat
+		// Splits of an exported transaction keep its ID on the first part
+		if id := e.meta["transaction_id"]; id != "" {
+			itx.TransactionID = id
+			if len(txs) > 0 {
+				itx.TransactionID = id + "-" + strconv.Itoa(len(txs)+1)
+			}
+		}
+		txs = append(txs, itx)
+	}
+
+	// Entries between categories only don't touch any account in fin
+	if len(own) == 1 {
+		for _, p := range other {
+			add(own[0], p.amount.Neg(), p.currency, category(p.account))
+		}
+	} else if len(own) > 1 {
+		cat := "Transfer"
+		if len(other) > 0 {
+			cat = category(other[0].account)
+		}
+		for _, p := range own {
+			add(p, p.amount, p.currency, cat)
+		}
+	}
+	return txs, nil
+}
@@ -0,0 +1,133 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+)
+
+const beancountJournal = `option "operating_currency" "EUR"
+
+2020-01-01 open Assets:Bank:Giro EUR
+  account_id: "acc1"
+  name: "Giro"
+2020-01-01 open Expenses:Food
+  category: "Food & Drink"
+
+2020-01-05 * "Bakery" "Bread"
+  transaction_id: "t1"
+  Assets:Bank:Giro  -3.50 EUR
+  Expenses:Food
+
+2020-01-06 txn "Shop"
+  transaction_id: "t2"
+  Assets:Bank:Giro  -10 EUR
+  Expenses:Food  4 EUR
+  Expenses:Home-Garden  6 EUR @ 1.1 USD
+
+2020-01-07 * "Move"
+  Assets:Bank:Giro  -5 EUR
+  Assets:Savings  5 EUR ; to the rainy day fund
+
+2020-01-08 balance Assets:Bank:Giro  100 EUR
+2020-01-09 * "Budget"
+  Expenses:Food  1 EUR
+  Income:Salary
+`
+
+const ledgerJournal = `account Assets:Checking
+    ; account_id: acc2
+    ; name: Checking account
+
+2020/1/2=2020/1/3 * (123) Grocer  ; transaction_id: t3
+    Expenses:Food   $12.00
+    Assets:Checking
+    (Budget:Food)  $-12
+
+2020-01-04 Salary
+    Assets:Checking  1.234,56 EUR
+    Income:Salary
+`
+
+func TestParseJournal(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		beancount bool
+		want      []imported
+		// accountIDs, transactionIDs and categories of the transactions
+		accountIDs, transactionIDs, categories []string
+		wantErr                                bool
+	}{
+		{
+			name:      "beancount",
+			data:      beancountJournal,
+			beancount: true,
+			want: []imported{
+				{"2020-01-05", "3.5", "debit", "Giro", "EUR", "Bakery", ""},
+				{"2020-01-06", "4", "debit", "Giro", "EUR", "Shop", ""},
+				{"2020-01-06", "6", "debit", "Giro", "EUR", "Shop", ""},
+				{"2020-01-07", "5", "debit", "Giro", "EUR", "Move", ""},
+				{"2020-01-07", "5", "credit", "Savings", "EUR", "Move", ""},
+			},
+			accountIDs:     []string{"acc1", "acc1", "acc1", "acc1", ""},
+			transactionIDs: []string{"t1", "t2", "t2-2", "", ""},
+			categories:     []string{"Food & Drink", "Food & Drink", "Home Garden", "Transfer", "Transfer"},
+		},
+		{
+			name: "ledger",
+			data: ledgerJournal,
+			want: []imported{
+				{"2020-01-02", "12", "debit", "Checking account", "USD", "Grocer", ""},
+				{"2020-01-04", "1234.56", "credit", "Checking account", "EUR", "Salary", ""},
+			},
+			accountIDs:     []string{"acc2", "acc2"},
+			transactionIDs: []string{"t3", ""},
+			categories:     []string{"Food", "Salary"},
+		},
+		{
+			name:      "two postings without an amount",
+			data:      "2020-01-01 * \"x\"\n  Assets:Bank\n  Expenses:Food\n",
+			beancount: true,
+			wantErr:   true,
+		},
+		{
+			name:    "bad date",
+			data:    "2020-Jan-01 x\n  Assets:Bank  1 EUR\n  Expenses:Food\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad amount",
+			data:    "2020-01-01 x\n  Assets:Bank  EUR\n  Expenses:Food\n",
+			wantErr: true,
+		},
+		{
+			name:      "nothing to import",
+			data:      "option \"title\" \"Empty\"\n2020-01-01 open Assets:Bank\n",
+			beancount: true,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseJournal(strings.NewReader(tt.data), tt.beancount)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		checkImported(t, tt.name, got, tt.want)
+		for i := range got {
+			if i >= len(tt.categories) {
+				break
+			}
+			if got[i].AccountID != tt.accountIDs[i] || got[i].TransactionID != tt.transactionIDs[i] || got[i].Category != tt.categories[i] {
+				t.Errorf("%s: row %d account %q, ID %q, category %q, want %q, %q, %q", tt.name, i+1,
+					got[i].AccountID, got[i].TransactionID, got[i].Category, tt.accountIDs[i], tt.transactionIDs[i], tt.categories[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package importers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+// Numeric date orders
+const (
+	OrderMDY = "mdy"
+	OrderDMY = "dmy"
+	OrderYMD = "ymd"
+)
+
+type localeFormat struct {
+	dateOrder string
+	decimal   string
+}
+
+// locales maps language tags (lowercase) to their numeric date order and
+// decimal separator. Tags not listed fall back to their language.
+var locales = map[string]localeFormat{
+	"en":    {OrderMDY, "."},
+	"en-us": {OrderMDY, "."},
+	"en-ca": {OrderYMD, "."},
+	"en-gb": {OrderDMY, "."},
+	"en-ie": {OrderDMY, "."},
+	"en-au": {OrderDMY, "."},
+	"en-nz": {OrderDMY, "."},
+	"en-in": {OrderDMY, "."},
+	"en-za": {OrderYMD, ","},
+	"de":    {OrderDMY, ","},
+	"de-ch": {OrderDMY, "."},
+	"fr":    {OrderDMY, ","},
+	"fr-ca": {OrderYMD, ","},
+	"fr-ch": {OrderDMY, "."},
+	"it":    {OrderDMY, ","},
+	"it-ch": {OrderDMY, "."},
+	"es":    {OrderDMY, ","},
+	"es-mx": {OrderDMY, "."},
+	"pt":    {OrderDMY, ","},
+	"nl":    {OrderDMY, ","},
+	"pl":    {OrderDMY, ","},
+	"cs":    {OrderDMY, ","},
+	"da":    {OrderDMY, ","},
+	"nb":    {OrderDMY, ","},
+	"no":    {OrderDMY, ","},
+	"fi":    {OrderDMY, ","},
+	"ru":    {OrderDMY, ","},
+	"tr":    {OrderDMY, ","},
+	"sv":    {OrderYMD, ","},
+	"lt":    {OrderYMD, ","},
+	"hu":    {OrderYMD, ","},
+	"ja":    {OrderYMD, "."},
+	"zh":    {OrderYMD, "."},
+	"ko":    {OrderYMD, "."},
+}
+
+func lookupLocale(tag string) (localeFormat, error) {
+	key := strings.ToLower(strings.Replace(strings.TrimSpace(tag), "_", "-", -1))
+	if lf, ok := locales[key]; ok {
+		return lf, nil
+	}
+	if i := strings.Index(key, "-"); i > 0 {
+		if lf, ok := locales[key[:i]]; ok {
+			return lf, nil
+		}
+	}
+	return localeFormat{}, fmt.Errorf("unknown locale %q", tag)
+}
+
+// RowErrors lists every transaction of an import that could not be read
+type RowErrors []types.ImportRowError
+
+func (e RowErrors) Error() string {
+	if len(e) == 0 {
+		return "no row errors"
+	}
+	return fmt.Sprintf("%d rows could not be read, first on row %d: %s %q: %s", len(e), e[0].Row, e[0].Field, e[0].Value, e[0].Error)
+}
+
+// dateLayout turns a pattern such as "DD.MM.YYYY" into a Go time layout.
+// Formats that are already Go layouts are returned unchanged.
+func dateLayout(format string) string {
+	if strings.Contains(format, "2006") || strings.Contains(format, "06") {
+		return format
+	}
+	return strings.NewReplacer(
+		"YYYY", "2006", "yyyy", "2006",
+		"YY", "06", "yy", "06",
+		"MM", "01", "mm", "01",
+		"DD", "02", "dd", "02",
+		"M", "1", "m", "1",
+		"D", "2", "d", "2",
+	).Replace(format)
+}
+
+// dateFields splits a numeric date such as 31.12.2020, 12/31/20 or
+// 2020-12-31 into its three parts, dropping any time of day
+func dateFields(value string) []string {
+	v := strings.TrimSpace(value)
+	if i := strings.IndexAny(v, " T"); i > 0 {
+		v = v[:i]
+	}
+	fields := strings.FieldsFunc(v, func(r rune) bool {
+		return r == '/' || r == '.' || r == '-'
+	})
+	if len(fields) != 3 {
+		return nil
+	}
+	for _, f := range fields {
+		if _, err := strconv.Atoi(f); err != nil {
+			return nil
+		}
+	}
+	return fields
+}
+
+// detectDateOrder looks at every date in the file for a day above 12, which
+// settles whether it is written day or month first. It returns an empty order
+// when no date decides it, and an error when the file has dates both ways.
+func detectDateOrder(values []string) (string, error) {
+	dmy, mdy := "", ""
+	for _, v := range values {
+		f := dateFields(v)
+		if f == nil || len(f[0]) == 4 {
+			continue
+		}
+		a, _ := strconv.Atoi(f[0])
+		b, _ := strconv.Atoi(f[1])
+		if a > 12 && dmy == "" {
+			dmy = v
+		}
+		if b > 12 && mdy == "" {
+			mdy = v
+		}
+	}
+	switch {
+	case dmy != "" && mdy != "":
+		return "", fmt.Errorf("file mixes day-first (%s) and month-first (%s) dates", dmy, mdy)
+	case dmy != "":
+		return OrderDMY, nil
+	case mdy != "":
+		return OrderMDY, nil
+	}
+	return "", nil
+}
+
+// parseDate reads a numeric date in the given order, always accepting ISO
+// dates, and returns it as YYYY-MM-DD
+func parseDate(value, order string) (string, error) {
+	f := dateFields(value)
+	if f == nil {
+		return "", fmt.Errorf("not a numeric date")
+	}
+	n := [3]int{}
+	for i := range f {
+		n[i], _ = strconv.Atoi(f[i])
+	}
+
+	var year, month, day int
+	switch {
+	case len(f[0]) == 4 || order == OrderYMD:
+		year, month, day = n[0], n[1], n[2]
+	case order == OrderDMY:
+		day, month, year = n[0], n[1], n[2]
+	default:
+		month, day, year = n[0], n[1], n[2]
+	}
+	yearLen := len(f[2])
+	if len(f[0]) == 4 || order == OrderYMD {
+		yearLen = len(f[0])
+	}
+	if yearLen <= 2 {
+		if year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if month < 1 || month > 12 || t.Day() != day {
+		return "", fmt.Errorf("no such date in %s order", order)
+	}
+	return t.Format("2006-01-02"), nil
+}
+
+// onlyISO reports whether every numeric date is written year first, which
+// leaves no order to guess
+func onlyISO(values []string) bool {
+	for _, v := range values {
+		if f := dateFields(v); f != nil && len(f[0]) != 4 {
+			return false
+		}
+	}
+	return true
+}
+
+// detectDecimal guesses the decimal separator from all amounts of the file.
+// A separator followed by other than three digits can only be a decimal one.
+func detectDecimal(values []string) string {
+	dot, comma := 0, 0
+	for _, v := range values {
+		lastDot := strings.LastIndex(v, ".")
+		lastComma := strings.LastIndex(v, ",")
+		switch {
+		case lastDot >= 0 && lastComma >= 0:
+			if lastDot > lastComma {
+				dot++
+			} else {
+				comma++
+			}
+		case lastComma >= 0:
+			if digitsAfter(v, lastComma) != 3 {
+				comma++
+			}
+		case lastDot >= 0:
+			if digitsAfter(v, lastDot) != 3 {
+				dot++
+			}
+		}
+	}
+	if comma > 0 && dot == 0 {
+		return ","
+	}
+	return "."
+}
+
+func digitsAfter(v string, i int) int {
+	n := 0
+	for _, r := range v[i+1:] {
+		if !unicode.IsDigit(r) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// ParseAmount reads an amount written with the given decimal separator. It
+// accepts thousands separators (".", ",", spaces and apostrophes, which have to
+// be followed by three digits), currency symbols and codes, and negative
+// amounts written as -12,50, 12,50- or (12.50). Symbols, codes and signs go
+// before or after the number, never inside it.
+func ParseAmount(value, decimalSeparator string) (decimal.Decimal, error) {
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+	dec := []rune(decimalSeparator)[0]
+
+	raw := []rune(strings.TrimSpace(value))
+	negative := false
+	seenDecimal := false
+	digits := 0
+	// ended is set by anything but digits and separators after the number
+	ended := false
+	var b strings.Builder
+	for i, r := range raw {
+		isDigit := r >= '0' && r <= '9'
+		if ended && (isDigit || r == dec || r == '.' || r == ',' || r == '\'' || r == '’') {
+			return decimal.Zero, fmt.Errorf("unexpected %q after the number", r)
+		}
+		switch {
+		case isDigit:
+			b.WriteRune(r)
+			digits++
+		case r == dec:
+			if seenDecimal {
+				return decimal.Zero, fmt.Errorf("more than one decimal separator")
+			}
+			seenDecimal = true
+			b.WriteRune('.')
+		case r == ' ' || r == '\u00a0' || r == '\u202f':
+			// Spaces group thousands or set off a currency symbol
+			if digits > 0 && !seenDecimal && startsWithDigit(raw[i+1:]) && !groupOfThree(raw[i+1:]) {
+				return decimal.Zero, fmt.Errorf("space inside a number")
+			}
+		case r == '.' || r == ',' || r == '\'' || r == '’':
+			if digits == 0 || seenDecimal {
+				return decimal.Zero, fmt.Errorf("misplaced %q", r)
+			}
+			if !groupOfThree(raw[i+1:]) {
+				return decimal.Zero, fmt.Errorf("%q is not the decimal separator %q", r, dec)
+			}
+		case r == '-' || r == '−' || r == '(' || r == ')':
+			negative = true
+			ended = digits > 0
+		case r == '+':
+			ended = digits > 0
+		case unicode.IsLetter(r) || unicode.Is(unicode.Sc, r):
+			// Currency symbols and codes, e.g. "€", "$" or "EUR"
+			ended = digits > 0
+		default:
+			return decimal.Zero, fmt.Errorf("unexpected %q", r)
+		}
+	}
+	if digits == 0 {
+		return decimal.Zero, fmt.Errorf("no digits")
+	}
+
+	amt, err := decimal.NewFromString(b.String())
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if negative {
+		amt = amt.Neg()
+	}
+	return amt, nil
+}
+
+func startsWithDigit(rest []rune) bool {
+	return len(rest) > 0 && rest[0] >= '0' && rest[0] <= '9'
+}
+
+// groupOfThree reports whether a thousands separator is followed by exactly
+// three digits
+func groupOfThree(rest []rune) bool {
+	n := 0
+	for _, r := range rest {
+		if r < '0' || r > '9' {
+			break
+		}
+		n++
+	}
+	return n == 3
+}
+
+// blankRow matches the empty trailing lines CSV parsers hand back
+func blankRow(itx types.ImportTransaction) bool {
+	return itx.RawAmount == "" && itx.Amount.IsZero()
+}
+
+// Normalize reads the dates and text amounts of submitted transactions with
+// the import options, rewriting dates as YYYY-MM-DD and filling Amount. When
+// no date format or order is given, the order of day and month is detected
+// from the whole file, and only when no date settles it the locale (or mm/dd,
+// the order of Mint exports) is used. All rows are checked and every failure
+// is returned, so callers can refuse the import as a whole.
+func Normalize(txs []types.ImportTransaction, opts types.ImportOptions) ([]types.ImportTransaction, types.ImportParseInfo, RowErrors) {
+	info := types.ImportParseInfo{}
+	rowErrs := RowErrors{}
+
+	lf := localeFormat{}
+	if opts.Locale != "" {
+		var err error
+		lf, err = lookupLocale(opts.Locale)
+		if err != nil {
+			rowErrs = append(rowErrs, types.ImportRowError{Row: 0, Field: "locale", Value: opts.Locale, Error: err.Error()})
+			return nil, info, rowErrs
+		}
+	}
+
+	dates := []string{}
+	amounts := []string{}
+	for _, itx := range txs {
+		if blankRow(itx) {
+			continue
+		}
+		dates = append(dates, itx.Date)
+		if itx.RawAmount != "" {
+			amounts = append(amounts, itx.RawAmount)
+		}
+	}
+
+	layout := ""
+	switch {
+	case opts.DateFormat != "":
+		layout = dateLayout(opts.DateFormat)
+		info.DateOrder = layout
+	case opts.DateOrder != "":
+		if opts.DateOrder != OrderMDY && opts.DateOrder != OrderDMY && opts.DateOrder != OrderYMD {
+			err := fmt.Errorf("date order is not one of %s, %s or %s", OrderMDY, OrderDMY, OrderYMD)
+			rowErrs = append(rowErrs, types.ImportRowError{Row: 0, Field: "dateOrder", Value: opts.DateOrder, Error: err.Error()})
+			return nil, info, rowErrs
+		}
+		info.DateOrder = opts.DateOrder
+	default:
+		order, err := detectDateOrder(dates)
+		if err != nil {
+			rowErrs = append(rowErrs, types.ImportRowError{Row: 0, Field: "date", Error: err.Error()})
+			return nil, info, rowErrs
+		}
+		if order != "" && lf.dateOrder != "" && lf.dateOrder != OrderYMD && order != lf.dateOrder {
+			err := fmt.Errorf("dates are written %s but locale %s uses %s", order, opts.Locale, lf.dateOrder)
+			rowErrs = append(rowErrs, types.ImportRowError{Row: 0, Field: "date", Error: err.Error()})
+			return nil, info, rowErrs
+		}
+		if order == "" && onlyISO(dates) {
+			order = OrderYMD
+		}
+		if order == "" {
+			info.DateAmbiguous = true
+			order = lf.dateOrder
+		}
+		if order == "" {
+			// Dotted dates are day-first wherever they are used
+			order = OrderMDY
+			for _, d := range dates {
+				if strings.Contains(d, ".") && dateFields(d) != nil {
+					order = OrderDMY
+					break
+				}
+			}
+		}
+		info.DateOrder = order
+	}
+
+	info.DecimalSeparator = opts.DecimalSeparator
+	if info.DecimalSeparator == "" {
+		info.DecimalSeparator = lf.decimal
+	}
+	if info.DecimalSeparator == "" {
+		info.DecimalSeparator = detectDecimal(amounts)
+	}
+
+	out := make([]types.ImportTransaction, len(txs))
+	for i, itx := range txs {
+		row := i + 1
+		if blankRow(itx) {
+			// Skipped by the import like any zero amount
+			out[i] = itx
+			continue
+		}
+
+		if layout != "" {
+			t, err := time.Parse(layout, strings.TrimSpace(itx.Date))
+			if err != nil {
+				rowErrs = append(rowErrs, types.ImportRowError{Row: row, Field: "date", Value: itx.Date, Error: fmt.Sprintf("does not match %s", opts.DateFormat)})
+			} else {
+				itx.Date = t.Format("2006-01-02")
+			}
+		} else if dt, err := parseDate(itx.Date, info.DateOrder); err != nil {
+			rowErrs = append(rowErrs, types.ImportRowError{Row: row, Field: "date", Value: itx.Date, Error: err.Error()})
+		} else {
+			itx.Date = dt
+		}
+
+		// Amounts sent as numbers are read already
+		amt, read := itx.Amount, true
+		if itx.RawAmount != "" {
+			var err error
+			if amt, err = ParseAmount(itx.RawAmount, info.DecimalSeparator); err != nil {
+				rowErrs = append(rowErrs, types.ImportRowError{Row: row, Field: "amount", Value: itx.RawAmount, Error: err.Error()})
+				read = false
+			}
+			itx.RawAmount = ""
+		}
+		if read {
+			// The type column gives the sign, whatever the amount's own,
+			// and a signed amount without one its type
+			itx.Amount = amt.Abs()
+			if itx.TransactionType == "" {
+				if amt.IsNegative() {
+					itx.TransactionType = "debit"
+				} else {
+					itx.TransactionType = "credit"
+				}
+			}
+		}
+
+		out[i] = itx
+	}
+
+	if len(rowErrs) > 0 {
+		return nil, info, rowErrs
+	}
+	return out, info, nil
+}
@@ -0,0 +1,282 @@
+package importers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value, sep, want string
+		wantErr          bool
+	}{
+		{"12.50", ".", "12.5", false},
+		{"-1,234.56", ".", "-1234.56", false},
+		{"-1.234,56 €", ",", "-1234.56", false},
+		{"1 234,56", ",", "1234.56", false},
+		{"1'234.56", ".", "1234.56", false},
+		{"12,50-", ",", "-12.5", false},
+		{"(12.50)", ".", "-12.5", false},
+		{"$ 12.50", ".", "12.5", false},
+		{"EUR 3", "", "3", false},
+		{"12,50", ".", "", true},
+		{"1.2.3", ",", "", true},
+		{"1,2,3", ",", "", true},
+		{"12 5", ".", "", true},
+		{"abc", ".", "", true},
+		{"", ".", "", true},
+		{"1e5", ".", "", true},
+		{"12abc345", ".", "", true},
+		{"12 EUR 5", ".", "", true},
+		{"12-34", ".", "", true},
+		{"12+3", ".", "", true},
+		{"1(2)", ".", "", true},
+		{"12,50-,5", ",", "", true},
+		{"+12.50", ".", "12.5", false},
+		{"-€12.50", ".", "-12.5", false},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.value, tt.sep)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q, %q) = %s, want an error", tt.value, tt.sep, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmount(%q, %q): %v", tt.value, tt.sep, err)
+			continue
+		}
+		if !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("ParseAmount(%q, %q) = %s, want %s", tt.value, tt.sep, got, tt.want)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value, order, want string
+		wantErr            bool
+	}{
+		{"12/31/2020", OrderMDY, "2020-12-31", false},
+		{"31.12.2020", OrderDMY, "2020-12-31", false},
+		{"2020-12-31", OrderDMY, "2020-12-31", false},
+		{"2020/12/31 10:00", OrderMDY, "2020-12-31", false},
+		{"20-12-31", OrderYMD, "2020-12-31", false},
+		{"1/2/99", OrderMDY, "1999-01-02", false},
+		{"1/2/05", OrderDMY, "2005-02-01", false},
+		{"31/12/2020", OrderMDY, "", true},
+		{"29.02.2021", OrderDMY, "", true},
+		{"yesterday", OrderMDY, "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseDate(tt.value, tt.order)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseDate(%q, %s) = %s, want an error", tt.value, tt.order, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseDate(%q, %s) = %q, %v, want %q", tt.value, tt.order, got, err, tt.want)
+		}
+	}
+}
+
+func TestDetectDateOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    string
+		wantErr bool
+	}{
+		{"day first", []string{"01/02/2020", "13/02/2020"}, OrderDMY, false},
+		{"month first", []string{"01/02/2020", "02/13/2020"}, OrderMDY, false},
+		{"undecided", []string{"01/02/2020", "03/04/2020"}, "", false},
+		{"ISO ignored", []string{"2020-12-31", "01/02/2020"}, "", false},
+		{"mixed", []string{"13/02/2020", "02/13/2020"}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := detectDateOrder(tt.values)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s: detectDateOrder = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestDetectDecimal(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"12.50", "3"}, "."},
+		{[]string{"12,50", "1.234,00"}, ","},
+		{[]string{"1,234", "1,234.50"}, "."},
+		{[]string{"1.234"}, "."},
+		{[]string{"12,5", "1.5"}, "."},
+	}
+	for _, tt := range tests {
+		if got := detectDecimal(tt.values); got != tt.want {
+			t.Errorf("detectDecimal(%q) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	row := func(date, amount, txType string) types.ImportTransaction {
+		return types.ImportTransaction{Date: date, RawAmount: amount, TransactionType: txType}
+	}
+	type want struct {
+		date, amount, txType string
+	}
+	tests := []struct {
+		name      string
+		rows      []types.ImportTransaction
+		opts      types.ImportOptions
+		want      []want
+		info      types.ImportParseInfo
+		errFields []string
+	}{
+		{
+			name: "signed amounts",
+			rows: []types.ImportTransaction{row("13/01/2020", "-12.50", ""), row("14/01/2020", "3", "")},
+			want: []want{{"2020-01-13", "12.5", "debit"}, {"2020-01-14", "3", "credit"}},
+			info: types.ImportParseInfo{DateOrder: OrderDMY, DecimalSeparator: "."},
+		},
+		{
+			name: "type column overrides the sign",
+			rows: []types.ImportTransaction{row("2020-01-13", "-12.00", "debit"), row("2020-01-14", "-3", "credit")},
+			want: []want{{"2020-01-13", "12", "debit"}, {"2020-01-14", "3", "credit"}},
+			info: types.ImportParseInfo{DateOrder: OrderYMD, DecimalSeparator: "."},
+		},
+		{
+			name: "locale settles order and decimal",
+			rows: []types.ImportTransaction{row("01.02.2020", "-1.234,56", "")},
+			opts: types.ImportOptions{Locale: "de_DE"},
+			want: []want{{"2020-02-01", "1234.56", "debit"}},
+			info: types.ImportParseInfo{DateOrder: OrderDMY, DateAmbiguous: true, DecimalSeparator: ","},
+		},
+		{
+			name: "ambiguous falls back to month first",
+			rows: []types.ImportTransaction{row("01/02/2020", "5", "")},
+			want: []want{{"2020-01-02", "5", "credit"}},
+			info: types.ImportParseInfo{DateOrder: OrderMDY, DateAmbiguous: true, DecimalSeparator: "."},
+		},
+		{
+			name: "date order option",
+			rows: []types.ImportTransaction{row("01/02/2020", "5", "")},
+			opts: types.ImportOptions{DateOrder: OrderDMY},
+			want: []want{{"2020-02-01", "5", "credit"}},
+			info: types.ImportParseInfo{DateOrder: OrderDMY, DecimalSeparator: "."},
+		},
+		{
+			name: "date format option",
+			rows: []types.ImportTransaction{row("2020|02|01", "5", "")},
+			opts: types.ImportOptions{DateFormat: "YYYY|MM|DD"},
+			want: []want{{"2020-02-01", "5", "credit"}},
+			info: types.ImportParseInfo{DateOrder: "2006|01|02", DecimalSeparator: "."},
+		},
+		{
+			name:      "every bad row",
+			rows:      []types.ImportTransaction{row("13/01/2020", "x", ""), row("14/01/2020", "1", ""), row("32/01/2020", "2", "")},
+			errFields: []string{"amount", "date"},
+		},
+		{
+			name:      "mixed orders",
+			rows:      []types.ImportTransaction{row("13/01/2020", "1", ""), row("01/13/2020", "1", "")},
+			errFields: []string{"date"},
+		},
+		{
+			name:      "locale disagrees",
+			rows:      []types.ImportTransaction{row("01/13/2020", "1", "")},
+			opts:      types.ImportOptions{Locale: "de"},
+			errFields: []string{"date"},
+		},
+		{
+			name:      "unknown locale",
+			rows:      []types.ImportTransaction{row("2020-01-01", "1", "")},
+			opts:      types.ImportOptions{Locale: "xx"},
+			errFields: []string{"locale"},
+		},
+		{
+			name:      "unknown date order",
+			rows:      []types.ImportTransaction{row("2020-01-01", "1", "")},
+			opts:      types.ImportOptions{DateOrder: "dym"},
+			errFields: []string{"dateOrder"},
+		},
+	}
+	for _, tt := range tests {
+		got, info, errs := Normalize(tt.rows, tt.opts)
+		if tt.errFields != nil {
+			fields := []string{}
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			if len(fields) != len(tt.errFields) {
+				t.Errorf("%s: errors on %q, want %q", tt.name, fields, tt.errFields)
+				continue
+			}
+			for i := range fields {
+				if fields[i] != tt.errFields[i] {
+					t.Errorf("%s: errors on %q, want %q", tt.name, fields, tt.errFields)
+				}
+			}
+			if got != nil {
+				t.Errorf("%s: got transactions along with errors", tt.name)
+			}
+			continue
+		}
+		if errs != nil {
+			t.Errorf("%s: %v", tt.name, errs)
+			continue
+		}
+		if info != tt.info {
+			t.Errorf("%s: info = %+v, want %+v", tt.name, info, tt.info)
+		}
+		for i, w := range tt.want {
+			g := got[i]
+			if g.Date != w.date || !g.Amount.Equal(decimal.RequireFromString(w.amount)) || g.TransactionType != w.txType || g.RawAmount != "" {
+				t.Errorf("%s: row %d = %s %s %s, want %s %s %s", tt.name, i+1, g.Date, g.Amount, g.TransactionType, w.date, w.amount, w.txType)
+			}
+		}
+	}
+}
+
+// Numbers in JSON always have a decimal point, whatever the import locale
+func TestNormalizeJSONNumbers(t *testing.T) {
+	tests := []struct {
+		amount, locale, want, txType string
+	}{
+		{`1234.567`, "de-DE", "1234.567", "credit"},
+		{`12.5`, "de-DE", "12.5", "credit"},
+		{`-12.5`, "de-DE", "12.5", "debit"},
+		{`"-12,50"`, "de-DE", "12.5", "debit"},
+		{`"1.234,567"`, "de-DE", "1234.567", "credit"},
+		{`1234.567`, "en-US", "1234.567", "credit"},
+	}
+	for _, tt := range tests {
+		rows := []types.ImportTransaction{}
+		data := `[{"date": "2020-01-13", "description": "Rent", "amount": ` + tt.amount + `}]`
+		if err := json.Unmarshal([]byte(data), &rows); err != nil {
+			t.Errorf("%s: %v", tt.amount, err)
+			continue
+		}
+		got, _, errs := Normalize(rows, types.ImportOptions{Locale: tt.locale})
+		if errs != nil {
+			t.Errorf("%s in %s: %v", tt.amount, tt.locale, errs)
+			continue
+		}
+		if !got[0].Amount.Equal(decimal.RequireFromString(tt.want)) || got[0].TransactionType != tt.txType {
+			t.Errorf("%s in %s = %s %s, want %s %s", tt.amount, tt.locale, got[0].Amount, got[0].TransactionType, tt.want, tt.txType)
+		}
+	}
+
+	rows := []types.ImportTransaction{}
+	if err := json.Unmarshal([]byte(`[{"amount": true}]`), &rows); err == nil {
+		t.Error("amount true was accepted")
+	}
+}
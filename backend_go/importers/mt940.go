@@ -0,0 +1,200 @@
+package importers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	mt940TagRe     = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	mt940BalanceRe = regexp.MustCompile(`^[CD](\d{6})([A-Z]{3})([\d,]+)`)
+	mt940LineRe    = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)[A-Z]?(\d+,\d*)[A-Z][A-Z0-9]{3}([^/\n]*)(?://([^\n]*))?(?:\n(.*))?`)
+	mt940SubRe     = regexp.MustCompile(`\?(\d{2})`)
+)
+
+// mt940Field is a single :tag: field with its continuation lines
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ParseMT940 reads a SWIFT MT940 customer statement, which may contain several
+// statements for different accounts or currencies. Booking date is the entry
+// date of each :61: line, falling back to its value date.
+func ParseMT940(r io.Reader) ([]types.ImportTransaction, error) {
+	fields, err := mt940Fields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := []types.ImportTransaction{}
+	var acctID, currency string
+	var last *types.ImportTransaction
+	found := false
+
+	for _, f := range fields {
+		switch f.tag {
+		case "20":
+			acctID, currency, last = "", "", nil
+		case "25":
+			acctID = strings.TrimSpace(f.value)
+			found = true
+		case "60F", "60M":
+			m := mt940BalanceRe.FindStringSubmatch(f.value)
+			if m == nil {
+				return nil, fmt.Errorf("MT940 opening balance %q is invalid", f.value)
+			}
+			currency = m[2]
+		case "61":
+			itx, err := mt940Line(f.value)
+			if err != nil {
+				return nil, err
+			}
+			itx.AccountName = acctID
+			itx.CurrencyCode = currency
+			txs = append(txs, itx)
+			last = &txs[len(txs)-1]
+		case "86":
+			if last != nil {
+				mt940Info(last, f.value)
+				last = nil
+			}
+		}
+	}
+
+	if !found {
+		return nil, errors.New("no :25: account field found in MT940 file")
+	}
+
+	return txs, nil
+}
+
+func mt940Fields(r io.Reader) ([]mt940Field, error) {
+	fields := []mt940Field{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if m := mt940TagRe.FindStringSubmatch(line); m != nil {
+			fields = append(fields, mt940Field{tag: m[1], value: m[2]})
+			continue
+		}
+		// Skip SWIFT block headers and message terminators
+		if strings.HasPrefix(line, "{") || strings.HasPrefix(line, "-}") || line == "-" || len(fields) == 0 {
+			continue
+		}
+		fields[len(fields)-1].value += "\n" + line
+	}
+	return fields, scanner.Err()
+}
+
+func mt940Date(yymmdd string) (int, string) {
+	yy, _ := strconv.Atoi(yymmdd[0:2])
+	year := 2000 + yy
+	if yy >= 70 {
+		year = 1900 + yy
+	}
+	return year, fmt.Sprintf("%04d-%s-%s", year, yymmdd[2:4], yymmdd[4:6])
+}
+
+func mt940Line(value string) (types.ImportTransaction, error) {
+	itx := types.ImportTransaction{}
+
+	m := mt940LineRe.FindStringSubmatch(value)
+	if m == nil {
+		return itx, fmt.Errorf("MT940 statement line %q is invalid", value)
+	}
+
+	year, valueDate := mt940Date(m[1])
+	itx.ValueDate = valueDate
+	itx.Date = valueDate
+	if m[2] != "" {
+		// Entry date has no year, so it may fall in the year before or after the value date
+		entryYear := year
+		if m[1][2:4] == "01" && m[2][0:2] == "12" {
+			entryYear--
+		} else if m[1][2:4] == "12" && m[2][0:2] == "01" {
+			entryYear++
+		}
+		itx.Date = fmt.Sprintf("%04d-%s-%s", entryYear, m[2][0:2], m[2][2:4])
+	}
+
+	// RC/RD are reversals, so a reversed credit is a debit and vice versa
+	switch m[3] {
+	case "D", "RC":
+		itx.TransactionType = "debit"
+	default:
+		itx.TransactionType = "credit"
+	}
+
+	amount, err := decimal.NewFromString(strings.Replace(m[4], ",", ".", 1))
+	if err != nil {
+		return itx, fmt.Errorf("MT940 statement line %q has invalid amount", value)
+	}
+	itx.Amount = amount
+
+	ownerRef := strings.TrimSpace(m[5])
+	bankRef := strings.TrimSpace(m[6])
+	if bankRef != "" {
+		itx.ExternalID = bankRef
+	} else if ownerRef != "" && ownerRef != "NONREF" {
+		itx.ExternalID = ownerRef
+	}
+	itx.Description = strings.TrimSpace(m[7])
+
+	return itx, nil
+}
+
+// mt940Info applies the :86: information to owner field, which is either free
+// text or the structured ?NN subfield layout used by German banks
+func mt940Info(itx *types.ImportTransaction, value string) {
+	value = strings.Replace(value, "\n", "", -1)
+
+	if !mt940SubRe.MatchString(value) {
+		itx.OriginalDescription = strings.TrimSpace(value)
+		if itx.Description == "" {
+			itx.Description = itx.OriginalDescription
+		}
+		return
+	}
+
+	locs := mt940SubRe.FindAllStringSubmatchIndex(value, -1)
+	purpose := []string{}
+	counterparty := []string{}
+	bookingText := ""
+	for i, loc := range locs {
+		end := len(value)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		code, _ := strconv.Atoi(value[loc[2]:loc[3]])
+		text := strings.TrimSpace(value[loc[1]:end])
+		switch {
+		case code == 0:
+			bookingText = text
+		case code >= 20 && code <= 29, code >= 60 && code <= 63:
+			purpose = append(purpose, text)
+		case code == 32 || code == 33:
+			counterparty = append(counterparty, text)
+		}
+	}
+
+	itx.Counterparty = strings.Join(counterparty, "")
+	itx.OriginalDescription = strings.Join(purpose, "")
+	if itx.OriginalDescription == "" {
+		itx.OriginalDescription = bookingText
+	}
+	if itx.Counterparty != "" {
+		itx.Description = itx.Counterparty
+	} else if itx.OriginalDescription != "" {
+		itx.Description = itx.OriginalDescription
+	}
+}
@@ -0,0 +1,67 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMT940(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []imported
+		wantErr bool
+	}{
+		{
+			name: "structured and free text information",
+			data: "{1:F01}{4:\n:20:STMT1\n:25:10020030/1234567\n:28C:1/1\n:60F:C201231EUR1000,00\n" +
+				":61:2101040104D12,50NTRFNONREF//BANKREF1\n:86:005?00Lastschrift?20Strom?21 Januar?32Stadt\n?33werke\n" +
+				":61:2012311231C100,NMSCOWNREF\nSalary\n:86:Salary December\n" +
+				":61:2101050105RC3,00NTRFNONREF\n:62F:C210105EUR1084,50\n-}",
+			want: []imported{
+				{"2021-01-04", "12.5", "debit", "10020030/1234567", "EUR", "Stadtwerke", "BANKREF1"},
+				{"2020-12-31", "100", "credit", "10020030/1234567", "EUR", "Salary", "OWNREF"},
+				{"2021-01-05", "3", "debit", "10020030/1234567", "EUR", "", ""},
+			},
+		},
+		{
+			name: "entry date in the next year",
+			data: ":20:X\n:25:ACC\n:60F:C201231USD0,\n:61:2012310101D1,NCHGNONREF\n",
+			want: []imported{{"2021-01-01", "1", "debit", "ACC", "USD", "", ""}},
+		},
+		{
+			name: "entry date in the previous year",
+			data: ":20:X\n:25:ACC\n:60F:C210101USD0,\n:61:2101021231C1,NCHGNONREF\n",
+			want: []imported{{"2020-12-31", "1", "credit", "ACC", "USD", "", ""}},
+		},
+		{
+			name:    "no account",
+			data:    ":20:X\n:60F:C201231EUR0,\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad opening balance",
+			data:    ":20:X\n:25:ACC\n:60F:nonsense\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad statement line",
+			data:    ":20:X\n:25:ACC\n:60F:C201231EUR0,\n:61:nonsense\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseMT940(strings.NewReader(tt.data))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		checkImported(t, tt.name, got, tt.want)
+	}
+}
@@ -0,0 +1,226 @@
+package importers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+// ofxNode is a single element of an OFX document, either an aggregate with
+// children or a leaf with a value (SGML leaves have no closing tag)
+type ofxNode struct {
+	name     string
+	value    string
+	parent   *ofxNode
+	children []*ofxNode
+}
+
+func (n *ofxNode) child(name string) *ofxNode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *ofxNode) path(names ...string) *ofxNode {
+	cur := n
+	for _, name := range names {
+		if cur = cur.child(name); cur == nil {
+			return nil
+		}
+	}
+	return cur
+}
+
+func (n *ofxNode) leaf(names ...string) string {
+	if c := n.path(names...); c != nil {
+		return c.value
+	}
+	return ""
+}
+
+func (n *ofxNode) findAll(name string, found []*ofxNode) []*ofxNode {
+	for _, c := range n.children {
+		if c.name == name {
+			found = append(found, c)
+		} else {
+			found = c.findAll(name, found)
+		}
+	}
+	return found
+}
+
+// parseOFXTree builds a node tree from the <OFX> body, accepting both the
+// SGML flavour of OFX 1.x (unclosed leaves) and the XML flavour of OFX 2.x
+func parseOFXTree(raw []byte) (*ofxNode, error) {
+	start := bytes.Index(bytes.ToUpper(raw), []byte("<OFX>"))
+	if start < 0 {
+		return nil, errors.New("no <OFX> element found")
+	}
+	body := string(raw[start:])
+
+	root := &ofxNode{}
+	cur := root
+	var lastLeaf *ofxNode
+
+	for len(body) > 0 {
+		lt := strings.IndexByte(body, '<')
+		if lt < 0 {
+			break
+		}
+		if text := strings.TrimSpace(body[:lt]); text != "" && cur != root {
+			// Text directly after an opening tag makes that element a leaf
+			cur.value = html.UnescapeString(text)
+			lastLeaf = cur
+			cur = cur.parent
+		}
+		gt := strings.IndexByte(body[lt:], '>')
+		if gt < 0 {
+			return nil, errors.New("unterminated OFX tag")
+		}
+		tag := strings.TrimSpace(body[lt+1 : lt+gt])
+		body = body[lt+gt+1:]
+
+		switch {
+		case tag == "" || tag[0] == '?' || tag[0] == '!':
+			continue
+		case tag[0] == '/':
+			name := strings.ToUpper(strings.TrimSpace(tag[1:]))
+			if lastLeaf != nil && lastLeaf.name == name && lastLeaf.parent == cur {
+				// XML closing tag for a leaf that was already closed implicitly
+				lastLeaf = nil
+				continue
+			}
+			for n := cur; n != root; n = n.parent {
+				if n.name == name {
+					cur = n.parent
+					break
+				}
+			}
+			lastLeaf = nil
+		default:
+			selfClosing := strings.HasSuffix(tag, "/")
+			name := strings.ToUpper(strings.TrimSpace(strings.TrimSuffix(tag, "/")))
+			node := &ofxNode{name: name, parent: cur}
+			cur.children = append(cur.children, node)
+			lastLeaf = nil
+			if !selfClosing {
+				cur = node
+			}
+		}
+	}
+
+	return root, nil
+}
+
+// ParseOFX reads an OFX/QFX bank or credit card statement and converts each
+// STMTTRN record into an ImportTransaction. The statement account ID is used
+// as the account name and CURDEF as the currency, FITID is kept as ExternalID.
+func ParseOFX(r io.Reader) ([]types.ImportTransaction, error) {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parseOFXTree(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := root.findAll("STMTRS", nil)
+	statements = root.findAll("CCSTMTRS", statements)
+	if len(statements) == 0 {
+		return nil, errors.New("no bank or credit card statement found in OFX file")
+	}
+
+	txs := []types.ImportTransaction{}
+	for _, stmt := range statements {
+		currency := strings.ToUpper(stmt.leaf("CURDEF"))
+		acctID := stmt.leaf("BANKACCTFROM", "ACCTID")
+		if acctID == "" {
+			acctID = stmt.leaf("CCACCTFROM", "ACCTID")
+		}
+		if acctID == "" {
+			return nil, errors.New("OFX statement is missing its account ID")
+		}
+
+		tranList := stmt.child("BANKTRANLIST")
+		if tranList == nil {
+			continue
+		}
+		for _, trn := range tranList.findAll("STMTTRN", nil) {
+			itx, err := ofxTransaction(trn)
+			if err != nil {
+				return nil, err
+			}
+			itx.AccountName = acctID
+			if itx.CurrencyCode == "" {
+				itx.CurrencyCode = currency
+			}
+			txs = append(txs, itx)
+		}
+	}
+
+	return txs, nil
+}
+
+func ofxTransaction(trn *ofxNode) (types.ImportTransaction, error) {
+	itx := types.ImportTransaction{}
+
+	fitID := trn.leaf("FITID")
+	dt := trn.leaf("DTPOSTED")
+	if len(dt) < 8 {
+		return itx, fmt.Errorf("OFX transaction %q has invalid DTPOSTED %q", fitID, dt)
+	}
+	itx.Date = dt[0:4] + "-" + dt[4:6] + "-" + dt[6:8]
+
+	amt := trn.leaf("TRNAMT")
+	if !strings.Contains(amt, ".") {
+		// Some banks write TRNAMT with a decimal comma
+		amt = strings.Replace(amt, ",", ".", 1)
+	}
+	amount, err := decimal.NewFromString(amt)
+	if err != nil {
+		return itx, fmt.Errorf("OFX transaction %q has invalid TRNAMT %q", fitID, trn.leaf("TRNAMT"))
+	}
+	if amount.IsNegative() {
+		itx.TransactionType = "debit"
+	} else {
+		itx.TransactionType = "credit"
+	}
+	itx.Amount = amount.Abs()
+
+	name := trn.leaf("NAME")
+	if name == "" {
+		name = trn.leaf("PAYEE", "NAME")
+	}
+	memo := trn.leaf("MEMO")
+	if name == "" {
+		name = memo
+	}
+	itx.Description = name
+	if memo != "" {
+		itx.OriginalDescription = memo
+	} else {
+		itx.OriginalDescription = name
+	}
+
+	// CURRENCY means TRNAMT is in that currency rather than CURDEF
+	if cur := trn.leaf("CURRENCY", "CURSYM"); cur != "" {
+		itx.CurrencyCode = strings.ToUpper(cur)
+	}
+
+	itx.ExternalID = fitID
+
+	return itx, nil
+}
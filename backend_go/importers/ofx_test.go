@@ -0,0 +1,179 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+
+	"fin-go/db"
+)
+
+// ofxSGML is an OFX 1.x file: an SGML header and leaves without closing tags
+const ofxSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:1252
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20210305120000</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>1<STMTRS>
+<CURDEF>usd
+<BANKACCTFROM><BANKID>121000248<ACCTID>1234567<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST><DTSTART>20210301<DTEND>20210305
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20210301120000[-5:EST]<TRNAMT>-12.50<FITID>F1<NAME>Bakery &amp; Co<MEMO>Bread</STMTTRN>
+<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20210302<TRNAMT>1000,00<FITID>F2<PAYEE><NAME>Employer</PAYEE></STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20210303<TRNAMT>-5<FITID>F3<MEMO>Card fee</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>982.50<DTASOF>20210305</LEDGERBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+// ofxXML is an OFX 2.x credit card statement, with one transaction charged
+// in another currency
+const ofxXML = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <TRNUID>1</TRNUID>
+      <CCSTMTRS>
+        <CURDEF>EUR</CURDEF>
+        <CCACCTFROM><ACCTID>4111111111111111</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <DTSTART>20210401</DTSTART>
+          <DTEND>20210430</DTEND>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20210410</DTPOSTED>
+            <TRNAMT>-20.00</TRNAMT>
+            <FITID>C1</FITID>
+            <NAME>Hotel</NAME>
+            <CURRENCY><CURRATE>0.9</CURRATE><CURSYM>chf</CURSYM></CURRENCY>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>CREDIT</TRNTYPE>
+            <DTPOSTED>20210415</DTPOSTED>
+            <TRNAMT>7.5</TRNAMT>
+            <FITID>C2</FITID>
+            <NAME>Refund</NAME>
+            <MEMO/>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>`
+
+func TestParseOFX(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []imported
+		wantErr bool
+	}{
+		{
+			name: "OFX 1.x bank statement",
+			data: ofxSGML,
+			want: []imported{
+				{"2021-03-01", "12.5", "debit", "1234567", "USD", "Bakery & Co", "F1"},
+				{"2021-03-02", "1000", "credit", "1234567", "USD", "Employer", "F2"},
+				{"2021-03-03", "5", "debit", "1234567", "USD", "Card fee", "F3"},
+			},
+		},
+		{
+			name: "OFX 2.x credit card statement",
+			data: ofxXML,
+			want: []imported{
+				{"2021-04-10", "20", "debit", "4111111111111111", "CHF", "Hotel", "C1"},
+				{"2021-04-15", "7.5", "credit", "4111111111111111", "EUR", "Refund", "C2"},
+			},
+		},
+		{
+			name:    "not OFX",
+			data:    "Date,Amount\n2021-01-01,5\n",
+			wantErr: true,
+		},
+		{
+			name:    "no statement",
+			data:    "<OFX><SIGNONMSGSRSV1></SIGNONMSGSRSV1></OFX>",
+			wantErr: true,
+		},
+		{
+			name:    "no account",
+			data:    "<OFX><STMTRS><CURDEF>USD<BANKTRANLIST></BANKTRANLIST></STMTRS></OFX>",
+			wantErr: true,
+		},
+		{
+			name:    "bad amount",
+			data:    "<OFX><STMTRS><BANKACCTFROM><ACCTID>1</BANKACCTFROM><BANKTRANLIST><STMTTRN><DTPOSTED>20210101<TRNAMT>abc<FITID>X</STMTTRN></BANKTRANLIST></STMTRS></OFX>",
+			wantErr: true,
+		},
+		{
+			name:    "bad date",
+			data:    "<OFX><STMTRS><BANKACCTFROM><ACCTID>1</BANKACCTFROM><BANKTRANLIST><STMTTRN><DTPOSTED>2021<TRNAMT>1<FITID>X</STMTTRN></BANKTRANLIST></STMTRS></OFX>",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseOFX(strings.NewReader(tt.data))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		checkImported(t, tt.name, got, tt.want)
+	}
+
+	got, _ := ParseOFX(strings.NewReader(ofxSGML))
+	if len(got) > 0 && got[0].OriginalDescription != "Bread" {
+		t.Errorf("original description = %q, want the memo", got[0].OriginalDescription)
+	}
+}
+
+// Banks repeat a FITID when a file is downloaded again, and some reuse one
+// for identical rows; importing the file again has to give the same IDs
+func TestOFXReimportIDs(t *testing.T) {
+	const data = `<OFX><STMTRS><CURDEF>USD<BANKACCTFROM><ACCTID>99</BANKACCTFROM><BANKTRANLIST>
+<STMTTRN><DTPOSTED>20210601<TRNAMT>-3.20<FITID>D1<NAME>Coffee</STMTTRN>
+<STMTTRN><DTPOSTED>20210601<TRNAMT>-3.20<FITID>D1<NAME>Coffee</STMTTRN>
+<STMTTRN><DTPOSTED>20210602<TRNAMT>-8<FITID>D2<NAME>Lunch</STMTTRN>
+</BANKTRANLIST></STMTRS></OFX>`
+
+	ids := func() []string {
+		got, err := ParseOFX(strings.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{}
+		occurrences := map[string]int{}
+		for _, itx := range got {
+			if itx.ExternalID == "" {
+				t.Errorf("%s lost its FITID", itx.Description)
+			}
+			key := db.ImportContentKey(itx.AccountName, itx.Date, itx.Amount, itx.Description)
+			ids = append(ids, db.ImportTransactionID(key, occurrences[key]))
+			occurrences[key]++
+		}
+		return ids
+	}
+
+	first, again := ids(), ids()
+	if len(first) != 3 || len(again) != 3 {
+		t.Fatalf("got %d and %d transactions, want 3", len(first), len(again))
+	}
+	for i := range first {
+		if first[i] != again[i] {
+			t.Errorf("row %d: ID %s on import, %s on import again", i+1, first[i], again[i])
+		}
+	}
+	if first[0] == first[1] {
+		t.Errorf("identical rows share ID %s", first[0])
+	}
+}
@@ -0,0 +1,238 @@
+package importers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+// qifTypes are the account sections whose records are imported, all other
+// sections (investments, category and class lists, memorized payees) are skipped
+var qifTypes = map[string]bool{
+	"bank":  true,
+	"ccard": true,
+	"cash":  true,
+	"oth a": true,
+	"oth l": true,
+}
+
+type qifSplit struct {
+	category string
+	memo     string
+	amount   string
+}
+
+type qifRecord struct {
+	date     string
+	amount   string
+	payee    string
+	memo     string
+	category string
+	splits   []qifSplit
+}
+
+// ParseQIF reads a Quicken/MS Money QIF export. Account names come from
+// !Account blocks, or defaultAccount when the file has none. Categories are
+// passed on as "Top:Sub" paths and transfers ("[Account]") as Transfer.
+func ParseQIF(r io.Reader, defaultAccount string) ([]types.ImportTransaction, error) {
+	txs := []types.ImportTransaction{}
+	account := defaultAccount
+	inAccountBlock := false
+	inTxSection := false
+	found := false
+	rec := qifRecord{}
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if line[0] == '!' {
+			header := strings.ToLower(strings.TrimSpace(line[1:]))
+			switch {
+			case header == "account":
+				inAccountBlock = true
+				inTxSection = false
+			case strings.HasPrefix(header, "type:"):
+				inAccountBlock = false
+				inTxSection = qifTypes[strings.TrimSpace(header[5:])]
+				found = found || inTxSection
+			default:
+				// !Option:AutoSwitch, !Clear:AutoSwitch and friends
+				inAccountBlock = false
+				inTxSection = false
+			}
+			rec = qifRecord{}
+			continue
+		}
+
+		code, value := line[0], strings.TrimSpace(line[1:])
+
+		if inAccountBlock {
+			if code == 'N' {
+				account = value
+			}
+			continue
+		}
+		if !inTxSection {
+			continue
+		}
+
+		switch code {
+		case 'D':
+			rec.date = value
+		case 'T', 'U':
+			rec.amount = value
+		case 'P':
+			rec.payee = value
+		case 'M':
+			rec.memo = value
+		case 'L':
+			rec.category = value
+		case 'S':
+			rec.splits = append(rec.splits, qifSplit{category: value})
+		case 'E':
+			if len(rec.splits) > 0 {
+				rec.splits[len(rec.splits)-1].memo = value
+			}
+		case '$':
+			if len(rec.splits) > 0 {
+				rec.splits[len(rec.splits)-1].amount = value
+			}
+		case '^':
+			recTxs, err := qifTransactions(rec, account)
+			if err != nil {
+				return nil, fmt.Errorf("QIF record ending on line %d: %v", lineNo, err)
+			}
+			txs = append(txs, recTxs...)
+			rec = qifRecord{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, errors.New("no !Type:Bank or !Type:CCard section found in QIF file")
+	}
+
+	return txs, nil
+}
+
+func qifTransactions(rec qifRecord, account string) ([]types.ImportTransaction, error) {
+	dt, err := qifDate(rec.date)
+	if err != nil {
+		return nil, err
+	}
+
+	base := types.ImportTransaction{}
+	base.Date = dt
+	base.AccountName = account
+	base.Description = rec.payee
+	if base.Description == "" {
+		base.Description = rec.memo
+	}
+	base.OriginalDescription = base.Description
+	base.Notes = rec.memo
+
+	if len(rec.splits) == 0 {
+		itx := base
+		if err := qifApply(&itx, rec.amount, rec.category); err != nil {
+			return nil, err
+		}
+		return []types.ImportTransaction{itx}, nil
+	}
+
+	// Split transactions are imported as one transaction per split line
+	txs := []types.ImportTransaction{}
+	for _, split := range rec.splits {
+		itx := base
+		if split.memo != "" {
+			itx.Notes = split.memo
+		}
+		if err := qifApply(&itx, split.amount, split.category); err != nil {
+			return nil, err
+		}
+		txs = append(txs, itx)
+	}
+	return txs, nil
+}
+
+// qifApply sets amount, type, category and class (as a label) on a transaction
+func qifApply(itx *types.ImportTransaction, amount, category string) error {
+	amt, err := decimal.NewFromString(strings.Replace(amount, ",", "", -1))
+	if err != nil {
+		return fmt.Errorf("invalid amount %q", amount)
+	}
+	if amt.IsNegative() {
+		itx.TransactionType = "debit"
+	} else {
+		itx.TransactionType = "credit"
+	}
+	itx.Amount = amt.Abs()
+
+	if i := strings.Index(category, "/"); i >= 0 {
+		itx.Labels = strings.TrimSpace(category[i+1:])
+		category = category[:i]
+	}
+	category = strings.TrimSpace(category)
+	if strings.HasPrefix(category, "[") && strings.HasSuffix(category, "]") {
+		category = "Transfer"
+	}
+	itx.Category = category
+
+	return nil
+}
+
+// qifDate normalizes the QIF date styles (1/2/98, 01/02'05, 1-2-2005 and the
+// day-first 02.01.2005) to ISO dates. An apostrophe marks years after 1999.
+func qifDate(value string) (string, error) {
+	raw := strings.Replace(value, " ", "", -1)
+	after2000 := strings.Contains(raw, "'")
+	dayFirst := strings.Contains(raw, ".")
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == '/' || r == '\'' || r == '-' || r == '.'
+	})
+	if len(fields) != 3 {
+		return "", fmt.Errorf("invalid date %q", value)
+	}
+
+	nums := [3]int{}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return "", fmt.Errorf("invalid date %q", value)
+		}
+		nums[i] = n
+	}
+
+	month, day, year := nums[0], nums[1], nums[2]
+	if len(fields[0]) == 4 {
+		year, month, day = nums[0], nums[1], nums[2]
+	} else if dayFirst {
+		day, month = nums[0], nums[1]
+	}
+	if len(fields[2]) <= 2 && len(fields[0]) != 4 {
+		if after2000 || year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return "", fmt.Errorf("invalid date %q", value)
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day), nil
+}
@@ -0,0 +1,106 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseQIF(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []imported
+		wantErr bool
+	}{
+		{
+			name: "default account",
+			data: "!Type:Bank\nD1/2/98\nT-1,234.50\nPGrocer\nLFood:Groceries\n^\nD01/02'05\nT20\nMRefund\nL[Savings]\n^\n",
+			want: []imported{
+				{"1998-01-02", "1234.5", "debit", "checking", "", "Grocer", ""},
+				{"2005-01-02", "20", "credit", "checking", "", "Refund", ""},
+			},
+		},
+		{
+			name: "account blocks and skipped sections",
+			data: "!Option:AutoSwitch\n!Account\nNVisa\nTCCard\n^\n!Clear:AutoSwitch\n!Type:CCard\nD02.01.2005\nU-5\nPShop\n^\n" +
+				"!Type:Invst\nD1/1/2005\nT100\n^\n!Type:Cat\nNFood\n^\n",
+			want: []imported{{"2005-01-02", "5", "debit", "Visa", "", "Shop", ""}},
+		},
+		{
+			name: "splits",
+			data: "!Type:Cash\nD2005-01-03\nT-30\nPMarket\nSFood\nEApples\n$-10\nSHouse/Garden\n$-20\n^\n",
+			want: []imported{
+				{"2005-01-03", "10", "debit", "checking", "", "Market", ""},
+				{"2005-01-03", "20", "debit", "checking", "", "Market", ""},
+			},
+		},
+		{
+			name:    "no transaction section",
+			data:    "!Type:Cat\nNFood\n^\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad date",
+			data:    "!Type:Bank\nDyesterday\nT1\n^\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad amount",
+			data:    "!Type:Bank\nD1/2/98\nTlots\n^\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseQIF(strings.NewReader(tt.data), "checking")
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		checkImported(t, tt.name, got, tt.want)
+	}
+
+	got, _ := ParseQIF(strings.NewReader(tests[2].data), "checking")
+	if len(got) == 2 {
+		if got[0].Category != "Food" || got[0].Notes != "Apples" || got[1].Category != "House" || got[1].Labels != "Garden" {
+			t.Errorf("splits = %+v", got)
+		}
+	}
+	got, _ = ParseQIF(strings.NewReader(tests[0].data), "checking")
+	if len(got) == 2 && (got[0].Category != "Food:Groceries" || got[1].Category != "Transfer") {
+		t.Errorf("categories %q and %q, want Food:Groceries and Transfer", got[0].Category, got[1].Category)
+	}
+}
+
+func TestQIFDate(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"1/2/98", "1998-01-02"},
+		{"1/2/05", "2005-01-02"},
+		{"01/02'05", "2005-01-02"},
+		{"1/ 2'5", "2005-01-02"},
+		{"1-2-2005", "2005-01-02"},
+		{"02.01.2005", "2005-01-02"},
+		{"2005-01-02", "2005-01-02"},
+		{"13/1/2005", ""},
+		{"1/2", ""},
+	}
+	for _, tt := range tests {
+		got, err := qifDate(tt.value)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("qifDate(%q) = %q, want an error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("qifDate(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+}
@@ -1,36 +1,25 @@
 package main
 
 import (
+	"flag"
 	"log"
-	"net/http"
+	"os"
 
-	"fin-go/app"
-	"fin-go/db"
-
-	"github.com/gorilla/mux"
+	"fin-go/cli"
+	"fin-go/config"
 )
 
 func main() {
-	// Init DB
-	_, err := db.CreateDatabase()
+	cfg, args, err := config.Load(os.Args[1:])
+	if err == flag.ErrHelp {
+		cli.Usage(os.Stderr)
+		os.Exit(0)
+	}
 	if err != nil {
-		log.Fatal("main: cannot initialize DB: %s", err.Error())
+		log.Fatalln("main: cannot load config:", err)
 	}
 
-	// Init Currency DB
-	_, err2 := db.CreateCurrencyDatabase()
-	if err2 != nil {
-		log.Fatal("main: cannot initialize Currency DB: %s", err.Error())
+	if err := cli.Run(cfg, args); err != nil {
+		log.Fatalln("main:", err)
 	}
-
-	db.GetNewXML()
-
-	app := &app.App{
-		Router: mux.NewRouter().StrictSlash(true),
-	}
-
-	app.SetupRouter()
-
-	log.Println("Starting HTTP server")
-	log.Fatal(http.ListenAndServe(":6060", app.Router))
 }
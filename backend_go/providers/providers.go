@@ -0,0 +1,347 @@
+// Package providers is what the bank aggregators fin syncs from have in
+// common. Each aggregator is a package implementing Provider that registers
+// itself from init; syncing, account and transaction upserts, category
+// mapping and currency normalization only go through the interface, so a
+// new aggregator doesn't touch any of them.
+package providers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"fin-go/config"
+	"fin-go/db"
+	"fin-go/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rickb777/date"
+	"github.com/shopspring/decimal"
+)
+
+// Uncategorized is the fin category of transactions whose provider category
+// maps to nothing
+const (
+	UncategorizedID   = 106
+	UncategorizedName = "Uncategorized"
+)
+
+var (
+	ErrUnknownProvider = errors.New("no such provider")
+	ErrDisabled        = errors.New("provider is turned off")
+	// ErrNeedsReLogin is returned when the provider won't sync an item until
+	// its owner logs in again
+	ErrNeedsReLogin = errors.New("item needs logging in again")
+)
+
+// Provider is a bank aggregator items are linked through
+type Provider interface {
+	// Name is what the provider column of item_tokens and accounts holds
+	Name() string
+	// Enabled reports whether the config turns the provider on
+	Enabled(cfg *config.Config) bool
+	// Link starts connecting a bank for user, or logging into item again
+	// when it is given, and returns what the frontend opens next: a link
+	// token or a connect URL
+	Link(cfg *config.Config, user types.User, item *types.ItemToken) (string, error)
+	// Items returns the items the provider keeps a list of itself, such as
+	// connections made on its own site, so new ones are synced too
+	Items(cfg *config.Config) ([]types.ItemToken, error)
+	// RefreshAccounts returns an item's accounts as the provider has them now
+	RefreshAccounts(cfg *config.Config, item types.ItemToken) ([]types.Account, error)
+	// FetchTransactions returns a page of an item's transactions from
+	// cursor, empty the first time, the cursor of the page after it and
+	// whether more pages follow. Each page is stored with its cursor before
+	// the next is asked for, so an interrupted sync carries on from there.
+	FetchTransactions(cfg *config.Config, item types.ItemToken, cursor string) ([]Transaction, string, bool, error)
+	// MapCategory returns the fin category for one of the provider's own
+	MapCategory(category string) (int, string, error)
+	// RemoveItem disconnects an item at the provider
+	RemoveItem(cfg *config.Config, item types.ItemToken) error
+	// Health reports whether an item still syncs
+	Health(cfg *config.Config, item types.ItemToken) (Health, error)
+}
+
+// Transaction is a transaction as a provider has it, amounts already signed
+// the fin way (negative for money going out), with the provider's category
+// left to MapCategory. Removed ones, which the provider took back, only
+// need their TransactionID.
+//
+// A posted transaction takes the place of the pending one it was before:
+// ReplacesPending names it when the provider knows, GuessPending has
+// SaveTransactions look for it when the provider doesn't and the transaction
+// isn't stored yet.
+type Transaction struct {
+	types.Transaction
+	ProviderCategory string
+	Removed          bool
+	ReplacesPending  string
+	GuessPending     bool
+}
+
+// Health is the state of an item at its provider
+type Health struct {
+	NeedsReLogin        bool      `json:"needs_re_login"`
+	Interactive         bool      `json:"interactive"`
+	LastRefresh         time.Time `json:"last_refresh"`
+	NextRefreshPossible time.Time `json:"next_refresh_possible"`
+	Status              string    `json:"status"`
+}
+
+var registry = struct {
+	sync.RWMutex
+	byName map[string]Provider
+}{byName: map[string]Provider{}}
+
+// Register makes a provider available under its name, from the init of the
+// provider's package
+func Register(p Provider) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.byName[p.Name()]; dup {
+		panic("providers: " + p.Name() + " registered twice")
+	}
+	registry.byName[p.Name()] = p
+}
+
+// Get returns the provider with a name
+func Get(name string) (Provider, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	p, ok := registry.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+// Enabled returns the providers the config turns on, by name
+func Enabled(cfg *config.Config) []Provider {
+	registry.RLock()
+	defer registry.RUnlock()
+	enabled := []Provider{}
+	for _, p := range registry.byName {
+		if p.Enabled(cfg) {
+			enabled = append(enabled, p)
+		}
+	}
+	sort.Slice(enabled, func(i, j int) bool { return enabled[i].Name() < enabled[j].Name() })
+	return enabled
+}
+
+// ForItem returns the provider of an item, if it is turned on
+func ForItem(cfg *config.Config, item types.ItemToken) (Provider, error) {
+	p, err := Get(item.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Enabled(cfg) {
+		return nil, fmt.Errorf("%w: %s", ErrDisabled, p.Name())
+	}
+	return p, nil
+}
+
+// ApplyHealth copies what Health found onto the item it was asked about
+func ApplyHealth(item types.ItemToken, h Health) types.ItemToken {
+	item.NeedsReLogin = h.NeedsReLogin
+	item.Interactive = h.Interactive
+	if !h.LastRefresh.IsZero() {
+		item.LastRefresh = h.LastRefresh
+	}
+	if !h.NextRefreshPossible.IsZero() {
+		item.NextRefreshPossible = h.NextRefreshPossible
+	}
+	return item
+}
+
+// SaveAccounts upserts the accounts of an item, filling in what comes from
+// the item rather than the provider
+func SaveAccounts(p Provider, item types.ItemToken, accounts []types.Account, stmt *sqlx.NamedStmt) {
+	for _, acc := range accounts {
+		acc.Provider = p.Name()
+		acc.Institution = item.Institution
+		acc.ItemID = item.ItemID
+		acc.UserID = item.UserID
+		stmt.MustExec(acc)
+	}
+}
+
+// SaveTransactions upserts the transactions a provider fetched, naming their
+// account, mapping their category and normalizing their amount to the base
+// currency, and deletes the ones it removed and the pending ones posted ones
+// replace. Transactions of accounts fin doesn't have are logged and left out.
+func SaveTransactions(cfg *config.Config, p Provider, txs []Transaction, txn *sqlx.Tx, stmt *sqlx.NamedStmt) error {
+	names := map[string]string{}
+	for _, ptx := range txs {
+		tx := ptx.Transaction
+
+		if ptx.Removed {
+			txn.MustExec(`DELETE FROM transactions WHERE transaction_id = $1
+				AND account_id IN (SELECT account_id FROM accounts WHERE provider = $2)`, tx.TransactionID, p.Name())
+			// Suspicions about the removed transaction are moot now
+			txn.MustExec("DELETE FROM duplicate_pairs WHERE status = 'pending' AND (transaction_id_1 = $1 OR transaction_id_2 = $1)", tx.TransactionID)
+			continue
+		}
+
+		name, ok := names[tx.AccountID]
+		if !ok {
+			err := txn.Get(&name, "SELECT name FROM accounts WHERE account_id = $1 AND provider = $2 LIMIT 1", tx.AccountID, p.Name())
+			if errors.Is(err, sql.ErrNoRows) {
+				// Failing would stall the item's sync on this page for good
+				log.Printf("Skipping transaction %s of unknown %s account %s", tx.TransactionID, p.Name(), tx.AccountID)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("account %s of transaction %s: %v", tx.AccountID, tx.TransactionID, err)
+			}
+			names[tx.AccountID] = name
+		}
+		tx.AccountName = name
+
+		tx.TransactionType = "credit"
+		if tx.Amount.IsNegative() {
+			tx.TransactionType = "debit"
+		}
+		tx.NormalizedAmount = db.GetNormalizedAmount(tx.CurrencyCode, cfg.BaseCurrency, string(tx.Date), tx.Amount)
+
+		var err error
+		if tx.Category, tx.CategoryName, err = p.MapCategory(ptx.ProviderCategory); err != nil {
+			return err
+		}
+
+		if tx.Status != "pending" {
+			pendingID := ptx.ReplacesPending
+			if pendingID == "" && ptx.GuessPending {
+				// Only a transaction seen for the first time can have been
+				// pending before, not every one a full sync brings again
+				var stored int
+				if err := txn.Get(&stored, "SELECT COUNT(*) FROM transactions WHERE transaction_id = $1", tx.TransactionID); err != nil {
+					return err
+				}
+				if stored == 0 {
+					if pendingID, err = guessPending(p, tx, cfg.Pending, txn); err != nil {
+						return err
+					}
+				}
+			}
+			if pendingID != "" {
+				if err := replacePending(p, pendingID, &tx, txn); err != nil {
+					return err
+				}
+			}
+		}
+
+		stmt.MustExec(tx)
+	}
+	return nil
+}
+
+// providerAccounts restricts a query on transactions to the accounts of the
+// provider given as parameter n
+func providerAccounts(n int) string {
+	return fmt.Sprintf("account_id IN (SELECT account_id FROM accounts WHERE provider = $%d)", n)
+}
+
+// guessPending returns the pending transaction a posted one most likely
+// replaces: one of the same account and currency, from up to MatchDays
+// before it, with the amount closest to its own, then the date
+func guessPending(p Provider, tx types.Transaction, cfg config.Pending, txn *sqlx.Tx) (string, error) {
+	posted, err := date.ParseISO(string(tx.Date))
+	if err != nil {
+		return "", fmt.Errorf("transaction %s date: %v", tx.TransactionID, err)
+	}
+	candidates := []types.Transaction{}
+	err = txn.Select(&candidates, `SELECT * FROM transactions WHERE status = 'pending'
+		AND account_id = $1 AND currency_code = $2 AND transaction_id != $3
+		AND substr(CAST(date AS TEXT), 1, 10) BETWEEN $4 AND $5 AND `+providerAccounts(6),
+		tx.AccountID, tx.CurrencyCode, tx.TransactionID, posted.AddDate(0, 0, -cfg.MatchDays).String(), posted.String(), p.Name())
+	if err != nil {
+		return "", err
+	}
+
+	best := ""
+	var bestOff decimal.Decimal
+	var bestDays date.PeriodOfDays
+	tolerance := tx.Amount.Abs().Mul(decimal.NewFromFloat(cfg.AmountTolerance))
+	for _, c := range candidates {
+		// A refund doesn't post a payment and the other way round
+		if c.Amount.IsNegative() != tx.Amount.IsNegative() {
+			continue
+		}
+		off := c.Amount.Sub(tx.Amount).Abs()
+		if off.GreaterThan(tolerance) {
+			continue
+		}
+		pending, err := date.ParseISO(string(c.Date))
+		if err != nil {
+			continue
+		}
+		days := posted.Sub(pending)
+		if best == "" || off.LessThan(bestOff) || (off.Equal(bestOff) && days < bestDays) {
+			best, bestOff, bestDays = c.TransactionID, off, days
+		}
+	}
+	return best, nil
+}
+
+// replacePending deletes the pending transaction a posted one replaces,
+// giving the posted one what was added to it in fin: its labels and notes,
+// and its category when the posted one's maps to nothing
+func replacePending(p Provider, pendingID string, tx *types.Transaction, txn *sqlx.Tx) error {
+	pending := types.Transaction{}
+	err := txn.Get(&pending, `SELECT * FROM transactions WHERE transaction_id = $1 AND status = 'pending' AND `+providerAccounts(2), pendingID, p.Name())
+	if err == sql.ErrNoRows {
+		// Already replaced, removed or expired
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if tx.Labels == "" {
+		tx.Labels = pending.Labels
+	}
+	if tx.Notes == "" {
+		tx.Notes = pending.Notes
+	}
+	if tx.Category == UncategorizedID && pending.Category != UncategorizedID {
+		tx.Category, tx.CategoryName = pending.Category, pending.CategoryName
+	}
+
+	txn.MustExec("DELETE FROM transactions WHERE transaction_id = $1", pendingID)
+	// Suspicions about the pending transaction are moot now
+	txn.MustExec("DELETE FROM duplicate_pairs WHERE status = 'pending' AND (transaction_id_1 = $1 OR transaction_id_2 = $1)", pendingID)
+	return nil
+}
+
+// ExpirePending deletes the pending transactions older than ExpiryDays,
+// which will never post, and returns how many there were
+func ExpirePending(cfg config.Pending) (int64, error) {
+	if cfg.ExpiryDays == 0 {
+		return 0, nil
+	}
+	before := date.Today().AddDate(0, 0, -cfg.ExpiryDays).String()
+	stale := "(SELECT transaction_id FROM transactions WHERE status = 'pending' AND substr(CAST(date AS TEXT), 1, 10) < $1)"
+
+	txn, err := db.DBCon.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer txn.Rollback()
+	if _, err := txn.Exec("DELETE FROM duplicate_pairs WHERE status = 'pending' AND (transaction_id_1 IN "+stale+" OR transaction_id_2 IN "+stale+")", before); err != nil {
+		return 0, err
+	}
+	res, err := txn.Exec("DELETE FROM transactions WHERE transaction_id IN "+stale, before)
+	if err != nil {
+		return 0, err
+	}
+	expired, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return expired, txn.Commit()
+}
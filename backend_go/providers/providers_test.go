@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"testing"
+
+	"fin-go/config"
+	"fin-go/db"
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+// fakeProvider stores what it is handed and maps every category to
+// Uncategorized
+type fakeProvider struct{}
+
+func (fakeProvider) Name() string                    { return "Fake" }
+func (fakeProvider) Enabled(cfg *config.Config) bool { return true }
+func (fakeProvider) Link(cfg *config.Config, user types.User, item *types.ItemToken) (string, error) {
+	return "", nil
+}
+func (fakeProvider) Items(cfg *config.Config) ([]types.ItemToken, error) { return nil, nil }
+func (fakeProvider) RefreshAccounts(cfg *config.Config, item types.ItemToken) ([]types.Account, error) {
+	return nil, nil
+}
+func (fakeProvider) FetchTransactions(cfg *config.Config, item types.ItemToken, cursor string) ([]Transaction, string, bool, error) {
+	return nil, "", false, nil
+}
+func (fakeProvider) MapCategory(category string) (int, string, error) {
+	return UncategorizedID, UncategorizedName, nil
+}
+func (fakeProvider) RemoveItem(cfg *config.Config, item types.ItemToken) error { return nil }
+func (fakeProvider) Health(cfg *config.Config, item types.ItemToken) (Health, error) {
+	return Health{}, nil
+}
+
+func openTestDB(t *testing.T) {
+	if err := db.OpenStorage(config.Database{DataDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.CurrencyDBCon.Close()
+		db.DBCon.Close()
+	})
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+	db.DBCon.MustExec("INSERT INTO accounts (account_id, name, institution, item_id, type, provider, currency) VALUES('acc', 'Checking', 'Bank', 'item', 'depository', 'Fake', 'XTS')")
+}
+
+// save runs SaveTransactions in a transaction of its own, like a sync page
+func save(t *testing.T, txs ...Transaction) {
+	t.Helper()
+	cfg := config.Default()
+	txn := db.DBCon.MustBegin()
+	if err := SaveTransactions(&cfg, fakeProvider{}, txs, txn, types.PrepTransSt(txn)); err != nil {
+		txn.Rollback()
+		t.Fatal(err)
+	}
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fakeTx(id, account, day, amount, status string) Transaction {
+	return Transaction{Transaction: types.Transaction{
+		TransactionID: id,
+		AccountID:     account,
+		Date:          types.Day(day),
+		Amount:        decimal.RequireFromString(amount),
+		CurrencyCode:  "XTS",
+		Description:   "Coffee",
+		Status:        status,
+	}}
+}
+
+func storedIDs(t *testing.T) []string {
+	t.Helper()
+	ids := []string{}
+	if err := db.DBCon.Select(&ids, "SELECT transaction_id FROM transactions ORDER BY transaction_id"); err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func TestSaveTransactionsSkipsUnknownAccounts(t *testing.T) {
+	openTestDB(t)
+	save(t, fakeTx("t1", "acc", "2021-01-02", "-3.5", ""), fakeTx("t2", "gone", "2021-01-02", "-1", ""))
+
+	ids := storedIDs(t)
+	if len(ids) != 1 || ids[0] != "t1" {
+		t.Fatalf("stored %q, want only t1", ids)
+	}
+	stored := types.Transaction{}
+	if err := db.DBCon.Get(&stored, "SELECT * FROM transactions WHERE transaction_id = 't1'"); err != nil {
+		t.Fatal(err)
+	}
+	if stored.AccountName != "Checking" || stored.TransactionType != "debit" || stored.Category != UncategorizedID {
+		t.Errorf("stored %q %q %d, want Checking debit %d", stored.AccountName, stored.TransactionType, stored.Category, UncategorizedID)
+	}
+}
+
+func TestSaveTransactionsGuessesPendingOnce(t *testing.T) {
+	openTestDB(t)
+	guessing := func(tx Transaction) Transaction {
+		tx.GuessPending = true
+		return tx
+	}
+
+	save(t, fakeTx("p1", "acc", "2021-01-01", "-10", "pending"))
+	// Posting two days later for a little more, as tips do
+	save(t, guessing(fakeTx("t1", "acc", "2021-01-03", "-11", "posted")))
+	if ids := storedIDs(t); len(ids) != 1 || ids[0] != "t1" {
+		t.Fatalf("stored %q after t1 posted, want p1 replaced by t1", ids)
+	}
+
+	// Another pending payment that looks the same isn't replaced by t1 coming
+	// again in a full sync, only by its own posted transaction
+	save(t, fakeTx("p2", "acc", "2021-01-02", "-10", "pending"))
+	save(t, guessing(fakeTx("t1", "acc", "2021-01-03", "-11", "posted")))
+	if ids := storedIDs(t); len(ids) != 2 || ids[0] != "p2" || ids[1] != "t1" {
+		t.Fatalf("stored %q after t1 came again, want p2 and t1", ids)
+	}
+	save(t, guessing(fakeTx("t2", "acc", "2021-01-05", "-10", "posted")))
+	if ids := storedIDs(t); len(ids) != 2 || ids[0] != "t1" || ids[1] != "t2" {
+		t.Fatalf("stored %q after t2 posted, want t1 and t2", ids)
+	}
+}
@@ -1,11 +1,15 @@
 package accounts
 
 import (
+	"database/sql"
 	"encoding/json"
+	"fmt"
+	"log"
 	"net/http"
 
-	// "fmt"
+	"fin-go/auth"
 	"fin-go/db"
+	"fin-go/routes/analysisTrees"
 	"fin-go/types"
 
 	_ "github.com/jmoiron/sqlx"
@@ -13,17 +17,36 @@ import (
 
 func SelectAll() []types.Account {
 	dbdata := []types.Account{}
-	err := db.DBCon.Select(&dbdata, "SELECT * FROM `accounts`")
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM accounts")
 	if err != nil {
 		panic(err)
 	}
 	return dbdata
 }
 
+// SelectWhere returns the accounts matching an auth.Identity filter
+func SelectWhere(filter string) []types.Account {
+	dbdata := []types.Account{}
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM accounts WHERE "+filter)
+	if err != nil {
+		panic(err)
+	}
+	return dbdata
+}
+
+// writeForbidden refuses a change to an account the caller can only read
+func writeForbidden(res http.ResponseWriter, context string, accountID string) {
+	errString := fmt.Sprintf("Error with %s: account %s is not shared for writing \n", context, accountID)
+	log.Println(errString)
+	res.WriteHeader(http.StatusForbidden)
+	res.Write([]byte(errString))
+}
+
 func GetFunction() func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
-		dbdata := SelectAll()
+		id, _ := auth.FromContext(req.Context())
+		dbdata := SelectWhere(id.AccountFilter(auth.View(req), false))
 
 		res.WriteHeader(http.StatusOK)
 		if err := json.NewEncoder(res).Encode(dbdata); err != nil {
@@ -42,6 +65,14 @@ func UpsertIgnoreFunction() func(http.ResponseWriter, *http.Request) {
 			panic(err)
 		}
 
+		id, _ := auth.FromContext(req.Context())
+		if ok, err := id.CanWriteAccounts(p.AccountID); err != nil {
+			panic(err)
+		} else if !ok {
+			writeForbidden(res, "Account Ignore", p.AccountID)
+			return
+		}
+
 		txn := db.DBCon.MustBegin()
 		astmt := types.PrepAccountUpsertIgnoreSt(txn)
 
@@ -65,6 +96,14 @@ func UpsertNameFunction() func(http.ResponseWriter, *http.Request) {
 			panic(err)
 		}
 
+		id, _ := auth.FromContext(req.Context())
+		if ok, err := id.CanWriteAccounts(p.AccountID); err != nil {
+			panic(err)
+		} else if !ok {
+			writeForbidden(res, "Account Name", p.AccountID)
+			return
+		}
+
 		txn := db.DBCon.MustBegin()
 		astmt := types.PrepAccountUpsertNameSt(txn)
 
@@ -85,3 +124,69 @@ func UpsertNameFunction() func(http.ResponseWriter, *http.Request) {
 		res.WriteHeader(http.StatusOK)
 	}
 }
+
+// SharingFunction changes who else in the household sees an account. Only
+// its owner can, and household accounts (user 0) can be handed to a user.
+func SharingFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		p := types.AccountSharingPost{}
+		err := json.NewDecoder(req.Body).Decode(&p)
+		if err != nil {
+			errString := fmt.Sprintf("Error with Account Sharing Decode: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+		if !auth.ValidSharing(p.Sharing) {
+			errString := fmt.Sprintf("Error with Account Sharing: %q is not one of private, shared_read or shared_write \n", p.Sharing)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		acc := types.Account{}
+		err = db.DBCon.Get(&acc, "SELECT * FROM accounts WHERE account_id = $1 AND provider = $2", p.AccountID, p.Provider)
+		if err == sql.ErrNoRows {
+			errString := fmt.Sprintf("Error with Account Sharing: no account %s \n", p.AccountID)
+			log.Println(errString)
+			res.WriteHeader(http.StatusNotFound)
+			res.Write([]byte(errString))
+			return
+		} else if err != nil {
+			panic(err)
+		}
+
+		id, _ := auth.FromContext(req.Context())
+		if id.User.ID != 0 && acc.UserID != 0 && acc.UserID != id.User.ID {
+			errString := fmt.Sprintf("Error with Account Sharing: account %s belongs to someone else \n", p.AccountID)
+			log.Println(errString)
+			res.WriteHeader(http.StatusForbidden)
+			res.Write([]byte(errString))
+			return
+		}
+		if p.UserID == nil {
+			p.UserID = &acc.UserID
+		} else if *p.UserID != 0 {
+			var users int
+			if err := db.DBCon.Get(&users, "SELECT COUNT(*) FROM users WHERE id = $1", *p.UserID); err != nil {
+				panic(err)
+			}
+			if users == 0 {
+				errString := fmt.Sprintf("Error with Account Sharing: no user %d \n", *p.UserID)
+				log.Println(errString)
+				res.WriteHeader(http.StatusBadRequest)
+				res.Write([]byte(errString))
+				return
+			}
+		}
+
+		db.DBCon.MustExec("UPDATE accounts SET sharing = $1, user_id = $2 WHERE id = $3", p.Sharing, *p.UserID, acc.ID)
+
+		analysisTrees.ReAnalyze()
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
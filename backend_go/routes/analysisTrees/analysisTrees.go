@@ -4,27 +4,45 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"sync"
 	"time"
 
+	"fin-go/auth"
 	"fin-go/db"
 	"fin-go/types"
 
-	_ "github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx"
 	"github.com/rickb777/date"
 
 	"github.com/shopspring/decimal"
 )
 
+// selectTrees returns the trees kept for a scope
+func selectTrees(scope string) []types.Tree {
+	dbdata := []types.Tree{}
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM analysis_trees WHERE scope = $1", scope)
+	if err != nil {
+		panic(err)
+	}
+	return dbdata
+}
+
+// GetFunction returns the trees for what the caller sees in the view asked
+// for, analyzing them first if the caller has none yet
 func GetFunction() func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
-		dbdata := []types.Tree{}
-		err := db.DBCon.Select(&dbdata, "SELECT * FROM `analysis_trees`")
-		if err != nil {
-			panic(err)
+		id, _ := auth.FromContext(req.Context())
+		view := auth.View(req)
+		scope := auth.AnalysisScope{Name: id.TreeScope(view), Filter: id.TransactionFilter(view, false)}
+
+		dbdata := selectTrees(scope.Name)
+		if len(dbdata) == 0 && id.User.ID != 0 {
+			analyze([]auth.AnalysisScope{scope})
+			dbdata = selectTrees(scope.Name)
 		}
 
 		res.WriteHeader(http.StatusOK)
@@ -38,15 +56,27 @@ func CustomAnalyze() func(http.ResponseWriter, *http.Request) {
 		var err error
 
 		customRange := types.CustomRange{}
+		id, _ := auth.FromContext(req.Context())
+		view := auth.View(req)
 
 		err = json.NewDecoder(req.Body).Decode(&customRange)
 		if err != nil {
 			panic(err)
 		}
 
+		stDt, errSt := date.ParseISO(customRange.Start)
+		endDt, errEnd := date.ParseISO(customRange.End)
+		if errSt != nil || errEnd != nil || endDt.Before(stDt) {
+			errString := fmt.Sprintf("Error with Custom Tree: start %q and end %q have to be dates (YYYY-MM-DD), start first \n", customRange.Start, customRange.End)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
 		dbcatsBase := []types.Category{}
 
-		err = db.DBCon.Select(&dbcatsBase, "SELECT * FROM `categories`")
+		err = db.DBCon.Select(&dbcatsBase, "SELECT * FROM categories")
 		if err != nil {
 			panic(err)
 		}
@@ -56,12 +86,10 @@ func CustomAnalyze() func(http.ResponseWriter, *http.Request) {
 
 		rangedata := []types.Transaction{}
 		var st, end, dbEnd string
-		st = customRange.Start
-		end = customRange.End
-		stDt, _ := date.ParseISO(customRange.Start)
-		endDt, _ := date.ParseISO(customRange.End)
+		st = stDt.String()
+		end = endDt.String()
 		dbEnd = endDt.AddDate(0, 0, 1).String()
-		err = db.DBCon.Select(&rangedata, "SELECT * FROM `transactions` WHERE DATE between '"+st+"' and '"+dbEnd+"'")
+		err = db.DBCon.Select(&rangedata, "SELECT * FROM transactions WHERE DATE between $1 and $2 AND "+id.TransactionFilter(view, false), st, dbEnd)
 		// log.Println("number in ", name, len(rangedata))
 		if err != nil {
 			log.Println(end)
@@ -71,6 +99,7 @@ func CustomAnalyze() func(http.ResponseWriter, *http.Request) {
 		diff := int64(endDt.Sub(stDt) + 1)
 
 		tree := SetupTree(dbcatsBase, rangedata, "custom", st, end, diff)
+		tree.Scope = id.TreeScope(view)
 		tstmt.MustExec(tree)
 
 		errC := txn.Commit()
@@ -85,13 +114,22 @@ func CustomAnalyze() func(http.ResponseWriter, *http.Request) {
 	}
 }
 
+// ReAnalyze rebuilds the trees of every scope, see auth.AnalysisScopes
 func ReAnalyze() {
+	scopes, err := auth.AnalysisScopes()
+	if err != nil {
+		panic(err)
+	}
+	analyze(scopes)
+}
+
+func analyze(scopes []auth.AnalysisScope) {
 	// log.Println("today: ", date.Today().String(), date.Today().FormatISO(4), date.Today().String())
 
 	start := time.Now()
 	dbcatsBase := []types.Category{}
 
-	err := db.DBCon.Select(&dbcatsBase, "SELECT * FROM `categories`")
+	err := db.DBCon.Select(&dbcatsBase, "SELECT * FROM categories")
 	if err != nil {
 		panic(err)
 	}
@@ -101,71 +139,11 @@ func ReAnalyze() {
 	tstmt := types.PrepTreeSt(txn)
 
 	var wg sync.WaitGroup
-	for _, name := range types.TreeRanges {
-		wg.Add(1)
-		go func(name string) {
-			defer wg.Done()
-			rangedata := []types.Transaction{}
-			today := date.Today()
-			var stDt, endDt date.Date
-			var st, end, dbEnd string
-			var err error
-			switch name {
-			case "last30":
-				stDt = today.AddDate(0, 0, -29)
-				endDt = today
-			case "thisMonth":
-				stDt = date.New(today.Year(), today.Month(), 1)
-				endDt = today
-			case "lastMonth":
-				stPre := today.AddDate(0, -1, 0)
-				stDt = date.New(stPre.Year(), stPre.Month(), 1)
-				endDt = date.New(stPre.Year(), stPre.Month(), 1).AddDate(0, 1, -1)
-			case "last6Months":
-				stDt = today.AddDate(0, -6, 0)
-				endDt = today
-			case "thisYear":
-				stDt = date.New(today.Year(), 1, 1)
-				endDt = today
-			case "lastYear":
-				stPre := today.AddDate(-1, 0, 0)
-				stDt = date.New(stPre.Year(), 1, 1)
-				endDt = date.New(stPre.Year(), 1, 1).AddDate(1, 0, -1)
-			case "fromBeginning":
-				var dbst sql.NullString
-				err2 := db.DBCon.Get(&dbst, "SELECT MIN(date) FROM `transactions`")
-				if err2 != nil {
-					panic(err2)
-				}
-				if !dbst.Valid {
-					return
-				}
-				st = dbst.String
-				stDt, _ = date.ParseISO(st)
-				endDt = today
-			case "custom":
-				stDt = today.AddDate(0, 0, -29)
-				endDt = today
-			}
-
-			st = stDt.String()
-			end = endDt.String()
-			dbEnd = endDt.AddDate(0, 0, 1).String()
-
-			err = db.DBCon.Select(&rangedata, "SELECT * FROM `transactions` WHERE DATE between '"+st+"' and '"+dbEnd+"'")
-			// log.Println("number in ", name, len(rangedata))
-			if err != nil {
-				log.Println(end)
-				panic(err)
-			}
-
-			diff := int64(endDt.Sub(stDt) + 1)
-
-			tree := SetupTree(dbcatsBase, rangedata, name, st, end, diff)
-
-			tstmt.MustExec(tree)
-
-		}(name)
+	for _, scope := range scopes {
+		for _, name := range types.TreeRanges {
+			wg.Add(1)
+			go analyzeRange(&wg, tstmt, dbcatsBase, scope, name)
+		}
 	}
 
 	wg.Wait()
@@ -176,7 +154,92 @@ func ReAnalyze() {
 	log.Println("Regenerate analysis trees done in:", time.Since(start))
 }
 
+func analyzeRange(wg *sync.WaitGroup, tstmt *sqlx.NamedStmt, dbcatsBase []types.Category, scope auth.AnalysisScope, name string) {
+	defer wg.Done()
+	rangedata := []types.Transaction{}
+	today := date.Today()
+	var stDt, endDt date.Date
+	var st, end, dbEnd string
+	var err error
+	switch name {
+	case "last30":
+		stDt = today.AddDate(0, 0, -29)
+		endDt = today
+	case "thisMonth":
+		stDt = date.New(today.Year(), today.Month(), 1)
+		endDt = today
+	case "lastMonth":
+		stPre := today.AddDate(0, -1, 0)
+		stDt = date.New(stPre.Year(), stPre.Month(), 1)
+		endDt = date.New(stPre.Year(), stPre.Month(), 1).AddDate(0, 1, -1)
+	case "last6Months":
+		stDt = today.AddDate(0, -6, 0)
+		endDt = today
+	case "thisYear":
+		stDt = date.New(today.Year(), 1, 1)
+		endDt = today
+	case "lastYear":
+		stPre := today.AddDate(-1, 0, 0)
+		stDt = date.New(stPre.Year(), 1, 1)
+		endDt = date.New(stPre.Year(), 1, 1).AddDate(1, 0, -1)
+	case "fromBeginning":
+		var dbst sql.NullString
+		err2 := db.DBCon.Get(&dbst, "SELECT MIN(substr(CAST(date AS TEXT), 1, 10)) FROM transactions WHERE "+scope.Filter)
+		if err2 != nil {
+			panic(err2)
+		}
+		if !dbst.Valid {
+			return
+		}
+		st = dbst.String
+		stDt, _ = date.ParseISO(st)
+		endDt = today
+	case "custom":
+		stDt = today.AddDate(0, 0, -29)
+		endDt = today
+	}
+
+	st = stDt.String()
+	end = endDt.String()
+	dbEnd = endDt.AddDate(0, 0, 1).String()
+
+	err = db.DBCon.Select(&rangedata, "SELECT * FROM transactions WHERE DATE between $1 and $2 AND "+scope.Filter, st, dbEnd)
+	// log.Println("number in ", name, len(rangedata))
+	if err != nil {
+		log.Println(end)
+		panic(err)
+	}
+
+	diff := int64(endDt.Sub(stDt) + 1)
+
+	tree := SetupTree(dbcatsBase, rangedata, name, st, end, diff)
+	tree.Scope = scope.Name
+
+	tstmt.MustExec(tree)
+}
+
+// SetupTree builds the trees of a range, with and without investments, and
+// again leaving pending transactions out
 func SetupTree(dbcatsBase []types.Category, rangedata []types.Transaction, name, st, end string, diff int64) types.Tree {
+	posted := make([]types.Transaction, 0, len(rangedata))
+	for _, tx := range rangedata {
+		if tx.Status != "pending" {
+			posted = append(posted, tx)
+		}
+	}
+
+	tree := types.Tree{}
+	tree.Name = name
+	tree.FirstDate = st
+	tree.LastDate = end
+	tree.Data, tree.DataNoInvest = dataTrees(dbcatsBase, rangedata, diff)
+	tree.DataPosted, tree.DataNoInvestPosted = dataTrees(dbcatsBase, posted, diff)
+	return tree
+}
+
+// dataTrees totals transactions by category into a tree, and a second one
+// without investments
+func dataTrees(dbcatsBase []types.Category, rangedata []types.Transaction, diff int64) (string, string) {
 	dbcats := append(dbcatsBase[:0:0], dbcatsBase...)
 	for _, tx := range rangedata {
 		for i := range dbcats {
@@ -198,14 +261,9 @@ func SetupTree(dbcatsBase []types.Category, rangedata []types.Transaction, name,
 		}
 	}
 
-	tree := types.Tree{}
-	tree.Name = name
-	tree.FirstDate = st
-	tree.LastDate = end
-	var totalcount int64
-	tree.Data, totalcount = GenerateDataTree(dbcats, diff, 0, false)
-	tree.DataNoInvest, _ = GenerateDataTree(dbcatsNoInvest, diff, totalcount, true)
-	return tree
+	data, totalcount := GenerateDataTree(dbcats, diff, 0, false)
+	dataNoInvest, _ := GenerateDataTree(dbcatsNoInvest, diff, totalcount, true)
+	return data, dataNoInvest
 }
 
 func GenerateDataTree(dbcats []types.Category, diff, trueTotal int64, useTrueTotalFlag bool) (string, int64) {
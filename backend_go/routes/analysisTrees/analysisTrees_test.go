@@ -0,0 +1,41 @@
+package analysisTrees
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"fin-go/config"
+	"fin-go/db"
+)
+
+func TestCustomAnalyzeRange(t *testing.T) {
+	if err := db.OpenStorage(config.Database{DataDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	defer db.DBCon.Close()
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		start, end string
+		want       int
+	}{
+		{"2021-01-01", "2021-01-31", http.StatusOK},
+		{"2000-01-01' OR 1=1 OR '", "2021-01-31", http.StatusBadRequest},
+		{"2021-01-01", "2021-01-31' OR '1'='1", http.StatusBadRequest},
+		{"01/02/2021", "2021-01-31", http.StatusBadRequest},
+		{"", "", http.StatusBadRequest},
+		{"2021-02-01", "2021-01-01", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		body := `{"start": "` + tt.start + `", "end": "` + tt.end + `"}`
+		res := httptest.NewRecorder()
+		CustomAnalyze()(res, httptest.NewRequest("POST", "/api/customTree", strings.NewReader(body)))
+		if res.Code != tt.want {
+			t.Errorf("start %q, end %q: answered %d, want %d", tt.start, tt.end, res.Code, tt.want)
+		}
+	}
+}
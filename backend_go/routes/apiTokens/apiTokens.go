@@ -0,0 +1,112 @@
+package apiTokens
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"fin-go/auth"
+	"fin-go/types"
+
+	"github.com/gorilla/mux"
+)
+
+// writeError reports an API token error with the status matching it
+func writeError(res http.ResponseWriter, context string, err error) {
+	errString := fmt.Sprintf("Error with %s: %v \n", context, err)
+	log.Println(errString)
+	switch {
+	case errors.Is(err, auth.ErrNotFound):
+		res.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, errForbidden):
+		res.WriteHeader(http.StatusForbidden)
+	default:
+		res.WriteHeader(http.StatusBadRequest)
+	}
+	res.Write([]byte(errString))
+}
+
+var (
+	errNoUser    = errors.New("API tokens belong to a user, log in first")
+	errForbidden = errors.New("forbidden")
+)
+
+// GetFunction lists the logged in user's tokens, never the tokens themselves
+func GetFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, _ := auth.FromContext(req.Context())
+		tokens := []types.APIToken{}
+		if id.User.ID != 0 {
+			var err error
+			if tokens, err = auth.SelectTokens(id.User.ID); err != nil {
+				panic(err)
+			}
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(tokens); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// CreateFunction adds a token for the logged in user, with at most the
+// scopes they have themselves
+func CreateFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, _ := auth.FromContext(req.Context())
+		if id.User.ID == 0 {
+			writeError(res, "API Token", errNoUser)
+			return
+		}
+		post := types.APITokenPost{Scopes: []string{auth.ScopeRead}}
+		if err := json.NewDecoder(req.Body).Decode(&post); err != nil {
+			writeError(res, "API Token Decode", err)
+			return
+		}
+		for _, scope := range post.Scopes {
+			if !id.Can(scope) {
+				writeError(res, "API Token", fmt.Errorf("%w: can't hand out the %s scope", errForbidden, scope))
+				return
+			}
+		}
+
+		created, err := auth.CreateToken(id.User.ID, post.Name, post.Scopes, post.ExpiresDays)
+		if err != nil {
+			writeError(res, "API Token", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(created); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func DeleteFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, _ := auth.FromContext(req.Context())
+		if id.User.ID == 0 {
+			writeError(res, "API Token Revoke", errNoUser)
+			return
+		}
+		tokenID, err := strconv.Atoi(mux.Vars(req)["id"])
+		if err != nil {
+			writeError(res, "API Token Revoke", err)
+			return
+		}
+		if err := auth.RevokeToken(id.User.ID, tokenID); err != nil {
+			writeError(res, "API Token Revoke", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
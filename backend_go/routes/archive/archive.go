@@ -0,0 +1,414 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"fin-go/auth"
+	"fin-go/config"
+	"fin-go/db"
+	"fin-go/routes/analysisTrees"
+	"fin-go/secrets"
+	"fin-go/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	archiveFormat  = "fin-archive"
+	archiveVersion = 1
+)
+
+var (
+	ErrArchiveInvalid  = errors.New("not a fin archive")
+	ErrPassphraseUnset = errors.New("archive is encrypted, a passphrase is needed")
+)
+
+func selectAll(dest interface{}, query string) {
+	if err := db.DBCon.Select(dest, query); err != nil {
+		panic(err)
+	}
+}
+
+// Build collects all user data into an archive. Access tokens are only
+// included when asked for, and then still sealed, so they only work in an
+// install with the same token key; otherwise items have to be logged into
+// again after an import. Analysis trees aren't kept, they are rebuilt on
+// import.
+func Build(includeTokens bool, baseCurrency string) types.Archive {
+	a := types.Archive{
+		Format:         archiveFormat,
+		Version:        archiveVersion,
+		CreatedAt:      time.Now().UTC().Truncate(time.Second),
+		SchemaVersion:  db.SchemaVersion,
+		IncludesTokens: includeTokens,
+		Settings:       types.ArchiveSettings{BaseCurrency: baseCurrency},
+
+		Categories:         []types.ArchiveCategory{},
+		PlaidCategories:    []types.CategoryPlaid{},
+		SaltEdgeCategories: []types.CategorySE{},
+		ImportProfiles:     []types.ImportProfile{},
+		Items:              []types.ArchiveItem{},
+		Accounts:           []types.Account{},
+		Transactions:       []types.Transaction{},
+		ImportBatches:      []types.ArchiveBatch{},
+		DuplicatePairs:     []types.DuplicatePair{},
+	}
+	selectAll(&a.Categories, "SELECT id, top_category, sub_category, exclude_from_analysis FROM categories ORDER BY id")
+	selectAll(&a.PlaidCategories, "SELECT * FROM plaid__categories ORDER BY id")
+	selectAll(&a.SaltEdgeCategories, "SELECT * FROM salt_edge__categories ORDER BY id")
+	selectAll(&a.ImportProfiles, "SELECT * FROM import_profiles ORDER BY id")
+	selectAll(&a.Accounts, "SELECT * FROM accounts ORDER BY id")
+	selectAll(&a.Transactions, "SELECT * FROM transactions ORDER BY date, id")
+	selectAll(&a.DuplicatePairs, "SELECT * FROM duplicate_pairs ORDER BY id")
+
+	items := []types.ItemToken{}
+	selectAll(&items, "SELECT * FROM item_tokens ORDER BY id")
+	for _, item := range items {
+		archived := types.ArchiveItem{ItemToken: item}
+		if includeTokens {
+			archived.AccessToken = item.AccessToken
+		}
+		a.Items = append(a.Items, archived)
+	}
+
+	batches := []types.ImportBatch{}
+	selectAll(&batches, "SELECT * FROM import_batches ORDER BY id")
+	for _, batch := range batches {
+		archived := types.ArchiveBatch{ImportBatch: batch, TransactionIDs: []string{}, AccountIDs: []string{}}
+		if err := db.DBCon.Select(&archived.TransactionIDs, "SELECT transaction_id FROM import_batch_transactions WHERE batch_id = $1 ORDER BY transaction_id", batch.ID); err != nil {
+			panic(err)
+		}
+		if err := db.DBCon.Select(&archived.AccountIDs, "SELECT account_id FROM import_batch_accounts WHERE batch_id = $1 ORDER BY account_id", batch.ID); err != nil {
+			panic(err)
+		}
+		a.ImportBatches = append(a.ImportBatches, archived)
+	}
+	return a
+}
+
+// Encode writes an archive as JSON, sealed with the passphrase if one is given
+func Encode(a types.Archive, passphrase string) ([]byte, error) {
+	raw, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return raw, nil
+	}
+	return seal(raw, passphrase)
+}
+
+// Decode reads an archive written by Encode, decrypting it first if needed
+func Decode(raw []byte, passphrase string) (types.Archive, error) {
+	a := types.Archive{}
+	if isSealed(raw) {
+		if passphrase == "" {
+			return a, ErrPassphraseUnset
+		}
+		var err error
+		if raw, err = open(raw, passphrase); err != nil {
+			return a, err
+		}
+	}
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return a, fmt.Errorf("%w: %v", ErrArchiveInvalid, err)
+	}
+	if a.Format != archiveFormat {
+		return a, fmt.Errorf("%w: format is %q", ErrArchiveInvalid, a.Format)
+	}
+	if a.Version < 1 || a.Version > archiveVersion {
+		return a, fmt.Errorf("%w: version %d is not supported by this version of fin (%d)", ErrArchiveInvalid, a.Version, archiveVersion)
+	}
+	return a, nil
+}
+
+// inserted reports whether an INSERT ... ON CONFLICT DO NOTHING added a row
+func inserted(res interface{ RowsAffected() (int64, error) }) bool {
+	n, err := res.RowsAffected()
+	if err != nil {
+		panic(err)
+	}
+	return n > 0
+}
+
+// importCategories matches the archive's categories to this instance's by
+// name, creating the missing ones, and returns the archive ID to local ID map
+func importCategories(txn *sqlx.Tx, a types.Archive, result *types.ArchiveImportResult) map[int]int {
+	local := []types.ArchiveCategory{}
+	if err := txn.Select(&local, "SELECT id, top_category, sub_category, exclude_from_analysis FROM categories"); err != nil {
+		panic(err)
+	}
+	byName := map[[2]string]int{}
+	for _, cat := range local {
+		byName[[2]string{cat.TopCategory, cat.SubCategory}] = cat.ID
+	}
+
+	ids := map[int]int{}
+	for _, cat := range a.Categories {
+		key := [2]string{cat.TopCategory, cat.SubCategory}
+		if id, ok := byName[key]; ok {
+			ids[cat.ID] = id
+			continue
+		}
+		id, err := db.InsertID(txn, "INSERT INTO categories (top_category, sub_category, exclude_from_analysis) VALUES($1, $2, $3)",
+			cat.TopCategory, cat.SubCategory, cat.ExcludeFromAnalysis)
+		if err != nil {
+			panic(err)
+		}
+		ids[cat.ID] = int(id)
+		byName[key] = int(id)
+		result.Categories++
+	}
+	return ids
+}
+
+func localCategory(ids map[int]int, id int) int {
+	if local, ok := ids[id]; ok {
+		return local
+	}
+	return 106
+}
+
+// Import adds an archive to the current data in one transaction. Nothing is
+// keyed on database IDs: categories are matched by name and the category
+// mappings by their provider IDs, taking the archive's links. Rows that
+// already exist (same account, item, transaction or profile) are kept as they
+// are, so importing into a fresh instance recreates everything and importing
+// twice changes nothing. Amounts are normalized again when the archive was made
+// with another base currency.
+func Import(a types.Archive, base string) types.ArchiveImportResult {
+	result := types.ArchiveImportResult{}
+	txn := db.DBCon.MustBegin()
+	defer txn.Rollback()
+
+	catIDs := importCategories(txn, a, &result)
+
+	for _, cat := range a.PlaidCategories {
+		res := txn.MustExec("UPDATE plaid__categories SET link_to_app_cat = $1, app_cat_name = $2 WHERE cat_i_d = $3",
+			localCategory(catIDs, cat.LinkToAppCat), cat.AppCatName, cat.CatID)
+		if !inserted(res) {
+			txn.MustExec("INSERT INTO plaid__categories (hierarchy, cat_i_d, link_to_app_cat, app_cat_name) VALUES($1, $2, $3, $4)",
+				cat.Hierarchy, cat.CatID, localCategory(catIDs, cat.LinkToAppCat), cat.AppCatName)
+		}
+	}
+	for _, cat := range a.SaltEdgeCategories {
+		res := txn.MustExec(`UPDATE salt_edge__categories SET link_to_app_cat = $1, app_cat_name = $2
+			WHERE top_category = $3 AND sub_category = $4 AND bottom_category = $5`,
+			localCategory(catIDs, cat.LinkToAppCat), cat.AppCatName, cat.TopCategory, cat.SubCategory, cat.BottomCategory)
+		if !inserted(res) {
+			txn.MustExec(`INSERT INTO salt_edge__categories (top_category, sub_category, bottom_category, link_to_app_cat, app_cat_name)
+				VALUES($1, $2, $3, $4, $5)`,
+				cat.TopCategory, cat.SubCategory, cat.BottomCategory, localCategory(catIDs, cat.LinkToAppCat), cat.AppCatName)
+		}
+	}
+
+	pstmt := types.PrepImportProfileSt(txn)
+	for _, profile := range a.ImportProfiles {
+		exists := 0
+		if err := txn.Get(&exists, "SELECT count(*) FROM import_profiles WHERE name = $1", profile.Name); err != nil {
+			panic(err)
+		}
+		if exists > 0 {
+			continue
+		}
+		if _, err := pstmt.Exec(profile); err != nil {
+			panic(err)
+		}
+		result.ImportProfiles++
+	}
+
+	for _, item := range a.Items {
+		// Archives from before tokens were sealed carry them in the clear
+		sealed, err := secrets.Seal(item.AccessToken)
+		if err != nil {
+			panic(err)
+		}
+		// Without a token sealed under a key this install has, an item can
+		// only be used again after logging in
+		if !secrets.Readable(sealed) {
+			sealed = ""
+		}
+		item.ItemToken.AccessToken = sealed
+		item.NeedsReLogin = item.NeedsReLogin || sealed == ""
+		res, err := txn.NamedExec(`INSERT INTO item_tokens(institution, provider, interactive, last_refresh, next_refresh_possible, item_id, needs_re_login, access_token, last_downloaded_transactions, sync_cursor, user_id)
+			VALUES(:institution, :provider, :interactive, :last_refresh, :next_refresh_possible, :item_id, :needs_re_login, :access_token, :last_downloaded_transactions, :sync_cursor, :user_id)
+			ON CONFLICT (item_id, provider) DO NOTHING`, item.ItemToken)
+		if err != nil {
+			panic(err)
+		}
+		if inserted(res) {
+			result.Items++
+		}
+	}
+
+	for _, acc := range a.Accounts {
+		// Archives from before households have no sharing
+		if acc.Sharing == "" {
+			acc.Sharing = auth.SharingPrivate
+		}
+		res, err := txn.NamedExec(`INSERT INTO accounts(name, institution, provider, account_id, item_id, type, "limit", available, balance, currency, subtype, ignore_transactions, running_total, user_id, sharing)
+			VALUES(:name, :institution, :provider, :account_id, :item_id, :type, :limit, :available, :balance, :currency, :subtype, :ignore_transactions, :running_total, :user_id, :sharing)
+			ON CONFLICT (account_id, provider) DO NOTHING`, acc)
+		if err != nil {
+			panic(err)
+		}
+		if inserted(res) {
+			result.Accounts++
+		}
+	}
+
+	for _, tx := range a.Transactions {
+		if len(tx.Date) > 10 {
+			tx.Date = tx.Date[:10]
+		}
+		tx.Category = localCategory(catIDs, tx.Category)
+		if a.Settings.BaseCurrency != base {
+			tx.NormalizedAmount = db.GetNormalizedAmount(tx.CurrencyCode, base, string(tx.Date), tx.Amount)
+		}
+		res, err := txn.NamedExec(`INSERT INTO transactions("date", transaction_id, description, original_description, amount, normalized_amount,
+			transaction_type, category, category_name, account_name, currency_code, account_id, labels, notes, status)
+			VALUES(:date, :transaction_id, :description, :original_description, :amount, :normalized_amount,
+			:transaction_type, :category, :category_name, :account_name, :currency_code, :account_id, :labels, :notes, COALESCE(NULLIF(:status, ''), 'posted'))
+			ON CONFLICT (transaction_id) DO NOTHING`, tx)
+		if err != nil {
+			panic(err)
+		}
+		if inserted(res) {
+			result.Transactions++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	for _, batch := range a.ImportBatches {
+		exists := 0
+		err := txn.Get(&exists, "SELECT count(*) FROM import_batches WHERE filename = $1 AND created_at = $2", batch.Filename, batch.CreatedAt)
+		if err != nil {
+			panic(err)
+		}
+		if exists > 0 {
+			continue
+		}
+		query, args, err := txn.BindNamed(`INSERT INTO import_batches(filename, profile, imported, duplicates, uncategorized, created_accounts, status, created_at)
+			VALUES(:filename, :profile, :imported, :duplicates, :uncategorized, :created_accounts, :status, :created_at)`, batch.ImportBatch)
+		if err != nil {
+			panic(err)
+		}
+		id, err := db.InsertID(txn, query, args...)
+		if err != nil {
+			panic(err)
+		}
+		for _, txID := range batch.TransactionIDs {
+			txn.MustExec("INSERT INTO import_batch_transactions (batch_id, transaction_id) VALUES($1, $2) ON CONFLICT DO NOTHING", id, txID)
+		}
+		for _, accID := range batch.AccountIDs {
+			txn.MustExec("INSERT INTO import_batch_accounts (batch_id, account_id) VALUES($1, $2) ON CONFLICT DO NOTHING", id, accID)
+		}
+		result.ImportBatches++
+	}
+
+	for _, pair := range a.DuplicatePairs {
+		res := txn.MustExec(`INSERT INTO duplicate_pairs(transaction_id_1, transaction_id_2, score, status)
+			VALUES($1, $2, $3, $4) ON CONFLICT (transaction_id_1, transaction_id_2) DO NOTHING`,
+			pair.TransactionID1, pair.TransactionID2, pair.Score, pair.Status)
+		if inserted(res) {
+			result.DuplicatePairs++
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// ExportFunction downloads every piece of user data as a JSON archive,
+// encrypted when a passphrase is posted. Sealed access tokens are only
+// included with include_tokens, which needs a passphrase.
+func ExportFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		opts := types.ArchiveExportPost{}
+		if req.ContentLength != 0 {
+			if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+				errString := fmt.Sprintf("Error with Archive Options: %v \n", err)
+				log.Println(errString)
+				res.WriteHeader(http.StatusBadRequest)
+				res.Write([]byte(errString))
+				return
+			}
+		}
+		if opts.IncludeTokens && opts.Passphrase == "" {
+			errString := "Error with Archive Options: access tokens are only exported in an encrypted archive, set a passphrase \n"
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		start := time.Now()
+		a := Build(opts.IncludeTokens, cfg.BaseCurrency)
+		out, err := Encode(a, opts.Passphrase)
+		if err != nil {
+			panic(err)
+		}
+
+		filename := fmt.Sprintf("fin-archive-%s.json", a.CreatedAt.Format("20060102"))
+		res.Header().Set("Content-Type", "application/json")
+		res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		res.WriteHeader(http.StatusOK)
+		res.Write(out)
+		log.Printf("Exported archive with %d transactions (encrypted %v, tokens %v) in: %v",
+			len(a.Transactions), opts.Passphrase != "", opts.IncludeTokens, time.Since(start))
+	}
+}
+
+// ImportFunction adds the archive in the multipart "file" field to the current
+// data, using the "passphrase" field for encrypted archives
+func ImportFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		file, _, err := req.FormFile("file")
+		if err != nil {
+			errString := fmt.Sprintf("Error with File Upload: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+		defer file.Close()
+		raw, err := ioutil.ReadAll(file)
+		if err != nil {
+			panic(err)
+		}
+
+		a, err := Decode(raw, req.FormValue("passphrase"))
+		if err != nil {
+			errString := fmt.Sprintf("Error with Archive: %v \n", err)
+			log.Println(errString)
+			if errors.Is(err, ErrPassphraseUnset) {
+				res.WriteHeader(http.StatusBadRequest)
+			} else {
+				res.WriteHeader(http.StatusUnprocessableEntity)
+			}
+			res.Write([]byte(errString))
+			return
+		}
+
+		start := time.Now()
+		result := Import(a, cfg.BaseCurrency)
+		analysisTrees.ReAnalyze()
+		log.Printf("Imported archive from %v: %+v in: %v", a.CreatedAt, result, time.Since(start))
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(result); err != nil {
+			panic(err)
+		}
+	}
+}
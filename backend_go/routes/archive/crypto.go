@@ -0,0 +1,113 @@
+package archive
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const encryptedFormat = "fin-archive-encrypted"
+
+var ErrPassphrase = errors.New("wrong passphrase or damaged archive")
+
+// sealed wraps an encrypted archive. The scrypt parameters are stored with it
+// so they can be raised later without breaking older archives.
+type sealed struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Salt    []byte `json:"salt"`
+	Cipher  string `json:"cipher"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+func (s sealed) aead(passphrase string) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), s.Salt, s.N, s.R, s.P, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData ties the ciphertext to its header, so a tampered header fails
+// to decrypt just like tampered data
+func (s sealed) additionalData() []byte {
+	return []byte(fmt.Sprintf("%s/%d/%s/%d/%d/%d/%s", s.Format, s.Version, s.KDF, s.N, s.R, s.P, s.Cipher))
+}
+
+// seal encrypts an archive with AES-256-GCM under a key derived from the
+// passphrase with scrypt
+func seal(plain []byte, passphrase string) ([]byte, error) {
+	s := sealed{
+		Format:  encryptedFormat,
+		Version: 1,
+		KDF:     "scrypt",
+		N:       1 << 15,
+		R:       8,
+		P:       1,
+		Salt:    make([]byte, 16),
+		Cipher:  "AES-256-GCM",
+	}
+	if _, err := rand.Read(s.Salt); err != nil {
+		return nil, err
+	}
+	gcm, err := s.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	s.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(s.Nonce); err != nil {
+		return nil, err
+	}
+	s.Data = gcm.Seal(nil, s.Nonce, plain, s.additionalData())
+	return json.Marshal(s)
+}
+
+// isSealed reports whether raw is an encrypted archive
+func isSealed(raw []byte) bool {
+	header := struct {
+		Format string `json:"format"`
+	}{}
+	return json.Unmarshal(raw, &header) == nil && header.Format == encryptedFormat
+}
+
+// open decrypts an archive written by seal
+func open(raw []byte, passphrase string) ([]byte, error) {
+	s := sealed{}
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	if s.Version != 1 || s.KDF != "scrypt" || s.Cipher != "AES-256-GCM" {
+		return nil, fmt.Errorf("unsupported encryption %s/%s version %d", s.KDF, s.Cipher, s.Version)
+	}
+	// scrypt costs N*r*128 bytes of memory, so don't let a file ask for more
+	// than a gigabyte
+	if s.N > 1<<20 || s.R > 8 || s.P > 16 {
+		return nil, fmt.Errorf("scrypt parameters N=%d r=%d p=%d are too expensive", s.N, s.R, s.P)
+	}
+	gcm, err := s.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Nonce) != gcm.NonceSize() {
+		return nil, ErrPassphrase
+	}
+	plain, err := gcm.Open(nil, s.Nonce, s.Data, s.additionalData())
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	return plain, nil
+}
@@ -0,0 +1,162 @@
+package backups
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+
+	"fin-go/db"
+	"fin-go/routes/analysisTrees"
+
+	"github.com/gorilla/mux"
+)
+
+// writeError reports a backup error with the status matching it
+func writeError(res http.ResponseWriter, context string, err error) {
+	errString := fmt.Sprintf("Error with %s: %v \n", context, err)
+	log.Println(errString)
+	switch {
+	case errors.Is(err, db.ErrBackupNotFound):
+		res.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, db.ErrBackupInvalid):
+		res.WriteHeader(http.StatusUnprocessableEntity)
+	case errors.Is(err, db.ErrBackupUnsupported):
+		res.WriteHeader(http.StatusNotImplemented)
+	default:
+		res.WriteHeader(http.StatusInternalServerError)
+	}
+	res.Write([]byte(errString))
+}
+
+func GetFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		backups, err := db.ListBackups()
+		if err != nil {
+			writeError(res, "Backups List", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(backups); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func CreateFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		backup, err := db.CreateBackup("manual")
+		if err != nil {
+			writeError(res, "Backup", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(backup); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// DownloadFunction streams a backup folder as a .tar.gz
+func DownloadFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		name := mux.Vars(req)["name"]
+		backup, err := db.SelectBackup(name)
+		if err != nil {
+			writeError(res, "Backup "+name, err)
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/gzip")
+		res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "fin-backup-"+name+".tar.gz"))
+		res.WriteHeader(http.StatusOK)
+
+		gz := gzip.NewWriter(res)
+		tw := tar.NewWriter(gz)
+		files := []string{"manifest.json"}
+		for _, f := range backup.Files {
+			files = append(files, f.Name)
+		}
+		for _, file := range files {
+			if err := addToArchive(tw, name, file); err != nil {
+				log.Printf("Error with Backup %s Download: %v \n", name, err)
+				return
+			}
+		}
+		if err := tw.Close(); err != nil {
+			log.Printf("Error with Backup %s Download: %v \n", name, err)
+			return
+		}
+		gz.Close()
+	}
+}
+
+func addToArchive(tw *tar.Writer, name, file string) error {
+	path, err := db.BackupPath(name, file)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name + "/" + file
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func DeleteFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		name := mux.Vars(req)["name"]
+		if err := db.DeleteBackup(name); err != nil {
+			writeError(res, "Backup "+name+" Delete", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
+
+// RestoreFunction replaces both databases with a backup, after checking it and
+// backing up the current data
+func RestoreFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		name := mux.Vars(req)["name"]
+		backup, err := db.RestoreBackup(name)
+		if err != nil {
+			writeError(res, "Backup "+name+" Restore", err)
+			return
+		}
+
+		analysisTrees.ReAnalyze()
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(backup); err != nil {
+			panic(err)
+		}
+	}
+}
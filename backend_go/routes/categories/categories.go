@@ -12,7 +12,7 @@ import (
 
 func SelectAll() []types.Category {
 	dbdata := []types.Category{}
-	err := db.DBCon.Select(&dbdata, "SELECT * FROM `categories`")
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM categories")
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,452 @@
+package duplicates
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"fin-go/auth"
+	"fin-go/config"
+	"fin-go/db"
+	"fin-go/routes/analysisTrees"
+	"fin-go/types"
+
+	"github.com/gorilla/mux"
+	_ "github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNotFound = errors.New("duplicate pair not found")
+	ErrResolved = errors.New("duplicate pair was already resolved")
+	ErrKeep     = errors.New("keep must be one of the pair's transaction IDs")
+)
+
+// normalizeDescription lowercases a description and keeps only its words,
+// dropping the card numbers, dates and store IDs banks add to it
+func normalizeDescription(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	kept := []string{}
+	for _, w := range words {
+		if len(w) > 1 {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+func bigrams(s string) map[string]int {
+	grams := map[string]int{}
+	for _, word := range strings.Fields(s) {
+		r := []rune(word)
+		for i := 0; i+1 < len(r); i++ {
+			grams[string(r[i:i+2])]++
+		}
+	}
+	return grams
+}
+
+// similarity is the Dice coefficient of the descriptions' letter pairs, so
+// "UNITED AIRLINES 0162" and "United Air Lines" still score high
+func similarity(a, b string) float64 {
+	a, b = normalizeDescription(a), normalizeDescription(b)
+	if a == "" || b == "" {
+		// Nothing to compare, so neither for nor against
+		return 0.5
+	}
+	if a == b {
+		return 1
+	}
+	ga, gb := bigrams(a), bigrams(b)
+	total, shared := 0, 0
+	for g, n := range ga {
+		total += n
+		if m, ok := gb[g]; ok {
+			if m < n {
+				shared += m
+			} else {
+				shared += n
+			}
+		}
+	}
+	for _, m := range gb {
+		total += m
+	}
+	if total == 0 {
+		return 0
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+func txDate(tx types.Transaction) (time.Time, error) {
+	d := string(tx.Date)
+	if len(d) > 10 {
+		d = d[:10]
+	}
+	return time.Parse("2006-01-02", d)
+}
+
+// Score rates how likely two transactions are the same payment, from 0 to 1.
+// Transactions outside the date window, outside the amount tolerance or with
+// opposite signs score 0. Amounts in different currencies are compared in
+// the base currency.
+func Score(a, b types.Transaction, cfg config.Duplicates) float64 {
+	amtA, amtB := a.Amount, b.Amount
+	if a.CurrencyCode != b.CurrencyCode {
+		amtA, amtB = a.NormalizedAmount, b.NormalizedAmount
+	}
+	if amtA.Sign() != amtB.Sign() {
+		return 0
+	}
+	fa, _ := amtA.Abs().Float64()
+	fb, _ := amtB.Abs().Float64()
+	maxDiff := cfg.AmountTolerance*math.Max(fa, fb) + 0.01
+	diff := math.Abs(fa - fb)
+	if diff > maxDiff {
+		return 0
+	}
+	amountScore := 1 - diff/maxDiff
+
+	dateA, errA := txDate(a)
+	dateB, errB := txDate(b)
+	if errA != nil || errB != nil {
+		return 0
+	}
+	days := math.Abs(dateA.Sub(dateB).Hours() / 24)
+	if days > float64(cfg.DateWindow) {
+		return 0
+	}
+	dateScore := 1 - days/float64(cfg.DateWindow+1)
+
+	return 0.4*amountScore + 0.25*dateScore + 0.35*similarity(a.Description, b.Description)
+}
+
+// ScoredTransaction is a possible duplicate with its score
+type ScoredTransaction struct {
+	Transaction types.Transaction
+	Score       float64
+}
+
+func selectRange(from, to time.Time) []types.Transaction {
+	txs := []types.Transaction{}
+	err := db.DBCon.Select(&txs, `SELECT * FROM transactions WHERE date >= $1 AND date <= $2 ORDER BY date`,
+		from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		panic(err)
+	}
+	return txs
+}
+
+// Candidates returns the stored transactions that look like the given one,
+// best match first
+func Candidates(tx types.Transaction, cfg config.Duplicates) []ScoredTransaction {
+	d, err := txDate(tx)
+	if err != nil {
+		return nil
+	}
+	window := time.Duration(cfg.DateWindow) * 24 * time.Hour
+	found := []ScoredTransaction{}
+	for _, other := range selectRange(d.Add(-window), d.Add(window)) {
+		if other.TransactionID == tx.TransactionID {
+			continue
+		}
+		if score := Score(tx, other, cfg); score >= cfg.Threshold {
+			found = append(found, ScoredTransaction{Transaction: other, Score: score})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Score > found[j].Score })
+	return found
+}
+
+// Scan compares the transactions created since the given time with all
+// transactions around their dates and stores the likely duplicates as
+// pending pairs. Pairs are only looked for within one account, or across
+// accounts of different providers (the same card linked through Plaid and
+// SaltEdge, or imported from a file); accounts of one provider are distinct.
+// Identical rows arriving together in one account are taken as separate
+// payments (except on a full scan, from the zero time), and pairs that were
+// reviewed before are not flagged again.
+func Scan(since time.Time, cfg config.Duplicates) int {
+	newTxs := []types.Transaction{}
+	err := db.DBCon.Select(&newTxs, `SELECT * FROM transactions WHERE created_at >= $1 ORDER BY date`,
+		since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		panic(err)
+	}
+	if len(newTxs) == 0 {
+		return 0
+	}
+
+	providers := map[string]string{}
+	rows, err := db.DBCon.Query(`SELECT account_id, provider FROM accounts`)
+	if err != nil {
+		panic(err)
+	}
+	for rows.Next() {
+		var accountID, provider sql.NullString
+		if err := rows.Scan(&accountID, &provider); err != nil {
+			panic(err)
+		}
+		providers[accountID.String] = provider.String
+	}
+	rows.Close()
+
+	first, errF := txDate(newTxs[0])
+	last, errL := txDate(newTxs[len(newTxs)-1])
+	if errF != nil || errL != nil {
+		return 0
+	}
+	window := time.Duration(cfg.DateWindow) * 24 * time.Hour
+	all := selectRange(first.Add(-window), last.Add(window))
+
+	isNew := map[string]bool{}
+	for _, n := range newTxs {
+		isNew[n.TransactionID] = true
+	}
+
+	txn := db.DBCon.MustBegin()
+	found := 0
+	for _, n := range newTxs {
+		for _, o := range all {
+			if o.TransactionID == n.TransactionID {
+				continue
+			}
+			if n.AccountID != o.AccountID && providers[n.AccountID] == providers[o.AccountID] {
+				continue
+			}
+			if n.AccountID == o.AccountID && isNew[o.TransactionID] && !since.IsZero() {
+				// Identical rows arriving together are separate payments
+				continue
+			}
+			score := Score(n, o, cfg)
+			if score < cfg.Threshold {
+				continue
+			}
+			id1, id2 := n.TransactionID, o.TransactionID
+			if id2 < id1 {
+				id1, id2 = id2, id1
+			}
+			res := txn.MustExec(`INSERT INTO duplicate_pairs (transaction_id_1, transaction_id_2, score) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+				id1, id2, decimal.NewFromFloat(score).Round(3))
+			if c, _ := res.RowsAffected(); c > 0 {
+				found++
+			}
+		}
+	}
+	errC := txn.Commit()
+	if errC != nil {
+		panic(errC)
+	}
+
+	if found > 0 {
+		log.Println("suspected duplicate pairs found = " + strconv.Itoa(found))
+	}
+	return found
+}
+
+// pairFilter is an SQL condition on the duplicate_pairs table for the pairs
+// with neither transaction outside of filter, a condition on transactions
+func pairFilter(filter string) string {
+	hidden := "(SELECT transaction_id FROM transactions WHERE NOT (" + filter + "))"
+	return "transaction_id_1 NOT IN " + hidden + " AND transaction_id_2 NOT IN " + hidden
+}
+
+// SelectAll returns the pairs with the given status whose transactions are
+// both within visible. Pending pairs are only listed while both transactions
+// exist; for resolved ones the deleted transaction is left empty.
+func SelectAll(status string, visible string) []types.DuplicatePairDetail {
+	pairs := []types.DuplicatePair{}
+	err := db.DBCon.Select(&pairs, "SELECT * FROM duplicate_pairs WHERE status = $1 AND "+pairFilter(visible)+" ORDER BY score DESC", status)
+	if err != nil {
+		panic(err)
+	}
+
+	details := []types.DuplicatePairDetail{}
+	for _, pair := range pairs {
+		tx1, ok1 := selectTransaction(pair.TransactionID1)
+		tx2, ok2 := selectTransaction(pair.TransactionID2)
+		if status == "pending" && (!ok1 || !ok2) {
+			continue
+		}
+		details = append(details, types.DuplicatePairDetail{DuplicatePair: pair, Transaction1: tx1, Transaction2: tx2})
+	}
+	return details
+}
+
+func selectTransaction(transactionID string) (types.Transaction, bool) {
+	tx := types.Transaction{}
+	err := db.DBCon.Get(&tx, `SELECT * FROM transactions WHERE transaction_id = $1`, transactionID)
+	if err == sql.ErrNoRows {
+		return tx, false
+	}
+	if err != nil {
+		panic(err)
+	}
+	return tx, true
+}
+
+// Resolve settles a pending pair. "dismissed" keeps both transactions and
+// never flags the pair again. "confirmed" deletes the transaction not kept,
+// and "merged" does the same after copying its category (when the kept one
+// is uncategorized) and description (when the kept one has none). keep
+// defaults to the transaction fetched from a provider rather than imported.
+func Resolve(id int, status string, keep string) error {
+	txn := db.DBCon.MustBegin()
+
+	pair := types.DuplicatePair{}
+	err := txn.Get(&pair, "SELECT * FROM duplicate_pairs WHERE id = $1", id)
+	if err == sql.ErrNoRows {
+		txn.Rollback()
+		return ErrNotFound
+	}
+	if err != nil {
+		panic(err)
+	}
+	if pair.Status != "pending" {
+		txn.Rollback()
+		return ErrResolved
+	}
+
+	if status != "dismissed" {
+		if keep == "" {
+			keep = pair.TransactionID1
+			if strings.HasPrefix(keep, "import-") && !strings.HasPrefix(pair.TransactionID2, "import-") {
+				keep = pair.TransactionID2
+			}
+		}
+		drop := pair.TransactionID2
+		if keep == pair.TransactionID2 {
+			drop = pair.TransactionID1
+		} else if keep != pair.TransactionID1 {
+			txn.Rollback()
+			return ErrKeep
+		}
+
+		if status == "merged" {
+			txn.MustExec(`UPDATE transactions SET
+				category = (SELECT d.category FROM transactions d WHERE d.transaction_id = $1),
+				category_name = (SELECT d.category_name FROM transactions d WHERE d.transaction_id = $2)
+				WHERE transaction_id = $3 AND category = 106
+				AND (SELECT d.category FROM transactions d WHERE d.transaction_id = $4) != 106`, drop, drop, keep, drop)
+			txn.MustExec(`UPDATE transactions SET
+				description = (SELECT d.description FROM transactions d WHERE d.transaction_id = $1)
+				WHERE transaction_id = $2 AND COALESCE(description, '') = ''`, drop, keep)
+		}
+		txn.MustExec(`DELETE FROM transactions WHERE transaction_id = $1`, drop)
+		// Other suspicions about the deleted transaction are moot now
+		txn.MustExec("DELETE FROM duplicate_pairs WHERE status = 'pending' AND id != $1 AND (transaction_id_1 = $2 OR transaction_id_2 = $2)", id, drop)
+	}
+	txn.MustExec("UPDATE duplicate_pairs SET status = $1 WHERE id = $2", status, id)
+
+	errC := txn.Commit()
+	if errC != nil {
+		panic(errC)
+	}
+	return nil
+}
+
+func GetFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		status := req.URL.Query().Get("status")
+		if status == "" {
+			status = "pending"
+		}
+		id, _ := auth.FromContext(req.Context())
+		dbdata := SelectAll(status, id.TransactionFilter(auth.View(req), false))
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(dbdata); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ScanFunction looks for duplicates among all stored transactions
+func ScanFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		found := Scan(time.Time{}, cfg.Duplicates)
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(map[string]int{"found": found}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ResolveFunction handles confirm, dismiss and merge, taking an optional
+// {"keep": transaction_id} body for the first and last
+func ResolveFunction(status string) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, err := strconv.Atoi(mux.Vars(req)["id"])
+		if err != nil {
+			errString := fmt.Sprintf("Error with Duplicate Pair ID: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		var p struct {
+			Keep string `json:"keep"`
+		}
+		if req.ContentLength > 0 {
+			if err := json.NewDecoder(req.Body).Decode(&p); err != nil {
+				errString := fmt.Sprintf("Error with Duplicate Pair Decode: %v \n", err)
+				log.Println(errString)
+				res.WriteHeader(http.StatusBadRequest)
+				res.Write([]byte(errString))
+				return
+			}
+		}
+
+		caller, _ := auth.FromContext(req.Context())
+		var readOnly int
+		err = db.DBCon.Get(&readOnly, "SELECT COUNT(*) FROM duplicate_pairs WHERE id = $1 AND NOT ("+
+			pairFilter(caller.TransactionFilter(auth.ViewHousehold, true))+")", id)
+		if err != nil {
+			panic(err)
+		}
+		if readOnly > 0 {
+			errString := fmt.Sprintf("Error with Duplicate Pair %d: a transaction is in an account you can't change \n", id)
+			log.Println(errString)
+			res.WriteHeader(http.StatusForbidden)
+			res.Write([]byte(errString))
+			return
+		}
+
+		if err := Resolve(id, status, p.Keep); err != nil {
+			errString := fmt.Sprintf("Error with Duplicate Pair %d: %v \n", id, err)
+			log.Println(errString)
+			switch err {
+			case ErrNotFound:
+				res.WriteHeader(http.StatusNotFound)
+			case ErrKeep:
+				res.WriteHeader(http.StatusBadRequest)
+			default:
+				res.WriteHeader(http.StatusConflict)
+			}
+			res.Write([]byte(errString))
+			return
+		}
+
+		if status != "dismissed" {
+			analysisTrees.ReAnalyze()
+		}
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
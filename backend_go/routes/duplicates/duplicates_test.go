@@ -0,0 +1,68 @@
+package duplicates
+
+import (
+	"math"
+	"testing"
+
+	"fin-go/config"
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		min, max float64
+	}{
+		{"Coffee Shop", "COFFEE SHOP", 1, 1},
+		{"UNITED AIRLINES 0162 12/03", "United Air Lines", 0.9, 1},
+		{"AMAZON MKTPLACE PMTS", "Amazon Marketplace", 0.6, 0.8},
+		{"Rent", "Groceries", 0, 0.1},
+		{"1234 5678", "Coffee", 0.5, 0.5},
+		{"", "", 0.5, 0.5},
+	}
+	for _, tt := range tests {
+		if got := similarity(tt.a, tt.b); got < tt.min || got > tt.max {
+			t.Errorf("similarity(%q, %q) = %.3f, want %.2f to %.2f", tt.a, tt.b, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	cfg := config.Default().Duplicates
+	tx := func(date, amount, currency, normalized, description string) types.Transaction {
+		return types.Transaction{
+			Date:             types.Day(date),
+			Amount:           decimal.RequireFromString(amount),
+			CurrencyCode:     currency,
+			NormalizedAmount: decimal.RequireFromString(normalized),
+			Description:      description,
+		}
+	}
+	base := tx("2021-03-10", "-42.00", "USD", "-42.00", "UNITED AIRLINES 0162")
+	tests := []struct {
+		name     string
+		other    types.Transaction
+		min, max float64
+	}{
+		{"same payment", tx("2021-03-10", "-42.00", "USD", "-42.00", "United Airlines"), 1, 1},
+		{"two days later", tx("2021-03-12", "-42.00", "USD", "-42.00", "United Air Lines"), 0.8, 0.95},
+		{"within the tolerance", tx("2021-03-10", "-42.20", "USD", "-42.20", "United Airlines"), 0.7, 0.95},
+		{"other description", tx("2021-03-10", "-42.00", "USD", "-42.00", "Grocery Store"), 0.65, 0.7},
+		{"base currency", tx("2021-03-10", "-38.00", "EUR", "-42.00", "United Airlines"), 1, 1},
+		{"outside the window", tx("2021-03-14", "-42.00", "USD", "-42.00", "United Airlines"), 0, 0},
+		{"other amount", tx("2021-03-10", "-45.00", "USD", "-45.00", "United Airlines"), 0, 0},
+		{"opposite sign", tx("2021-03-10", "42.00", "USD", "42.00", "United Airlines"), 0, 0},
+		{"bad date", tx("yesterday", "-42.00", "USD", "-42.00", "United Airlines"), 0, 0},
+	}
+	for _, tt := range tests {
+		got := Score(base, tt.other, cfg)
+		if got < tt.min-1e-9 || got > tt.max+1e-9 {
+			t.Errorf("%s: score %.3f, want %.2f to %.2f", tt.name, got, tt.min, tt.max)
+		}
+		if back := Score(tt.other, base, cfg); math.Abs(back-got) > 1e-9 {
+			t.Errorf("%s: score %.3f one way and %.3f the other", tt.name, got, back)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin-go/auth"
+	"fin-go/config"
+	"fin-go/db"
+	"fin-go/exporters"
+	"fin-go/routes/accounts"
+	"fin-go/routes/categories"
+	"fin-go/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+// Filter selects the transactions to export, empty fields match everything
+type Filter struct {
+	Start      string
+	End        string
+	Accounts   []string
+	Categories []int
+	Providers  []string
+	// Visible limits the export to the transactions an auth.Identity can
+	// see, as its TransactionFilter, and VisibleAccounts the accounts named in
+	// it, as its AccountFilter for the same view; empty is everything
+	Visible         string
+	VisibleAccounts string
+}
+
+// listParam collects a query parameter given either repeated or comma separated
+func listParam(q url.Values, name string) []string {
+	list := []string{}
+	for _, v := range q[name] {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// ParseFilter reads start, end (YYYY-MM-DD, inclusive), accounts (account
+// IDs), categories (category IDs) and provider from the query string
+func ParseFilter(q url.Values) (Filter, error) {
+	f := Filter{
+		Start:     strings.TrimSpace(q.Get("start")),
+		End:       strings.TrimSpace(q.Get("end")),
+		Accounts:  listParam(q, "accounts"),
+		Providers: listParam(q, "provider"),
+	}
+	for _, d := range []string{f.Start, f.End} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return f, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", d)
+		}
+	}
+	for _, c := range listParam(q, "categories") {
+		id, err := strconv.Atoi(c)
+		if err != nil {
+			return f, fmt.Errorf("invalid category ID %q", c)
+		}
+		f.Categories = append(f.Categories, id)
+	}
+	return f, nil
+}
+
+// where builds the WHERE clause for a filter, with sqlx.In style bindvars
+func (f Filter) where() (string, []interface{}) {
+	clauses := []string{}
+	args := []interface{}{}
+	if f.Start != "" {
+		clauses = append(clauses, "substr(CAST(date AS TEXT), 1, 10) >= ?")
+		args = append(args, f.Start)
+	}
+	if f.End != "" {
+		clauses = append(clauses, "substr(CAST(date AS TEXT), 1, 10) <= ?")
+		args = append(args, f.End)
+	}
+	if len(f.Accounts) > 0 {
+		clauses = append(clauses, "account_id IN (?)")
+		args = append(args, f.Accounts)
+	}
+	if len(f.Categories) > 0 {
+		clauses = append(clauses, "category IN (?)")
+		args = append(args, f.Categories)
+	}
+	if len(f.Providers) > 0 {
+		clauses = append(clauses, "account_id IN (SELECT account_id FROM accounts WHERE provider IN (?))")
+		args = append(args, f.Providers)
+	}
+	if f.Visible != "" {
+		clauses = append(clauses, f.Visible)
+	}
+	if len(clauses) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+func query(q string, args []interface{}) (string, []interface{}) {
+	q, args, err := sqlx.In(q, args...)
+	if err != nil {
+		panic(err)
+	}
+	return db.DBCon.Rebind(q), args
+}
+
+// dateRange returns the first and last date among the filtered transactions,
+// using the filter's own bounds where it has them
+func dateRange(f Filter) (string, string) {
+	where, args := f.where()
+	q, args := query("SELECT COALESCE(MIN(substr(CAST(date AS TEXT), 1, 10)), ''), COALESCE(MAX(substr(CAST(date AS TEXT), 1, 10)), '') FROM transactions"+where, args)
+	var start, end string
+	if err := db.DBCon.QueryRowx(q, args...).Scan(&start, &end); err != nil {
+		panic(err)
+	}
+	if f.Start != "" {
+		start = f.Start
+	}
+	if f.End != "" {
+		end = f.End
+	}
+	return start, end
+}
+
+// Export streams the transactions matching the filter to w in the given format
+// and returns how many were written
+func Export(w io.Writer, format exporters.Format, f Filter, baseCurrency string) (int, error) {
+	opts := exporters.Options{
+		BaseCurrency: baseCurrency,
+		Accounts:     map[string]types.Account{},
+		Categories:   map[int]types.Category{},
+		Price: func(currency, date string) decimal.Decimal {
+			return db.GetNormalizedAmount(currency, baseCurrency, date, decimal.NewFromInt(1))
+		},
+	}
+	visible := f.VisibleAccounts
+	if visible == "" {
+		visible = "1 = 1"
+	}
+	for _, acc := range accounts.SelectWhere(visible) {
+		opts.Accounts[acc.AccountID] = acc
+	}
+	for _, cat := range categories.SelectAll() {
+		opts.Categories[cat.ID] = cat
+	}
+	opts.Start, opts.End = dateRange(f)
+
+	order := " ORDER BY date DESC, id DESC"
+	if format.Grouped {
+		order = " ORDER BY account_id, currency_code, date, id"
+	} else if format.Chronological {
+		order = " ORDER BY date, id"
+	}
+	where, args := f.where()
+	q, args := query("SELECT * FROM transactions"+where+order, args)
+	rows, err := db.DBCon.Queryx(q, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	out, err := exporters.NewWriter(format, w, opts)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for rows.Next() {
+		tx := types.Transaction{}
+		if err := rows.StructScan(&tx); err != nil {
+			panic(err)
+		}
+		if err := out.Write(tx); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+	return count, out.Close()
+}
+
+func GetFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		start := time.Now()
+		format, err := exporters.LookupFormat(req.URL.Query().Get("format"))
+		if err != nil {
+			errString := fmt.Sprintf("Error with Export Format: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		f, err := ParseFilter(req.URL.Query())
+		if err != nil {
+			errString := fmt.Sprintf("Error with Export Filter: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		id, _ := auth.FromContext(req.Context())
+		view := auth.View(req)
+		f.Visible = id.TransactionFilter(view, false)
+		f.VisibleAccounts = id.AccountFilter(view, false)
+
+		filename := fmt.Sprintf("fin-transactions-%s%s", time.Now().Format("20060102"), format.Extension)
+		res.Header().Set("Content-Type", format.ContentType)
+		res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		res.WriteHeader(http.StatusOK)
+
+		// Headers are already sent once rows are streaming, so a failed write
+		// (usually the client going away) can only be logged
+		count, err := Export(res, format, f, cfg.BaseCurrency)
+		if err != nil {
+			log.Printf("Error with Export after %d transactions: %v \n", count, err)
+			return
+		}
+		log.Printf("Exported %d transactions as %s in: %v", count, format.Name, time.Since(start))
+	}
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"fin-go/auth"
+	"fin-go/config"
+	"fin-go/db"
+	"fin-go/exporters"
+	"fin-go/types"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestExportOnlyVisibleAccounts(t *testing.T) {
+	if err := db.OpenStorage(config.Database{DataDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	defer db.DBCon.Close()
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+	for _, acc := range []struct {
+		id, name, sharing string
+		user              int
+	}{
+		{"mine", "My Checking", auth.SharingPrivate, 1},
+		{"household", "Joint Savings", auth.SharingPrivate, 0},
+		{"shared", "Partner Card", auth.SharingSharedRead, 2},
+		{"secret", "Partner Secret", auth.SharingPrivate, 2},
+	} {
+		db.DBCon.MustExec("INSERT INTO accounts (account_id, name, institution, item_id, type, provider, currency, user_id, sharing) VALUES($1, $2, 'Bank', '', 'depository', 'Import', 'USD', $3, $4)",
+			acc.id, acc.name, acc.user, acc.sharing)
+	}
+	txn := db.DBCon.MustBegin()
+	st := types.PrepTransSt(txn)
+	st.MustExec(types.Transaction{Date: "2021-01-02", TransactionID: "t1", Description: "Coffee", Amount: decimal.RequireFromString("-3.5"), CurrencyCode: "USD", AccountID: "mine"})
+	st.MustExec(types.Transaction{Date: "2021-01-03", TransactionID: "t2", Description: "Zeppelin ride", Amount: decimal.RequireFromString("-9"), CurrencyCode: "USD", AccountID: "secret"})
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	id := auth.Identity{User: types.User{ID: 1}}
+	tests := []struct {
+		view       string
+		want, hide []string
+	}{
+		{auth.ViewHousehold, []string{"My-Checking", "Joint-Savings", "Partner-Card", "Coffee"}, []string{"Partner-Secret", "secret", "Zeppelin ride"}},
+		{auth.ViewMine, []string{"My-Checking", "Coffee"}, []string{"Joint-Savings", "Partner-Card", "Partner-Secret", "Zeppelin ride"}},
+	}
+	for _, format := range []string{"beancount", "ledger"} {
+		f, err := exporters.LookupFormat(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			var out bytes.Buffer
+			filter := Filter{Visible: id.TransactionFilter(tt.view, false), VisibleAccounts: id.AccountFilter(tt.view, false)}
+			if _, err := Export(&out, f, filter, "USD"); err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("%s %s export is missing %q:\n%s", format, tt.view, s, out.String())
+				}
+			}
+			for _, s := range tt.hide {
+				if strings.Contains(out.String(), s) {
+					t.Errorf("%s %s export shows %q:\n%s", format, tt.view, s, out.String())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package importBatches
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"fin-go/db"
+	"fin-go/routes/analysisTrees"
+	"fin-go/types"
+
+	"github.com/gorilla/mux"
+	_ "github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrNotFound          = errors.New("import batch not found")
+	ErrAlreadyRolledBack = errors.New("import batch was already rolled back")
+)
+
+func SelectAll() []types.ImportBatch {
+	dbdata := []types.ImportBatch{}
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM import_batches ORDER BY id DESC")
+	if err != nil {
+		panic(err)
+	}
+	return dbdata
+}
+
+// Select returns a batch with the transactions and accounts it created that
+// are still in the DB
+func Select(id int) (types.ImportBatchDetail, error) {
+	detail := types.ImportBatchDetail{}
+	err := db.DBCon.Get(&detail.ImportBatch, "SELECT * FROM import_batches WHERE id = $1", id)
+	if err == sql.ErrNoRows {
+		return detail, ErrNotFound
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	detail.Transactions = []types.Transaction{}
+	err = db.DBCon.Select(&detail.Transactions, `SELECT t.* FROM transactions t
+		JOIN import_batch_transactions b ON b.transaction_id = t.transaction_id
+		WHERE b.batch_id = $1 ORDER BY t.date DESC`, id)
+	if err != nil {
+		panic(err)
+	}
+
+	detail.Accounts = []types.Account{}
+	err = db.DBCon.Select(&detail.Accounts, `SELECT a.* FROM accounts a
+		JOIN import_batch_accounts b ON b.account_id = a.account_id
+		WHERE b.batch_id = $1 AND a.provider = 'Import'`, id)
+	if err != nil {
+		panic(err)
+	}
+
+	return detail, nil
+}
+
+// Rollback deletes the transactions a batch created, and the accounts it
+// created unless later imports have put transactions in them, all in one DB
+// transaction. The batch itself is kept and marked as rolled back.
+func Rollback(id int) error {
+	txn := db.DBCon.MustBegin()
+
+	var status string
+	err := txn.Get(&status, "SELECT status FROM import_batches WHERE id = $1", id)
+	if err == sql.ErrNoRows {
+		txn.Rollback()
+		return ErrNotFound
+	}
+	if err != nil {
+		panic(err)
+	}
+	if status == "rolled_back" {
+		txn.Rollback()
+		return ErrAlreadyRolledBack
+	}
+
+	res := txn.MustExec(`DELETE FROM transactions WHERE transaction_id IN
+		(SELECT transaction_id FROM import_batch_transactions WHERE batch_id = $1)`, id)
+	txCount, _ := res.RowsAffected()
+	res = txn.MustExec(`DELETE FROM accounts WHERE provider = 'Import' AND account_id IN
+		(SELECT account_id FROM import_batch_accounts WHERE batch_id = $1)
+		AND account_id NOT IN (SELECT DISTINCT account_id FROM transactions WHERE account_id IS NOT NULL)`, id)
+	accCount, _ := res.RowsAffected()
+	txn.MustExec("UPDATE import_batches SET status = 'rolled_back' WHERE id = $1", id)
+
+	errC := txn.Commit()
+	if errC != nil {
+		panic(errC)
+	}
+
+	log.Printf("Rolled back import batch %d: %d transactions and %d accounts deleted", id, txCount, accCount)
+	return nil
+}
+
+func GetFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		dbdata := SelectAll()
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(dbdata); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func GetOneFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, err := strconv.Atoi(mux.Vars(req)["id"])
+		if err != nil {
+			errString := fmt.Sprintf("Error with Import Batch ID: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		detail, err := Select(id)
+		if err != nil {
+			errString := fmt.Sprintf("Error with Import Batch %d: %v \n", id, err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusNotFound)
+			res.Write([]byte(errString))
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(detail); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func RollbackFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, err := strconv.Atoi(mux.Vars(req)["id"])
+		if err != nil {
+			errString := fmt.Sprintf("Error with Import Batch ID: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		if err := Rollback(id); err != nil {
+			errString := fmt.Sprintf("Error with Import Batch %d Rollback: %v \n", id, err)
+			log.Println(errString)
+			if err == ErrNotFound {
+				res.WriteHeader(http.StatusNotFound)
+			} else {
+				res.WriteHeader(http.StatusConflict)
+			}
+			res.Write([]byte(errString))
+			return
+		}
+
+		analysisTrees.ReAnalyze()
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
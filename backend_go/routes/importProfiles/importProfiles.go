@@ -0,0 +1,104 @@
+package importProfiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"fin-go/db"
+	"fin-go/types"
+
+	"github.com/gorilla/mux"
+	_ "github.com/jmoiron/sqlx"
+)
+
+func SelectAll() []types.ImportProfile {
+	dbdata := []types.ImportProfile{}
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM import_profiles ORDER BY name")
+	if err != nil {
+		panic(err)
+	}
+	return dbdata
+}
+
+// Select returns the profile with the given name, and false if there is none
+func Select(name string) (types.ImportProfile, bool) {
+	dbdata := []types.ImportProfile{}
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM import_profiles WHERE name = $1", name)
+	if err != nil {
+		panic(err)
+	}
+	if len(dbdata) == 0 {
+		return types.ImportProfile{}, false
+	}
+	return dbdata[0], true
+}
+
+func GetFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		dbdata := SelectAll()
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(dbdata); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func UpsertFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		p := types.ImportProfile{HasHeader: true, Delimiter: ",", DecimalSeparator: ".", SignConvention: "signed"}
+
+		err := json.NewDecoder(req.Body).Decode(&p)
+		if err != nil {
+			errString := fmt.Sprintf("Error with Import Profile Decode: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		switch p.SignConvention {
+		case "signed", "inverted", "type_column", "debit_credit":
+		default:
+			errString := fmt.Sprintf("Error with Import Profile: unknown sign convention %q \n", p.SignConvention)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+		if p.Name == "" {
+			errString := "Error with Import Profile: name is required \n"
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		txn := db.DBCon.MustBegin()
+		pstmt := types.PrepImportProfileSt(txn)
+
+		pstmt.MustExec(p)
+		errC := txn.Commit()
+		if errC != nil {
+			panic(errC)
+		}
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
+
+func DeleteFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		vars := mux.Vars(req)
+		name := vars["name"]
+
+		db.DBCon.MustExec("DELETE FROM import_profiles WHERE name = $1", name)
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
@@ -1,34 +1,47 @@
 package itemTokens
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
-	"os"
-	"strings"
 	"sync"
+	"time"
 
+	"fin-go/auth"
+	"fin-go/config"
 	"fin-go/db"
+	"fin-go/providers"
 	"fin-go/routes/analysisTrees"
-	"fin-go/routes/plaid"
-	"fin-go/routes/saltedge"
+	"fin-go/routes/duplicates"
 	"fin-go/types"
 
-	_ "github.com/jmoiron/sqlx"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
 )
 
 func SelectAll() []types.ItemToken {
 	dbdata := []types.ItemToken{}
-	err := db.DBCon.Select(&dbdata, "SELECT * FROM `item_tokens`")
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM item_tokens")
 	if err != nil {
 		panic(err)
 	}
 	return dbdata
 }
 
+// GetFunction lists the caller's items and those holding accounts shared
+// with them
 func GetFunction() func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
-		dbdata := SelectAll()
+		id, _ := auth.FromContext(req.Context())
+		dbdata := []types.ItemToken{}
+		err := db.DBCon.Select(&dbdata, "SELECT * FROM item_tokens WHERE "+id.ItemFilter())
+		if err != nil {
+			panic(err)
+		}
 
 		res.WriteHeader(http.StatusOK)
 		if err := json.NewEncoder(res).Encode(dbdata); err != nil {
@@ -37,97 +50,307 @@ func GetFunction() func(http.ResponseWriter, *http.Request) {
 	}
 }
 
-func FetchTransactionsFunction() func(http.ResponseWriter, *http.Request) {
-	return func(res http.ResponseWriter, req *http.Request) {
+// discover adds the items providers list themselves that aren't stored yet
+func discover(cfg *config.Config, known []types.ItemToken) []types.ItemToken {
+	stored := map[string]bool{}
+	for _, item := range known {
+		stored[item.Provider+"/"+item.ItemID] = true
+	}
+	for _, p := range providers.Enabled(cfg) {
+		items, err := p.Items(cfg)
+		if err != nil {
+			log.Println("Error listing", p.Name(), "items:", err)
+			continue
+		}
+		for _, item := range items {
+			item.Provider = p.Name()
+			if !stored[item.Provider+"/"+item.ItemID] {
+				known = append(known, item)
+			}
+		}
+	}
+	return known
+}
+
+// refresh updates an item's health and, if it still syncs, its accounts
+func refresh(cfg *config.Config, item types.ItemToken, istmt types.ItemStmt, astmt *sqlx.NamedStmt) types.ItemToken {
+	p, err := providers.ForItem(cfg, item)
+	if err != nil {
+		return item
+	}
 
-		baseCurrency := strings.ToUpper(os.Getenv("BASE_CURRENCY"))
-		SE := strings.ToUpper(os.Getenv("USE_SALTEDGE"))
-		Plaid := strings.ToUpper(os.Getenv("USE_PLAID"))
-		var useSE, usePlaid bool
+	h, err := p.Health(cfg, item)
+	if err != nil {
+		log.Println("Error with", p.Name(), "item", item.ItemID, "health:", err)
+		return item
+	}
+	item = providers.ApplyHealth(item, h)
+	istmt.MustExec(item)
+	if item.NeedsReLogin {
+		return item
+	}
+
+	accounts, err := p.RefreshAccounts(cfg, item)
+	if errors.Is(err, providers.ErrNeedsReLogin) {
+		item.NeedsReLogin = true
+		istmt.MustExec(item)
+		return item
+	}
+	if err != nil {
+		log.Println("Error with", p.Name(), "item", item.ItemID, "accounts:", err)
+		return item
+	}
+	providers.SaveAccounts(p, item, accounts, astmt)
+	return item
+}
+
+// writes keeps page commits of items synced side by side from running into
+// each other, which SQLite can't take
+var writes sync.Mutex
+
+// savePage stores a page of an item's transactions together with the cursor
+// of the page after it, in one transaction
+func savePage(cfg *config.Config, p providers.Provider, item types.ItemToken, txs []providers.Transaction) error {
+	writes.Lock()
+	defer writes.Unlock()
+
+	txn := db.DBCon.MustBegin()
+	defer txn.Rollback()
+	if err := providers.SaveTransactions(cfg, p, txs, txn, types.PrepTransSt(txn)); err != nil {
+		return err
+	}
+	types.PrepItemStOnlyTx(txn).MustExec(item)
+	return txn.Commit()
+}
 
-		if SE == "TRUE" {
-			useSE = true
-		} else {
-			useSE = false
+// fetch downloads an item's transactions from its cursor page by page,
+// committing each page with the cursor after it
+func fetch(cfg *config.Config, item types.ItemToken) {
+	p, err := providers.ForItem(cfg, item)
+	if err != nil || item.NeedsReLogin {
+		return
+	}
+
+	for pages := 1; ; pages++ {
+		txs, cursor, more, err := p.FetchTransactions(cfg, item, item.SyncCursor)
+		if err != nil {
+			log.Println("Error with", p.Name(), "item", item.ItemID, "transactions page", pages, "-", err)
+			return
 		}
 
-		if Plaid == "TRUE" {
-			usePlaid = true
-		} else {
-			usePlaid = false
+		item.SyncCursor = cursor
+		if !more {
+			// An interactive refresh only gets what the bank had when its
+			// owner last logged in
+			if item.Interactive {
+				item.LastDownloadedTransactions = item.LastRefresh
+			} else {
+				item.LastDownloadedTransactions = time.Now()
+			}
 		}
+		if err := savePage(cfg, p, item, txs); err != nil {
+			log.Println("Error with", p.Name(), "item", item.ItemID, "transactions page", pages, "-", err)
+			return
+		}
+		if !more {
+			return
+		}
+	}
+}
 
-		txnPre := db.DBCon.MustBegin()
+// Sync refreshes every connected item and downloads its new transactions,
+// then flags duplicates and rebuilds the analysis. Items go through their
+// provider, see the providers package.
+func Sync(cfg *config.Config) {
 
-		istmtPre := types.PrepItemSt(txnPre)
-		astmtPre := types.PrepAccountSt(txnPre)
+	itemTokens := discover(cfg, SelectAll())
 
-		itemTokens := SelectAll()
+	txnPre := db.DBCon.MustBegin()
 
-		var wgPre sync.WaitGroup
-		wgPre.Add(1)
-		go func() {
-			defer wgPre.Done()
-			if useSE {
-				saltedge.RefreshConnectionsFunction(istmtPre, astmtPre)
-			}
-		}()
+	istmtPre := types.PrepItemSt(txnPre)
+	astmtPre := types.PrepAccountSt(txnPre)
+
+	var wgPre sync.WaitGroup
+	wgPre.Add(1)
+	go func() {
+		defer wgPre.Done()
+		db.GetNewXML()
+	}()
+	for i := range itemTokens {
 		wgPre.Add(1)
-		go func() {
+		go func(i int) {
 			defer wgPre.Done()
-			db.GetNewXML()
-		}()
-		for _, itemTok := range itemTokens {
-			wgPre.Add(1)
-			go func(itemToken types.ItemToken) {
-				defer wgPre.Done()
-				if itemToken.Provider == "Plaid" && usePlaid {
-					plaid.RefreshConnection(itemToken, istmtPre, astmtPre)
-				}
-			}(itemTok)
-		}
-		wgPre.Wait()
-
-		err := txnPre.Commit()
-		if err != nil {
-			panic(err)
-		}
+			itemTokens[i] = refresh(cfg, itemTokens[i], istmtPre, astmtPre)
+		}(i)
+	}
+	wgPre.Wait()
 
-		// Make sure currencies are up to date
+	err := txnPre.Commit()
+	if err != nil {
+		panic(err)
+	}
 
-		// Refresh connections
+	started := time.Now().Add(-time.Second)
 
-		// Then we iterate through item tokens and process in either saltedge or plaid
+	var wg sync.WaitGroup
+	for _, itemTok := range itemTokens {
+		wg.Add(1)
+		go func(itemToken types.ItemToken) {
+			defer wg.Done()
+			fetch(cfg, itemToken)
+		}(itemTok)
 
-		txn := db.DBCon.MustBegin()
-		astmt := types.PrepAccountSt(txn)
-		tstmt := types.PrepTransSt(txn)
-		istmtOnlyTx := types.PrepItemStOnlyTx(txn)
+	}
+	wg.Wait()
 
-		var wg sync.WaitGroup
-		for _, itemTok := range itemTokens {
-			wg.Add(1)
-			go func(itemToken types.ItemToken) {
-				defer wg.Done()
+	expired, err := providers.ExpirePending(cfg.Pending)
+	if err != nil {
+		log.Println("Error expiring pending transactions:", err)
+	} else if expired > 0 {
+		log.Println("Expired", expired, "pending transactions")
+	}
+
+	// Flag payments that also came in through another provider or an import
+	duplicates.Scan(started, cfg.Duplicates)
 
-				if itemToken.Provider == "SaltEdge" && useSE {
-					saltedge.FetchTransactionsForItemToken(itemToken, istmtOnlyTx, astmt, tstmt, baseCurrency)
-				} else if usePlaid && itemToken.Provider == "Plaid" {
-					plaid.FetchTransactionsForItemToken(itemToken, istmtOnlyTx, astmt, tstmt, baseCurrency)
-				}
-			}(itemTok)
+	analysisTrees.ReAnalyze()
+}
+
+func FetchTransactionsFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
 
+		Sync(cfg)
+
+		res.WriteHeader(http.StatusOK)
+
+	}
+}
+
+var (
+	errNotYours   = errors.New("item belongs to someone else")
+	errBadRequest = errors.New("bad request")
+)
+
+// writeError reports an item error with the status matching it
+func writeError(res http.ResponseWriter, context string, err error) {
+	errString := fmt.Sprintf("Error with %s: %v \n", context, err)
+	log.Println(errString)
+	switch {
+	case errors.Is(err, errNotYours):
+		res.WriteHeader(http.StatusForbidden)
+	case errors.Is(err, sql.ErrNoRows):
+		res.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, providers.ErrUnknownProvider), errors.Is(err, providers.ErrDisabled), errors.Is(err, errBadRequest):
+		res.WriteHeader(http.StatusBadRequest)
+	default:
+		res.WriteHeader(http.StatusInternalServerError)
+	}
+	res.Write([]byte(errString))
+}
+
+// ownedItem returns one of the caller's items
+func ownedItem(req *http.Request, itemID, provider string) (types.ItemToken, error) {
+	item := types.ItemToken{}
+	id, _ := auth.FromContext(req.Context())
+	if owned, err := id.OwnsItem(itemID); err != nil || !owned {
+		return item, fmt.Errorf("%w: %s", errNotYours, itemID)
+	}
+	err := db.DBCon.Get(&item, "SELECT * FROM item_tokens WHERE item_id = $1 AND (provider = $2 OR $2 = '')", itemID, provider)
+	if err != nil {
+		return item, fmt.Errorf("item %s: %w", itemID, err)
+	}
+	return item, nil
+}
+
+// LinkFunction asks a provider for what the frontend opens to link a bank,
+// or to log into one of the caller's items again
+func LinkFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		var link types.LinkPost
+		if err := json.NewDecoder(req.Body).Decode(&link); err != nil {
+			writeError(res, "Link Post Request", fmt.Errorf("%w: %v", errBadRequest, err))
+			return
+		}
+
+		p, err := providers.ForItem(cfg, types.ItemToken{Provider: link.Provider})
+		if err != nil {
+			writeError(res, "Link", err)
+			return
+		}
+
+		var item *types.ItemToken
+		if link.ItemID != "" {
+			owned, err := ownedItem(req, link.ItemID, p.Name())
+			if err != nil {
+				writeError(res, "Link", err)
+				return
+			}
+			item = &owned
+		}
+
+		id, _ := auth.FromContext(req.Context())
+		token, err := p.Link(cfg, id.User, item)
+		if err != nil {
+			writeError(res, "Link", err)
+			return
 		}
-		wg.Wait()
 
-		err2 := txn.Commit()
-		if err2 != nil {
-			panic(err2)
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(map[string]string{"provider": p.Name(), "link": token}); err != nil {
+			panic(err)
 		}
+	}
+}
+
+// HealthFunction asks an item's provider whether the item still syncs
+func HealthFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
 
-		analysisTrees.ReAnalyze()
+		item, err := ownedItem(req, mux.Vars(req)["id"], "")
+		if err != nil {
+			writeError(res, "Item Health", err)
+			return
+		}
+		p, err := providers.ForItem(cfg, item)
+		if err != nil {
+			writeError(res, "Item Health", err)
+			return
+		}
+		h, err := p.Health(cfg, item)
+		if err != nil {
+			writeError(res, "Item Health", err)
+			return
+		}
 
 		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(h); err != nil {
+			panic(err)
+		}
+	}
+}
 
+// DeleteFunction disconnects an item at its provider and forgets it. Its
+// accounts and transactions stay, they just aren't synced anymore.
+func DeleteFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		item, err := ownedItem(req, mux.Vars(req)["id"], "")
+		if err != nil {
+			writeError(res, "Delete Item", err)
+			return
+		}
+		p, err := providers.ForItem(cfg, item)
+		if err != nil {
+			writeError(res, "Delete Item", err)
+			return
+		}
+		if err := p.RemoveItem(cfg, item); err != nil {
+			writeError(res, "Delete Item at "+p.Name(), err)
+			return
+		}
+
+		db.DBCon.MustExec("DELETE FROM item_tokens WHERE item_id = $1 AND provider = $2", item.ItemID, item.Provider)
+		res.WriteHeader(http.StatusOK)
 	}
 }
@@ -0,0 +1,42 @@
+package plaid
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateLinkToken(t *testing.T) {
+	requests := []linkTokenRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/link/token/create" {
+			t.Errorf("request to %s", req.URL.Path)
+		}
+		body := linkTokenRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		requests = append(requests, body)
+		res.Write([]byte(`{"link_token": "link-sandbox-1", "expiration": "2021-01-02T03:04:05Z", "request_id": "r"}`))
+	}))
+	defer srv.Close()
+	cfg, client := syncClient(t, srv)
+
+	for _, access := range []string{"", "access-sandbox"} {
+		token, err := createLinkToken(cfg, client, access)
+		if err != nil || token != "link-sandbox-1" {
+			t.Errorf("access %q: link token %q, %v", access, token, err)
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("%d requests, want 2", len(requests))
+	}
+	for i, access := range []string{"", "access-sandbox"} {
+		r := requests[i]
+		if r.ClientID != "client" || r.Secret != "secret" || r.User.ClientUserID == "" || r.AccessToken != access || len(r.CountryCodes) == 0 {
+			t.Errorf("request %d: %+v", i+1, r)
+		}
+	}
+}
@@ -7,52 +7,202 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"os"
-	"sync"
-	"time"
 
+	"fin-go/auth"
+	"fin-go/config"
 	"fin-go/db"
+	"fin-go/providers"
+	"fin-go/secrets"
 	"fin-go/types"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/plaid/plaid-go/plaid"
 	"github.com/shopspring/decimal"
 )
 
-func newClient() (*plaid.Client, error) {
-	env := os.Getenv("PLAID_ENVIRONMENT")
-	if env == "sandbox" {
-		clientOptions := plaid.ClientOptions{
-			os.Getenv("PLAID_CLIENT_ID"),
-			os.Getenv("PLAID_SECRET_SANDBOX"),
-			os.Getenv("PLAID_PUBLIC_KEY"),
-			plaid.Sandbox,
-			&http.Client{},
-		}
-		client, err := plaid.NewClient(clientOptions)
-		if err != nil {
-			panic(err)
-		}
-		return client, nil
-	} else if env == "development" {
-		clientOptions := plaid.ClientOptions{
-			os.Getenv("PLAID_CLIENT_ID"),
-			os.Getenv("PLAID_SECRET_DEVELOPMENT"),
-			os.Getenv("PLAID_PUBLIC_KEY"),
-			plaid.Development,
-			&http.Client{},
+func newClient(cfg config.Plaid) (*plaid.Client, error) {
+	var env plaid.Environment
+	if cfg.URL != "" {
+		env = plaid.Environment(cfg.URL)
+	} else if cfg.Environment == "sandbox" {
+		env = plaid.Sandbox
+	} else if cfg.Environment == "development" {
+		env = plaid.Development
+	} else {
+		return nil, errors.New("Plaid environment is not either 'sandbox' or 'development'")
+	}
+	clientOptions := plaid.ClientOptions{
+		ClientID:    cfg.ClientID,
+		Secret:      cfg.Secret(),
+		PublicKey:   cfg.PublicKey,
+		Environment: env,
+		HTTPClient:  &http.Client{},
+	}
+	client, err := plaid.NewClient(clientOptions)
+	if err != nil {
+		panic(err)
+	}
+	return client, nil
+}
+
+// provider is Plaid as a providers.Provider, registered under "Plaid"
+type provider struct{}
+
+func init() {
+	providers.Register(provider{})
+}
+
+func (provider) Name() string {
+	return "Plaid"
+}
+
+func (provider) Enabled(cfg *config.Config) bool {
+	return cfg.Plaid.Enabled
+}
+
+// needsReLogin turns Plaid's ITEM_LOGIN_REQUIRED into
+// providers.ErrNeedsReLogin, leaving other errors as they are
+func needsReLogin(err error) error {
+	if perr, ok := err.(plaid.Error); ok && perr.ErrorCode == "ITEM_LOGIN_REQUIRED" {
+		return fmt.Errorf("%w: %v", providers.ErrNeedsReLogin, perr)
+	}
+	return err
+}
+
+// clientFor returns a client and the opened access token of an item
+func clientFor(cfg config.Plaid, item types.ItemToken) (*plaid.Client, string, error) {
+	pClient, err := newClient(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	access, err := secrets.Open(item.AccessToken)
+	if err != nil {
+		// Such as a backup restored without its key: logging in again
+		// gets a new token
+		return nil, "", fmt.Errorf("%w: access token: %v", providers.ErrNeedsReLogin, err)
+	}
+	return pClient, access, nil
+}
+
+// Link creates a link token for Plaid Link, in update mode for an item
+func (provider) Link(cfg *config.Config, user types.User, item *types.ItemToken) (string, error) {
+	pClient, err := newClient(cfg.Plaid)
+	if err != nil {
+		return "", err
+	}
+
+	access := ""
+	if item != nil {
+		if access, err = secrets.Open(item.AccessToken); err != nil {
+			return "", err
 		}
-		client, err := plaid.NewClient(clientOptions)
-		if err != nil {
-			panic(err)
+	}
+
+	return createLinkToken(cfg.Plaid, pClient, access)
+}
+
+// Items is empty: Plaid items only come from Link
+func (provider) Items(cfg *config.Config) ([]types.ItemToken, error) {
+	return nil, nil
+}
+
+func (provider) RefreshAccounts(cfg *config.Config, item types.ItemToken) ([]types.Account, error) {
+	pClient, access, err := clientFor(cfg.Plaid, item)
+	if err != nil {
+		return nil, err
+	}
+
+	pAccountRes, err := pClient.GetAccounts(access)
+	if err != nil {
+		return nil, needsReLogin(err)
+	}
+
+	accounts := []types.Account{}
+	for _, pAcc := range pAccountRes.Accounts {
+		acc := types.Account{}
+		acc.Name = pAcc.Name
+		acc.AccountID = pAcc.AccountID
+		if pAcc.Type == "credit" {
+			acc.Balance = decimal.NewFromFloat(pAcc.Balances.Current * -1)
+		} else {
+			acc.Balance = decimal.NewFromFloat(pAcc.Balances.Current)
 		}
-		return client, nil
-	} else {
-		return nil, errors.New("Environment variable is not either 'sandbox' or 'development'")
+		acc.Limit = decimal.NewFromFloat(pAcc.Balances.Limit)
+		acc.Available = decimal.NewFromFloat(pAcc.Balances.Available)
+		acc.Currency = pAcc.Balances.ISOCurrencyCode
+		acc.Type = pAcc.Type
+		acc.Subtype = pAcc.Subtype
+		accounts = append(accounts, acc)
+	}
+	return accounts, nil
+}
+
+// FetchTransactions applies a page of what /transactions/sync reports since
+// cursor: added and modified transactions are upserted and removed ones
+// deleted. An empty cursor starts from the whole history.
+func (provider) FetchTransactions(cfg *config.Config, item types.ItemToken, cursor string) ([]providers.Transaction, string, bool, error) {
+	pClient, access, err := clientFor(cfg.Plaid, item)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	txs, next, more, err := syncPage(cfg.Plaid, pClient, access, cursor)
+	if err != nil {
+		return nil, "", false, needsReLogin(err)
+	}
+	return txs, next, more, nil
+}
+
+// MapCategory looks a Plaid category ID up in plaid__categories
+func (provider) MapCategory(category string) (int, string, error) {
+	pCat := types.CategoryPlaid{}
+	err := db.DBCon.Get(&pCat, `SELECT * FROM plaid__categories WHERE cat_i_d = $1`, category)
+	if err == sql.ErrNoRows {
+		return providers.UncategorizedID, providers.UncategorizedName, nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	return pCat.LinkToAppCat, pCat.AppCatName, nil
+}
+
+func (provider) RemoveItem(cfg *config.Config, item types.ItemToken) error {
+	pClient, access, err := clientFor(cfg.Plaid, item)
+	if err != nil {
+		return err
+	}
+	_, err = pClient.RemoveItem(access)
+	return err
+}
+
+func (provider) Health(cfg *config.Config, item types.ItemToken) (providers.Health, error) {
+	pClient, access, err := clientFor(cfg.Plaid, item)
+	if errors.Is(err, providers.ErrNeedsReLogin) {
+		return providers.Health{NeedsReLogin: true, Status: err.Error()}, nil
 	}
+	if err != nil {
+		return providers.Health{}, err
+	}
+
+	pRes, err := pClient.GetItem(access)
+	if err = needsReLogin(err); errors.Is(err, providers.ErrNeedsReLogin) {
+		return providers.Health{NeedsReLogin: true, Status: "ITEM_LOGIN_REQUIRED"}, nil
+	}
+	if err != nil {
+		return providers.Health{}, err
+	}
+
+	h := providers.Health{
+		NeedsReLogin: pRes.Item.Error.ErrorCode == "ITEM_LOGIN_REQUIRED",
+		LastRefresh:  pRes.Status.Transactions.LastSuccessfulUpdate,
+		Status:       pRes.Item.Error.ErrorCode,
+	}
+	if h.Status == "" {
+		h.Status = "ok"
+	}
+	return h, nil
 }
 
-func CreateFromPublicTokenFunction() func(http.ResponseWriter, *http.Request) {
+func CreateFromPublicTokenFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
 		var err error
@@ -68,7 +218,7 @@ func CreateFromPublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 			res.Write([]byte(errString))
 		}
 
-		pClient, err := newClient()
+		pClient, err := newClient(cfg.Plaid)
 		if err != nil {
 			// panic(err)
 			errString := fmt.Sprintf("Error with Create Token Client Create: %v \n", err)
@@ -77,27 +227,10 @@ func CreateFromPublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 			res.Write([]byte(errString))
 		}
 
-		createLinkTokenResp, err := pClient.CreateLinkToken(LinkTokenConfigs{
-			User: &LinkTokenUser{
-				ClientUserID:             time.Now().String(),
-				LegalName:                "Fin User",
-				PhoneNumber:              "8008675309",
-				EmailAddress:             "test@email.com",
-				PhoneNumberVerifiedTime:  time.Now(),
-				EmailAddressVerifiedTime: time.Now(),
-			},
-			ClientName:   "Plaid Test",
-			Products:     []string{"auth"},
-			CountryCodes: []string{"US"},
-			Webhook:      "https://webhook-uri.com",
-			AccountFilters: &map[string]map[string][]string{
-				"depository": {
-					"account_subtypes": {"all"},
-				},
-			},
-			Language:              "en",
-			LinkCustomizationName: "default",
-		})
+		// The item and its accounts belong to whoever linked them
+		id, _ := auth.FromContext(req.Context())
+
+		linkToken, err := provider{}.Link(cfg, id.User, nil)
 		if err != nil {
 			// panic(err)
 			errString := fmt.Sprintf("Error with Create Link Token: %v \n", err)
@@ -106,7 +239,7 @@ func CreateFromPublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 			res.Write([]byte(errString))
 		}
 
-		pRes, err := pClient.ExchangePublicToken(createLinkTokenResp.LinkToken)
+		pRes, err := pClient.ExchangePublicToken(linkToken)
 		if err != nil {
 			// panic(err)
 			errString := fmt.Sprintf("Error with Exchange Public Token: %v \n", err)
@@ -133,11 +266,12 @@ func CreateFromPublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 		iTok.Institution = item.Name
 		iTok.Provider = "Plaid"
 		iTok.NeedsReLogin = false
+		iTok.UserID = id.User.ID
 		istmt := types.PrepItemSt(txn)
 		upsertItemToken(iTok, istmt)
 		astmt := types.PrepAccountSt(txn)
 
-		pAccountRes, err := pClient.GetAccounts(pRes.AccessToken)
+		accounts, err := provider{}.RefreshAccounts(cfg, iTok)
 		if err != nil {
 			// panic(err)
 			errString := fmt.Sprintf("Error with Create Token Client Get Accounts request: %v \n", err)
@@ -145,17 +279,7 @@ func CreateFromPublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 			res.WriteHeader(http.StatusInternalServerError)
 			res.Write([]byte(errString))
 		}
-
-		var wg sync.WaitGroup
-		for _, account := range pAccountRes.Accounts {
-			wg.Add(1)
-			go func(pAcc plaid.Account) {
-				defer wg.Done()
-				acc := types.Account{}
-				upsertAccountWithPlaidAccount(acc, pAcc, iTok.Institution, iTok.ItemID, astmt)
-			}(account)
-		}
-		wg.Wait()
+		providers.SaveAccounts(provider{}, iTok, accounts, astmt)
 
 		errtx := txn.Commit()
 		if errtx != nil {
@@ -175,34 +299,15 @@ func CreateFromPublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 	}
 }
 
-func upsertItemToken(tok types.ItemToken, stmt *sqlx.NamedStmt) {
+func upsertItemToken(tok types.ItemToken, stmt types.ItemStmt) {
 
 	stmt.MustExec(tok)
 
 }
 
-func upsertAccountWithPlaidAccount(acc types.Account, pAcc plaid.Account, inst string, itemID string, stmt *sqlx.NamedStmt) {
-	acc.Name = pAcc.Name
-	acc.Institution = inst
-	acc.AccountID = pAcc.AccountID
-	acc.Provider = "Plaid"
-	if pAcc.Type == "credit" {
-		acc.Balance = decimal.NewFromFloat(pAcc.Balances.Current * -1)
-	} else {
-		acc.Balance = decimal.NewFromFloat(pAcc.Balances.Current)
-	}
-	acc.Limit = decimal.NewFromFloat(pAcc.Balances.Limit)
-	acc.Available = decimal.NewFromFloat(pAcc.Balances.Available)
-	acc.Currency = pAcc.Balances.ISOCurrencyCode
-	acc.Type = pAcc.Type
-	acc.Subtype = pAcc.Subtype
-	acc.ItemID = itemID
-
-	stmt.MustExec(acc)
-
-}
-
-func GeneratePublicTokenFunction() func(http.ResponseWriter, *http.Request) {
+// GeneratePublicTokenFunction hands the frontend a token to open Plaid Link
+// in update mode for an item that needs logging in again
+func GeneratePublicTokenFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
 		var err error
@@ -218,55 +323,34 @@ func GeneratePublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 			res.Write([]byte(errString))
 		}
 
-		var access string
-
-		query := fmt.Sprintf(`SELECT access_token FROM item_tokens WHERE item_id = %q`, item.ItemID)
-		err = db.DBCon.Get(&access, query)
-		if err != nil {
-			// panic(err)
-			errString := fmt.Sprintf("Error with Generate Token Item Query: %v \n", err)
+		id, _ := auth.FromContext(req.Context())
+		if owned, err := id.OwnsItem(item.ItemID); err != nil || !owned {
+			errString := fmt.Sprintf("Error with Generate Token: item %s belongs to someone else \n", item.ItemID)
 			log.Println(errString)
-			res.WriteHeader(http.StatusInternalServerError)
+			res.WriteHeader(http.StatusForbidden)
 			res.Write([]byte(errString))
+			return
 		}
 
-		pClient, err := newClient()
+		iTok := types.ItemToken{}
+		err = db.DBCon.Get(&iTok, `SELECT * FROM item_tokens WHERE item_id = $1 AND provider = 'Plaid'`, item.ItemID)
 		if err != nil {
 			// panic(err)
-			errString := fmt.Sprintf("Error with Create Token Client Create: %v \n", err)
+			errString := fmt.Sprintf("Error with Generate Token Item Query: %v \n", err)
 			log.Println(errString)
 			res.WriteHeader(http.StatusInternalServerError)
 			res.Write([]byte(errString))
+			return
 		}
 
-		pRes, err := pClient.CreateLinkToken(LinkTokenConfigs{
-			User: &LinkTokenUser{
-				ClientUserID:             time.Now().String(),
-				LegalName:                "Fin User",
-				PhoneNumber:              "8008675309",
-				EmailAddress:             "test@email.com",
-				PhoneNumberVerifiedTime:  time.Now(),
-				EmailAddressVerifiedTime: time.Now(),
-			},
-			ClientName:   "Plaid Test",
-			Products:     []string{"auth"},
-			CountryCodes: []string{"US"},
-			AccessToken: access,
-			Webhook:      "https://webhook-uri.com",
-			AccountFilters: &map[string]map[string][]string{
-				"depository": {
-					"account_subtypes": {"all"},
-				},
-			},
-			Language:              "en",
-			LinkCustomizationName: "default",
-		})
+		token, err := provider{}.Link(cfg, id.User, &iTok)
 		if err != nil {
 			// panic(err)
 			errString := fmt.Sprintf("Error with Create Link Token: %v \n", err)
 			log.Println(errString)
 			res.WriteHeader(http.StatusInternalServerError)
 			res.Write([]byte(errString))
+			return
 		}
 
 		// Deprecated in favor of Link (above)
@@ -280,13 +364,13 @@ func GeneratePublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 		// 	res.Write([]byte(errString))
 		// }
 
-		if pRes.PublicToken == "" {
+		if token == "" {
 			errString := fmt.Sprintf("PublicToken response seems to be empty")
 			log.Println(errString)
 			res.WriteHeader(http.StatusInternalServerError)
 			res.Write([]byte(errString))
 		} else {
-			resJSON := `{"public_token": "` + pRes.PublicToken + `"}`
+			resJSON := `{"public_token": "` + token + `"}`
 			_, err2 := res.Write([]byte(resJSON))
 			if err2 != nil {
 				errString := fmt.Sprintf("Error with Plaid Generate Public Token: %v \n", err2)
@@ -298,103 +382,3 @@ func GeneratePublicTokenFunction() func(http.ResponseWriter, *http.Request) {
 
 	}
 }
-
-func RefreshConnection(iTok types.ItemToken, istmt, astmt *sqlx.NamedStmt) {
-
-	pClient, err := newClient()
-	if err != nil {
-		panic(err)
-	}
-
-	pAccountRes, err := pClient.GetAccounts(iTok.AccessToken)
-	if err != nil {
-		perr, ok := err.(plaid.Error)
-		if ok {
-			log.Println(fmt.Sprintf("Plaid error: %v", perr))
-			if perr.ErrorCode == "ITEM_LOGIN_REQUIRED" {
-				iTok.NeedsReLogin = true
-				upsertItemToken(iTok, istmt)
-				return
-			}
-		}
-		errString := fmt.Sprintf("Error with Create Token Client Get Accounts request: %v \n", err)
-		log.Println(errString)
-		panic(err)
-	}
-
-	var wgAcc sync.WaitGroup
-	for _, account := range pAccountRes.Accounts {
-		wgAcc.Add(1)
-		go func(pAcc plaid.Account) {
-			defer wgAcc.Done()
-			acc := types.Account{}
-			upsertAccountWithPlaidAccount(acc, pAcc, iTok.Institution, iTok.ItemID, astmt)
-		}(account)
-	}
-	wgAcc.Wait()
-}
-
-func FetchTransactionsForItemToken(iTok types.ItemToken, istmt *sqlx.NamedStmt, astmt *sqlx.NamedStmt, tstmt *sqlx.NamedStmt, baseCurrency string) {
-	today := time.Now().Format("2006-01-02")
-
-	pClient, err := newClient()
-	if err != nil {
-		panic(err)
-	}
-
-	var pTransRes plaid.GetTransactionsResponse
-	if iTok.LastDownloadedTransactions.IsZero() {
-		pTransRes, err = pClient.GetTransactions(iTok.AccessToken, "2000-01-01", today)
-	} else {
-		pTransRes, err = pClient.GetTransactions(iTok.AccessToken, iTok.LastDownloadedTransactions.AddDate(0, 0, -40).Format("2006-01-02"), today)
-	}
-	if err != nil {
-		perr, ok := err.(plaid.Error)
-		if ok {
-			if perr.ErrorCode == "ITEM_LOGIN_REQUIRED" {
-				return
-			}
-		}
-		panic(err)
-	}
-
-	for _, ptx := range pTransRes.Transactions {
-		tx := types.Transaction{}
-
-		tx.Date = ptx.Date
-		tx.TransactionID = ptx.ID
-		tx.Description = ptx.Name
-		tx.Amount = decimal.NewFromFloat(ptx.Amount * -1)
-		tx.CurrencyCode = ptx.ISOCurrencyCode
-		tx.NormalizedAmount = db.GetNormalizedAmount(tx.CurrencyCode, baseCurrency, tx.Date, tx.Amount)
-
-		//Searching for category ID match first
-		pCat := types.CategoryPlaid{}
-		query := fmt.Sprintf(`SELECT * FROM plaid__categories WHERE cat_i_d = %q`, ptx.CategoryID)
-		err = db.DBCon.Get(&pCat, query)
-		if err != nil && err != sql.ErrNoRows {
-			panic(err)
-		}
-		if err == sql.ErrNoRows {
-			//If still nil then set category to Uncategorized
-			tx.Category = 106
-			tx.CategoryName = "Uncategorized"
-		} else {
-			tx.Category = pCat.LinkToAppCat
-			tx.CategoryName = pCat.AppCatName
-		}
-
-		var name string
-		err = db.DBCon.Get(&name, "SELECT name FROM accounts WHERE account_id='"+ptx.AccountID+"' AND provider='Plaid' LIMIT 1")
-		if err != nil {
-			panic(err)
-		}
-		tx.AccountName = name
-		tx.AccountID = ptx.AccountID
-
-		tstmt.MustExec(tx)
-	}
-
-	iTok.LastDownloadedTransactions = time.Now()
-	upsertItemToken(iTok, istmt)
-}
@@ -0,0 +1,134 @@
+package plaid
+
+import (
+	"encoding/json"
+	"strings"
+
+	"fin-go/config"
+	"fin-go/providers"
+	"fin-go/types"
+
+	"github.com/plaid/plaid-go/plaid"
+	"github.com/shopspring/decimal"
+)
+
+// syncPageSize is the most changes Plaid sends in one page
+const syncPageSize = 500
+
+// syncRestarts is how many times paging starts over when the item changes
+// halfway through, as Plaid asks
+const syncRestarts = 3
+
+// This version of plaid-go has no /transactions/sync, so its request and
+// response are here, sent through the client's Call
+
+type syncRequestOptions struct {
+	IncludeOriginalDescription bool `json:"include_original_description"`
+}
+
+type syncRequest struct {
+	ClientID    string             `json:"client_id"`
+	Secret      string             `json:"secret"`
+	AccessToken string             `json:"access_token"`
+	Cursor      string             `json:"cursor,omitempty"`
+	Count       int                `json:"count"`
+	Options     syncRequestOptions `json:"options"`
+}
+
+type syncTransaction struct {
+	plaid.Transaction
+	OriginalDescription string `json:"original_description"`
+}
+
+type syncRemoved struct {
+	TransactionID string `json:"transaction_id"`
+}
+
+type syncResponse struct {
+	plaid.APIResponse
+	Added      []syncTransaction `json:"added"`
+	Modified   []syncTransaction `json:"modified"`
+	Removed    []syncRemoved     `json:"removed"`
+	NextCursor string            `json:"next_cursor"`
+	HasMore    bool              `json:"has_more"`
+}
+
+// pageSep joins the cursor paging started from and the next page's while
+// pages are left, as Plaid wants paging to start over from the first when
+// the item changes halfway
+const pageSep = "|"
+
+// syncPage gets one page of changes to an item's transactions since cursor,
+// the whole history for an empty one, with the cursor to carry on from and
+// whether more pages follow
+func syncPage(cfg config.Plaid, pClient *plaid.Client, access, cursor string) ([]providers.Transaction, string, bool, error) {
+	start, current := cursor, cursor
+	if i := strings.Index(cursor, pageSep); i >= 0 {
+		start, current = cursor[:i], cursor[i+len(pageSep):]
+	}
+
+	var page syncResponse
+	for restarts := 0; ; restarts++ {
+		body, err := json.Marshal(syncRequest{
+			ClientID:    cfg.ClientID,
+			Secret:      cfg.Secret(),
+			AccessToken: access,
+			Cursor:      current,
+			Count:       syncPageSize,
+			Options:     syncRequestOptions{IncludeOriginalDescription: true},
+		})
+		if err != nil {
+			return nil, "", false, err
+		}
+		err = pClient.Call("/transactions/sync", body, &page)
+		if perr, ok := err.(plaid.Error); ok && perr.ErrorCode == "TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION" && restarts < syncRestarts {
+			// Pages already stored are upserted again on the way
+			current = start
+			continue
+		}
+		if err != nil {
+			return nil, "", false, err
+		}
+		break
+	}
+
+	txs := []providers.Transaction{}
+	for _, ptx := range page.Added {
+		txs = append(txs, fromSync(ptx))
+	}
+	for _, ptx := range page.Modified {
+		txs = append(txs, fromSync(ptx))
+	}
+	for _, removed := range page.Removed {
+		tx := providers.Transaction{Removed: true}
+		tx.TransactionID = removed.TransactionID
+		txs = append(txs, tx)
+	}
+
+	if page.HasMore {
+		return txs, start + pageSep + page.NextCursor, true, nil
+	}
+	return txs, page.NextCursor, false, nil
+}
+
+func fromSync(ptx syncTransaction) providers.Transaction {
+	tx := providers.Transaction{ProviderCategory: ptx.CategoryID}
+
+	tx.Date = types.Day(ptx.Date)
+	tx.TransactionID = ptx.ID
+	tx.Description = ptx.Name
+	tx.OriginalDescription = ptx.OriginalDescription
+	if tx.OriginalDescription == "" {
+		tx.OriginalDescription = ptx.Name
+	}
+	// Plaid has money going out positive
+	tx.Amount = decimal.NewFromFloat(ptx.Amount * -1)
+	tx.CurrencyCode = ptx.ISOCurrencyCode
+	tx.AccountID = ptx.AccountID
+	tx.Status = "posted"
+	if ptx.Pending {
+		tx.Status = "pending"
+	}
+	tx.ReplacesPending = ptx.PendingTransactionID
+	return tx
+}
@@ -0,0 +1,126 @@
+package plaid
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"fin-go/config"
+
+	"github.com/plaid/plaid-go/plaid"
+)
+
+// syncServer answers /transactions/sync from pages keyed by cursor, failing
+// a cursor's first request with a mutation error when it is in mutate
+func syncServer(t *testing.T, pages map[string]string, mutate map[string]bool) (*httptest.Server, *[]string) {
+	cursors := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/transactions/sync" {
+			t.Errorf("request to %s", req.URL.Path)
+		}
+		body := syncRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		if body.AccessToken != "access-sandbox" || body.ClientID != "client" || body.Secret != "secret" || body.Count != syncPageSize || !body.Options.IncludeOriginalDescription {
+			t.Errorf("request %+v", body)
+		}
+		cursors = append(cursors, body.Cursor)
+		if mutate[body.Cursor] {
+			mutate[body.Cursor] = false
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(`{"error_type": "TRANSACTIONS_ERROR", "error_code": "TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION"}`))
+			return
+		}
+		page, ok := pages[body.Cursor]
+		if !ok {
+			t.Errorf("no page for cursor %q", body.Cursor)
+		}
+		res.Write([]byte(page))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &cursors
+}
+
+func syncClient(t *testing.T, srv *httptest.Server) (config.Plaid, *plaid.Client) {
+	cfg := config.Plaid{Environment: "sandbox", ClientID: "client", SecretSandbox: "secret"}
+	client, err := plaid.NewClient(plaid.ClientOptions{
+		ClientID:    cfg.ClientID,
+		Secret:      cfg.Secret(),
+		Environment: plaid.Environment(srv.URL),
+		HTTPClient:  srv.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg, client
+}
+
+func TestSyncPageRestartsAfterMutation(t *testing.T) {
+	pages := map[string]string{
+		"c0": `{"added": [{"transaction_id": "a", "account_id": "acc", "amount": 12.5, "iso_currency_code": "USD",
+			"date": "2021-01-02", "name": "Coffee", "category_id": "13005000", "pending_transaction_id": "p0"}],
+			"next_cursor": "c1", "has_more": true}`,
+		"c1": `{"modified": [{"transaction_id": "b", "account_id": "acc", "amount": -100, "iso_currency_code": "EUR",
+			"date": "2021-01-03", "name": "Refund", "original_description": "REFUND 123", "pending": true}],
+			"removed": [{"transaction_id": "c"}], "next_cursor": "c2", "has_more": false}`,
+	}
+	srv, cursors := syncServer(t, pages, map[string]bool{"c1": true})
+	cfg, client := syncClient(t, srv)
+
+	txs, cursor, more, err := syncPage(cfg, client, "access-sandbox", "c0")
+	if err != nil || cursor != "c0|c1" || !more || len(txs) != 1 {
+		t.Fatalf("first page: %d transactions, cursor %q, more %v, %v", len(txs), cursor, more, err)
+	}
+	a := txs[0]
+	if a.TransactionID != "a" || a.AccountID != "acc" || a.Date != "2021-01-02" || a.Amount.String() != "-12.5" || a.CurrencyCode != "USD" ||
+		a.Description != "Coffee" || a.OriginalDescription != "Coffee" || a.ProviderCategory != "13005000" ||
+		a.Status != "posted" || a.ReplacesPending != "p0" || a.Removed {
+		t.Errorf("added transaction %+v", a)
+	}
+
+	// The item changes while paging: the second page fails and paging starts
+	// over from c0, handing back the first page again
+	txs, cursor, more, err = syncPage(cfg, client, "access-sandbox", cursor)
+	if err != nil || cursor != "c0|c1" || !more || len(txs) != 1 || txs[0].TransactionID != "a" {
+		t.Fatalf("after the mutation: %+v, cursor %q, more %v, %v", txs, cursor, more, err)
+	}
+
+	txs, cursor, more, err = syncPage(cfg, client, "access-sandbox", cursor)
+	if err != nil || more || len(txs) != 2 {
+		t.Fatalf("last page: %d transactions, more %v, %v", len(txs), more, err)
+	}
+	if cursor != "c2" || strings.Contains(cursor, pageSep) {
+		t.Errorf("cursor after the last page %q, want c2", cursor)
+	}
+	b, c := txs[0], txs[1]
+	if b.TransactionID != "b" || b.Amount.String() != "100" || b.CurrencyCode != "EUR" || b.OriginalDescription != "REFUND 123" ||
+		b.Status != "pending" || b.ReplacesPending != "" || b.Removed {
+		t.Errorf("modified transaction %+v", b)
+	}
+	if c.TransactionID != "c" || !c.Removed {
+		t.Errorf("removed transaction %+v", c)
+	}
+
+	if want := []string{"c0", "c1", "c0", "c1"}; !reflect.DeepEqual(*cursors, want) {
+		t.Errorf("requested cursors %q, want %q", *cursors, want)
+	}
+}
+
+func TestSyncPageGivesUpRestarting(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadRequest)
+		res.Write([]byte(`{"error_code": "TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION"}`))
+	}))
+	defer srv.Close()
+	cfg, client := syncClient(t, srv)
+
+	_, _, _, err := syncPage(cfg, client, "access-sandbox", "c0|c1")
+	if perr, ok := err.(plaid.Error); !ok || perr.ErrorCode != "TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION" {
+		t.Errorf("err = %v, want the mutation error after %d restarts", err, syncRestarts)
+	}
+}
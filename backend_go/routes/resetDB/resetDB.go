@@ -1,7 +1,6 @@
 package resetDB
 
 import (
-	"io/ioutil"
 	"log"
 	"net/http"
 
@@ -13,21 +12,9 @@ import (
 func ForceResetDBFunction() func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
-		raw, err := ioutil.ReadFile("/usr/src/app/backend_go/db/drop.sql")
-		if err != nil {
-			panic(err)
-		}
-		query := string(raw)
-		if _, err := db.DBCon.Exec(query); err != nil {
-			panic(err)
-		}
+		db.AutomaticBackup("pre-reset")
 
-		raw2, err2 := ioutil.ReadFile("/usr/src/app/backend_go/db/create.sql")
-		query2 := string(raw2)
-		if err2 != nil {
-			panic(err)
-		}
-		if _, err := db.DBCon.Exec(query2); err != nil {
+		if err := db.Reset(false); err != nil {
 			panic(err)
 		}
 
@@ -40,21 +27,9 @@ func ForceResetDBFunction() func(http.ResponseWriter, *http.Request) {
 func ForceResetDBFullFunction() func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
-		raw, err := ioutil.ReadFile("/usr/src/app/backend_go/db/fulldrop.sql")
-		if err != nil {
-			panic(err)
-		}
-		query := string(raw)
-		if _, err := db.DBCon.Exec(query); err != nil {
-			panic(err)
-		}
+		db.AutomaticBackup("pre-reset")
 
-		raw2, err2 := ioutil.ReadFile("/usr/src/app/backend_go/db/create.sql")
-		query2 := string(raw2)
-		if err2 != nil {
-			panic(err2)
-		}
-		if _, err := db.DBCon.Exec(query2); err != nil {
+		if err := db.Reset(true); err != nil {
 			panic(err)
 		}
 
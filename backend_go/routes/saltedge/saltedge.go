@@ -4,28 +4,29 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"os"
 	"strconv"
 
-	"sync"
 	"time"
 
+	"fin-go/auth"
+	"fin-go/config"
 	"fin-go/db"
+	"fin-go/providers"
 	"fin-go/types"
 
 	"github.com/gorilla/mux"
-	"github.com/jmoiron/sqlx"
 )
 
-func saltEdgeReq(verb string, url string, params string) string {
+func saltEdgeReq(cfg config.SaltEdge, verb string, url string, params string) string {
 	var err error
 	var req *http.Request
-	if verb == "GET" {
-		req, err = http.NewRequest("GET", url, nil)
+	if verb == "GET" || verb == "DELETE" {
+		req, err = http.NewRequest(verb, url, nil)
 	} else if verb == "POST" {
 		jsonStr := []byte(params)
 		req, err = http.NewRequest("POST", url, bytes.NewBuffer(jsonStr))
@@ -35,8 +36,8 @@ func saltEdgeReq(verb string, url string, params string) string {
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("App-id", os.Getenv("SALTEDGE_APP_ID"))
-	req.Header.Set("Secret", os.Getenv("SALTEDGE_APP_SECRET"))
+	req.Header.Set("App-id", cfg.AppID)
+	req.Header.Set("Secret", cfg.AppSecret)
 	req.Header.Set("Expires-at", strconv.FormatInt((time.Now().Unix()+60), 10))
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -56,108 +57,110 @@ func saltEdgeReq(verb string, url string, params string) string {
 
 }
 
-func RefreshConnectionsFunction(istmt, astmt *sqlx.NamedStmt) {
-	var wgConnections sync.WaitGroup
-	url := "https://www.saltedge.com/api/v5/connections?customer_id=" + os.Getenv("SALTEDGE_CUSTOMER_ID")
-
-	connections := saltEdgeReq("GET", url, "")
-	var data types.ConnectionResponse
-	json.Unmarshal([]byte(connections), &data)
-	for _, connection := range data.Data {
-		wgConnections.Add(1)
-		go func(conn types.SEConnection) {
-			defer wgConnections.Done()
-			item := types.ItemToken{}
-			item.Institution = conn.ProviderName
-			item.Provider = "SaltEdge"
-			if conn.LastAttempt.Interactive {
-				item.Interactive = true
-			} else {
-				item.Interactive = false
-			}
-			item.LastRefresh = conn.LastSuccessAt
-			item.NextRefreshPossible = conn.NextRefreshPossibleAt
-			item.ItemID = conn.ID
-
-			istmt.MustExec(item)
-			url2 := "https://www.saltedge.com/api/v5/accounts?connection_id=" + conn.ID
-			accounts := saltEdgeReq("GET", url2, "")
-
-			var data types.AccountResponse
-			json.Unmarshal([]byte(accounts), &data)
-			var wgAccounts sync.WaitGroup
-			for _, account := range data.Data {
-				wgAccounts.Add(1)
-				go func(SEAcc types.SEAccount) {
-					defer wgAccounts.Done()
-					acc := types.Account{}
-					if SEAcc.Extra.AccountName == "" {
-						acc.Name = SEAcc.Name
-					} else {
-						acc.Name = SEAcc.Extra.AccountName
-					}
-					acc.Institution = item.Institution
-					acc.Provider = "SaltEdge"
-					acc.AccountID = SEAcc.ID
-					acc.ItemID = SEAcc.ConnectionID
-					acc.Type = SEAcc.Nature
-					acc.Limit = SEAcc.Extra.CreditLimit
-					acc.Available = SEAcc.Extra.AvailableAmount
-					acc.Balance = SEAcc.Balance
-					acc.Currency = SEAcc.CurrencyCode
-
-					astmt.MustExec(acc)
-				}(account)
-			}
-			wgAccounts.Wait()
-		}(connection)
+// customers returns the SaltEdge customers to refresh with the user each
+// belongs to, the configured customer being the household's (user 0)
+func customers(cfg config.SaltEdge) map[string]int {
+	owners := map[string]int{}
+	if cfg.CustomerID != "" {
+		owners[cfg.CustomerID] = 0
+	}
+	users, err := auth.SelectUsers()
+	if err != nil {
+		panic(err)
+	}
+	for _, u := range users {
+		if u.SaltEdgeCustomerID != "" {
+			owners[u.SaltEdgeCustomerID] = u.ID
+		}
+	}
+	return owners
+}
 
+// customerFor returns the SaltEdge customer new connections of a user go to,
+// making one the first time. Without a user it is the configured customer.
+func customerFor(cfg config.SaltEdge, user types.User) (string, error) {
+	if user.ID == 0 {
+		if cfg.CustomerID == "" {
+			return "", errors.New("SALTEDGE_CUSTOMER_ID is empty")
+		}
+		return cfg.CustomerID, nil
+	}
+	if user.SaltEdgeCustomerID != "" {
+		return user.SaltEdgeCustomerID, nil
 	}
 
-	wgConnections.Wait()
+	params := fmt.Sprintf(`{
+			"data": {
+				"identifier": %q
+			}
+		}`, "fin-"+strconv.Itoa(user.ID)+"-"+user.Username)
+	created := saltEdgeReq(cfg, "POST", "https://www.saltedge.com/api/v5/customers", params)
+	data := types.CustomerResponse{}
+	if err := json.Unmarshal([]byte(created), &data); err != nil || data.Data.ID == "" {
+		return "", fmt.Errorf("creating a SaltEdge customer for %s: %v %s", user.Username, err, created)
+	}
+	db.DBCon.MustExec("UPDATE users SET saltedge_customer_id = $1 WHERE id = $2", data.Data.ID, user.ID)
+	log.Println("Created SaltEdge customer", data.Data.ID, "for", user.Username)
+	return data.Data.ID, nil
+}
 
+// nextID reads meta.next_id of a SaltEdge list, where the page after it
+// starts, empty on the last page
+func nextID(next interface{}) string {
+	switch id := next.(type) {
+	case string:
+		return id
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	}
+	return ""
 }
 
-func RefreshConnectionInteractiveFunction() func(http.ResponseWriter, *http.Request) {
-	return func(res http.ResponseWriter, req *http.Request) {
+// pageURL asks a SaltEdge list for the page starting at fromID
+func pageURL(url, fromID string) string {
+	if fromID == "" {
+		return url
+	}
+	return url + "&from_id=" + fromID
+}
 
-		var err error
+// provider is SaltEdge as a providers.Provider, registered under "SaltEdge".
+// Its items are connections.
+type provider struct{}
 
-		vars := mux.Vars(req)
-		connID := vars["id"]
+func init() {
+	providers.Register(provider{})
+}
+
+func (provider) Name() string {
+	return "SaltEdge"
+}
+
+func (provider) Enabled(cfg *config.Config) bool {
+	return cfg.SaltEdge.Enabled
+}
 
-		url := "https://www.saltedge.com/api/v5/connect_sessions/refresh"
-		params := fmt.Sprintf(`{
+// Link opens a connect session, for a new connection of the user's customer
+// or to refresh an existing one, and returns its URL
+func (provider) Link(cfg *config.Config, user types.User, item *types.ItemToken) (string, error) {
+	var url, params string
+	if item != nil {
+		url = "https://www.saltedge.com/api/v5/connect_sessions/refresh"
+		params = fmt.Sprintf(`{
 			"data": {
 				"connection_id": %q,
 				"attempt": {
 				"return_to": %q
 				}
 			}
-		}`, connID, os.Getenv("BASE_URL"))
-		refresh := saltEdgeReq("POST", url, params)
-
-		data := types.CreateRefreshResponse{}
-
-		err = json.Unmarshal([]byte(refresh), &data)
-		if err != nil {
-			fmt.Printf("Error with Create Connection Interactive: %v \n", err)
-		}
-
-		res.WriteHeader(http.StatusOK)
-		_, err = res.Write([]byte(data.Data.ConnectURL))
+		}`, item.ItemID, cfg.BaseURL)
+	} else {
+		customerID, err := customerFor(cfg.SaltEdge, user)
 		if err != nil {
-			fmt.Printf("Error with Create Connection Interactive: %v \n", err)
+			return "", err
 		}
-
-	}
-}
-
-func CreateConnectionInteractiveFunction() func(http.ResponseWriter, *http.Request) {
-	return func(res http.ResponseWriter, req *http.Request) {
-
-		url := "https://www.saltedge.com/api/v5/connect_sessions/create"
-		params := fmt.Sprintf(`{
+		url = "https://www.saltedge.com/api/v5/connect_sessions/create"
+		params = fmt.Sprintf(`{
 			"data": {
 				"customer_id": %q,
 				"consent": {
@@ -170,110 +173,222 @@ func CreateConnectionInteractiveFunction() func(http.ResponseWriter, *http.Reque
 				"return_to": %q
 				}
 			}
-		}`, os.Getenv("SALTEDGE_CUSTOMER_ID"), os.Getenv("BASE_URL"))
-		create := saltEdgeReq("POST", url, params)
-
-		data := types.CreateRefreshResponse{}
+		}`, customerID, cfg.BaseURL)
+	}
+	session := saltEdgeReq(cfg.SaltEdge, "POST", url, params)
 
-		err := json.Unmarshal([]byte(create), &data)
-		if err != nil {
-			errString := fmt.Sprintf("Error with Create Connection Interactive: %v \n", err)
-			log.Println(errString)
-			res.WriteHeader(http.StatusInternalServerError)
-			res.Write([]byte(errString))
-		}
+	data := types.CreateRefreshResponse{}
+	if err := json.Unmarshal([]byte(session), &data); err != nil {
+		return "", err
+	}
+	if data.Data.ConnectURL == "" {
+		return "", errors.New("ConnectURL field empty")
+	}
+	return data.Data.ConnectURL, nil
+}
 
-		if data.Data.ConnectURL != "" {
-			_, err2 := res.Write([]byte(data.Data.ConnectURL))
-			if err2 != nil {
-				errString := fmt.Sprintf("Error with Create Connection Interactive: %v \n", err)
-				log.Println(errString)
-				res.WriteHeader(http.StatusInternalServerError)
-				res.Write([]byte(errString))
+// Items lists the connections of every customer, as connections can be made
+// on SaltEdge's side
+func (provider) Items(cfg *config.Config) ([]types.ItemToken, error) {
+	items := []types.ItemToken{}
+	for customerID, userID := range customers(cfg.SaltEdge) {
+		url := "https://www.saltedge.com/api/v5/connections?customer_id=" + customerID
+
+		for next := ""; ; {
+			connections := saltEdgeReq(cfg.SaltEdge, "GET", pageURL(url, next), "")
+			var data types.ConnectionResponse
+			if err := json.Unmarshal([]byte(connections), &data); err != nil {
+				return nil, err
+			}
+			for _, conn := range data.Data {
+				item := types.ItemToken{}
+				item.Institution = conn.ProviderName
+				item.Provider = "SaltEdge"
+				item.ItemID = conn.ID
+				item.UserID = userID
+				items = append(items, item)
+			}
+			if next = nextID(data.Meta.NextID); next == "" {
+				break
 			}
-		} else {
-			errString := fmt.Sprintf("ConnectURL field empty")
-			log.Println(errString)
-			res.WriteHeader(http.StatusInternalServerError)
-			res.Write([]byte(errString))
 		}
 	}
+	return items, nil
 }
 
-func FetchTransactionsForItemToken(iTok types.ItemToken, istmt *sqlx.NamedStmt, astmt *sqlx.NamedStmt, tstmt *sqlx.NamedStmt, baseCurrency string) {
+func (provider) RefreshAccounts(cfg *config.Config, item types.ItemToken) ([]types.Account, error) {
+	url := "https://www.saltedge.com/api/v5/accounts?connection_id=" + item.ItemID
 
-	url := "https://www.saltedge.com/api/v5/transactions?connection_id=" + iTok.ItemID
+	accounts := []types.Account{}
+	for next := ""; ; {
+		res := saltEdgeReq(cfg.SaltEdge, "GET", pageURL(url, next), "")
+		var data types.AccountResponse
+		if err := json.Unmarshal([]byte(res), &data); err != nil {
+			return nil, err
+		}
+		for _, SEAcc := range data.Data {
+			acc := types.Account{}
+			if SEAcc.Extra.AccountName == "" {
+				acc.Name = SEAcc.Name
+			} else {
+				acc.Name = SEAcc.Extra.AccountName
+			}
+			acc.AccountID = SEAcc.ID
+			acc.Type = SEAcc.Nature
+			acc.Limit = SEAcc.Extra.CreditLimit
+			acc.Available = SEAcc.Extra.AvailableAmount
+			acc.Balance = SEAcc.Balance
+			acc.Currency = SEAcc.CurrencyCode
+			accounts = append(accounts, acc)
+		}
+		if next = nextID(data.Meta.NextID); next == "" {
+			return accounts, nil
+		}
+	}
+}
 
-	res := saltEdgeReq("GET", url, "")
+// FetchTransactions goes through all of a connection's transactions on
+// every sync, a page at a time. The cursor is the ID the next page starts
+// at, so only a sync that was cut short has one.
+func (provider) FetchTransactions(cfg *config.Config, item types.ItemToken, cursor string) ([]providers.Transaction, string, bool, error) {
+	url := "https://www.saltedge.com/api/v5/transactions?connection_id=" + item.ItemID
+	res := saltEdgeReq(cfg.SaltEdge, "GET", pageURL(url, cursor), "")
 
 	var data types.TransactionsResponse
-	err := json.Unmarshal([]byte(res), &data)
-	if err != nil {
-		panic(err)
+	if err := json.Unmarshal([]byte(res), &data); err != nil {
+		return nil, "", false, err
 	}
+	txs := []providers.Transaction{}
 	for _, tx := range data.Data {
-		var err error
-		trans := types.Transaction{}
+		trans := providers.Transaction{ProviderCategory: tx.Category}
 		if tx.Extra.PostingDate == "" {
-			trans.Date = tx.MadeOn
+			trans.Date = types.Day(tx.MadeOn)
 		} else {
-			trans.Date = tx.Extra.PostingDate
+			trans.Date = types.Day(tx.Extra.PostingDate)
 		}
 		trans.Description = tx.Description
+		// Salt Edge passes the bank's description through as it is
+		trans.OriginalDescription = tx.Description
 		trans.Amount = tx.Amount
 		trans.AccountID = tx.AccountID
-
-		var name string
-		querytest := "SELECT name FROM accounts WHERE account_id='" + tx.AccountID + "' AND provider='SaltEdge'"
-		// log.Println(querytest)
-		err = db.DBCon.Get(&name, querytest)
-		if err != nil {
-			panic(err)
+		trans.TransactionID = tx.ID
+		trans.CurrencyCode = tx.CurrencyCode
+		trans.Status = "posted"
+		if tx.Status == "pending" {
+			trans.Status = "pending"
 		}
-		trans.AccountName = name
+		// SaltEdge doesn't link a posted transaction to the pending one
+		trans.GuessPending = true
+		txs = append(txs, trans)
+	}
+	next := nextID(data.Meta.NextID)
+	return txs, next, next != "", nil
+}
 
-		trans.TransactionID = tx.ID
+// MapCategory looks a SaltEdge category up among the personal ones, as a
+// bottom category first, then as a sub category
+func (provider) MapCategory(category string) (int, string, error) {
+	sCat := types.CategorySE{}
+	err := db.DBCon.Get(&sCat, `SELECT * FROM salt_edge__categories WHERE bottom_category = $1 AND top_category = 'personal'`, category)
+	if err == sql.ErrNoRows {
+		err = db.DBCon.Get(&sCat, `SELECT * FROM salt_edge__categories WHERE sub_category = $1 AND top_category = 'personal'`, category)
+	}
+	if err == sql.ErrNoRows {
+		return providers.UncategorizedID, providers.UncategorizedName, nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	return sCat.LinkToAppCat, sCat.AppCatName, nil
+}
 
-		trans.CurrencyCode = tx.CurrencyCode
-		// log.Println(query2)
-		trans.NormalizedAmount = db.GetNormalizedAmount(trans.CurrencyCode, baseCurrency, trans.Date, trans.Amount)
-
-		//Searching for bottom category match first
-		sCat := types.CategorySE{}
-		query := fmt.Sprintf(`SELECT * FROM salt_edge__categories WHERE bottom_category = %q AND top_category = 'personal'`, tx.Category)
-		err = db.DBCon.Get(&sCat, query)
-		if err != nil && err != sql.ErrNoRows {
-			panic(err)
+func (provider) RemoveItem(cfg *config.Config, item types.ItemToken) error {
+	res := saltEdgeReq(cfg.SaltEdge, "DELETE", "https://www.saltedge.com/api/v5/connections/"+item.ItemID, "")
+	var data struct {
+		Data struct {
+			Removed bool `json:"removed"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(res), &data); err != nil {
+		return err
+	}
+	if !data.Data.Removed {
+		return fmt.Errorf("SaltEdge didn't remove connection %s: %s", item.ItemID, res)
+	}
+	return nil
+}
+
+// Health reads the connection's status: an inactive connection needs its
+// owner to reconnect
+func (provider) Health(cfg *config.Config, item types.ItemToken) (providers.Health, error) {
+	res := saltEdgeReq(cfg.SaltEdge, "GET", "https://www.saltedge.com/api/v5/connections/"+item.ItemID, "")
+	var data types.ConnectionShowResponse
+	if err := json.Unmarshal([]byte(res), &data); err != nil {
+		return providers.Health{}, err
+	}
+	conn := data.Data
+	if conn.ID == "" {
+		return providers.Health{}, fmt.Errorf("SaltEdge connection %s: %s", item.ItemID, res)
+	}
+	return providers.Health{
+		NeedsReLogin:        conn.Status == "inactive",
+		Interactive:         conn.LastAttempt.Interactive,
+		LastRefresh:         conn.LastSuccessAt,
+		NextRefreshPossible: conn.NextRefreshPossibleAt,
+		Status:              conn.Status,
+	}, nil
+}
+
+func RefreshConnectionInteractiveFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		var err error
+
+		vars := mux.Vars(req)
+		connID := vars["id"]
+
+		id, _ := auth.FromContext(req.Context())
+		if owned, err := id.OwnsItem(connID); err != nil || !owned {
+			errString := fmt.Sprintf("Error with Refresh Connection Interactive: connection %s belongs to someone else \n", connID)
+			log.Println(errString)
+			res.WriteHeader(http.StatusForbidden)
+			res.Write([]byte(errString))
+			return
 		}
-		if (types.CategorySE{}) == sCat {
-			//If nil for bottom category then look for match in sub category
-			query := fmt.Sprintf(`SELECT * FROM salt_edge__categories WHERE sub_category = %q AND top_category = 'personal'`, tx.Category)
-			err := db.DBCon.Get(&sCat, query)
-			if err != nil && err != sql.ErrNoRows {
-				panic(err)
-			}
-			if (types.CategorySE{}) == sCat {
-				//If still nil then set category to Uncategorized
-				trans.Category = 106
-				trans.CategoryName = "Uncategorized"
-			} else {
-				trans.Category = sCat.LinkToAppCat
-				trans.CategoryName = sCat.AppCatName
-			}
-		} else {
-			trans.Category = sCat.LinkToAppCat
-			trans.CategoryName = sCat.AppCatName
+
+		connectURL, err := provider{}.Link(cfg, id.User, &types.ItemToken{ItemID: connID})
+		if err != nil {
+			fmt.Printf("Error with Create Connection Interactive: %v \n", err)
 		}
-		tstmt.MustExec(trans)
-	}
 
+		res.WriteHeader(http.StatusOK)
+		_, err = res.Write([]byte(connectURL))
+		if err != nil {
+			fmt.Printf("Error with Create Connection Interactive: %v \n", err)
+		}
 
-	if iTok.Interactive {
-		iTok.LastDownloadedTransactions = iTok.LastRefresh
-	} else {
-		iTok.LastDownloadedTransactions = time.Now()
 	}
+}
+
+func CreateConnectionInteractiveFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
 
-	istmt.MustExec(iTok)
+		id, _ := auth.FromContext(req.Context())
+		connectURL, err := provider{}.Link(cfg, id.User, nil)
+		if err != nil {
+			errString := fmt.Sprintf("Error with Create Connection Interactive: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusInternalServerError)
+			res.Write([]byte(errString))
+			return
+		}
 
+		_, err = res.Write([]byte(connectURL))
+		if err != nil {
+			errString := fmt.Sprintf("Error with Create Connection Interactive: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusInternalServerError)
+			res.Write([]byte(errString))
+		}
+	}
 }
@@ -3,19 +3,26 @@ package transactions
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
-	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"fin-go/auth"
+	"fin-go/config"
 	"fin-go/db"
+	"fin-go/importers"
 	"fin-go/routes/accounts"
 	"fin-go/routes/analysisTrees"
+	"fin-go/routes/duplicates"
+	"fin-go/routes/importProfiles"
 	"fin-go/types"
 
 	_ "github.com/jmoiron/sqlx"
@@ -42,18 +49,58 @@ type account struct {
 
 func SelectAll() []types.Transaction {
 	dbdata := []types.Transaction{}
-	err := db.DBCon.Select(&dbdata, "SELECT * FROM `transactions`")
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM transactions")
 	if err != nil {
 		panic(err)
 	}
 	return dbdata
 }
 
+// SelectWhere returns the transactions matching an auth.Identity filter
+func SelectWhere(filter string) []types.Transaction {
+	dbdata := []types.Transaction{}
+	err := db.DBCon.Select(&dbdata, "SELECT * FROM transactions WHERE "+filter)
+	if err != nil {
+		panic(err)
+	}
+	return dbdata
+}
+
+// writeForbidden refuses changes to transactions of accounts the caller can
+// only read
+func writeForbidden(res http.ResponseWriter, context string) {
+	errString := fmt.Sprintf("Error with %s: %v \n", context, ErrAccountNotWritable)
+	log.Println(errString)
+	res.WriteHeader(http.StatusForbidden)
+	res.Write([]byte(errString))
+}
+
+// canWrite reports whether id may change the transactions, checking the
+// account each is in now as well as the one the request gives, since an
+// update never moves a transaction to another account
+func canWrite(id auth.Identity, txs ...types.Transaction) bool {
+	accountIDs := []string{}
+	for _, tx := range txs {
+		var current []string
+		if err := db.DBCon.Select(&current, "SELECT account_id FROM transactions WHERE transaction_id = $1", tx.TransactionID); err != nil {
+			panic(err)
+		}
+		accountIDs = append(accountIDs, tx.AccountID)
+		accountIDs = append(accountIDs, current...)
+	}
+	ok, err := id.CanWriteAccounts(accountIDs...)
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
 func GetFunction() func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
 		start := time.Now()
-		dbdata := SelectAll()
+		id, _ := auth.FromContext(req.Context())
+		dbdata := SelectWhere(id.TransactionFilter(auth.View(req), false))
 		// log.Println("SelectAll Transactions done in:", time.Since(start))
 
 		res.WriteHeader(http.StatusOK)
@@ -78,6 +125,13 @@ func PutFunction() func(http.ResponseWriter, *http.Request) {
 			panic(err)
 		}
 
+		id, _ := auth.FromContext(req.Context())
+		if !canWrite(id, p) {
+			txn.Rollback()
+			writeForbidden(res, "Transaction")
+			return
+		}
+
 		tstmt.MustExec(p)
 
 		errC := txn.Commit()
@@ -89,17 +143,38 @@ func PutFunction() func(http.ResponseWriter, *http.Request) {
 	}
 }
 
-func CheckFunction() func(http.ResponseWriter, *http.Request) {
+func CheckFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
-		p := []types.ImportTransaction{}
-
-		err := json.NewDecoder(req.Body).Decode(&p)
+		// The body is either the bare list of transactions or an object that
+		// also carries the import options
+		var body json.RawMessage
+		err := json.NewDecoder(req.Body).Decode(&body)
 		if err != nil {
 			panic(err)
 		}
+		cp := types.CheckPostData{}
+		if trimmed := strings.TrimSpace(string(body)); strings.HasPrefix(trimmed, "{") {
+			err = json.Unmarshal(body, &cp)
+		} else {
+			err = json.Unmarshal(body, &cp.TxSet)
+		}
+		if err != nil {
+			errString := fmt.Sprintf("Error with Check Decode: %v \n", err)
+			log.Println(errString)
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(errString))
+			return
+		}
+
+		p, parseInfo, rowErrs := importers.Normalize(cp.TxSet, cp.Options)
+		if rowErrs != nil {
+			writeRowErrors(res, rowErrs)
+			return
+		}
 
 		var resJSON struct {
+			Parse types.ImportParseInfo `json:"parse"`
 			TSets struct {
 				mu       sync.Mutex                 `json:"-"`
 				TSingles []types.CompareTransSingle `json:"transSets"`
@@ -110,6 +185,8 @@ func CheckFunction() func(http.ResponseWriter, *http.Request) {
 			} `json:"cats"`
 		}
 
+		resJSON.Parse = parseInfo
+
 		txArray := make([]types.Transaction, len(p))
 
 		for i, itx := range p {
@@ -117,25 +194,12 @@ func CheckFunction() func(http.ResponseWriter, *http.Request) {
 			if itx.Amount.IsZero() {
 				continue
 			}
-			dt, err := date.Parse("01/02/2006", itx.Date)
+			// Dates were checked and rewritten as ISO by importers.Normalize
+			dt, err := date.Parse("2006-01-02", itx.Date)
 			if err != nil {
-				dt, err := date.Parse("1/02/2006", itx.Date)
-				if err != nil {
-					dt, err := date.Parse("2006-01-02", itx.Date)
-					if err != nil {
-						errString := fmt.Sprintf("Error with Import Date Parse: %v \n", err)
-						log.Println(errString)
-						res.WriteHeader(http.StatusInternalServerError)
-						res.Write([]byte(errString))
-					} else {
-						tx.Date = dt.String()
-					}
-				} else {
-					tx.Date = dt.String()
-				}
-			} else {
-				tx.Date = dt.String()
+				panic(err)
 			}
+			tx.Date = types.Day(dt.String())
 
 			tx.Description = itx.Description
 			if itx.TransactionType == "debit" {
@@ -153,12 +217,7 @@ func CheckFunction() func(http.ResponseWriter, *http.Request) {
 			} else {
 				if _, ok := types.MintCatMap[itx.Category]; ok {
 				} else {
-					sCat := types.Category{}
-					query := fmt.Sprintf(`SELECT * FROM categories WHERE sub_category = %q`, itx.Category)
-					err = db.DBCon.Get(&sCat, query)
-					if err != nil && err != sql.ErrNoRows {
-						panic(err)
-					}
+					sCat := lookupCategory(itx.Category)
 					// Setting to nil so we can check with user later to ID the category
 					if (types.Category{}) == sCat {
 						v := false
@@ -180,28 +239,43 @@ func CheckFunction() func(http.ResponseWriter, *http.Request) {
 				}
 			}
 
-			possibleMatches := []types.Transaction{}
-			query := fmt.Sprintf(`SELECT * FROM transactions WHERE amount = %q AND date = %q`, tx.Amount, tx.Date)
-			err = db.DBCon.Select(&possibleMatches, query)
-			if err != nil && err != sql.ErrNoRows {
-				panic(err)
+			possibleMatches := []duplicates.ScoredTransaction{}
+			matchType := "trans"
+			if extID := externalTransactionID(itx); extID != "" {
+				extMatches := []types.Transaction{}
+				err = db.DBCon.Select(&extMatches, `SELECT * FROM transactions WHERE transaction_id = $1`, extID)
+				if err != nil && err != sql.ErrNoRows {
+					panic(err)
+				}
+				for _, matchTx := range extMatches {
+					possibleMatches = append(possibleMatches, duplicates.ScoredTransaction{Transaction: matchTx, Score: 1})
+				}
+				matchType = "externalID"
+			}
+			if len(possibleMatches) == 0 {
+				// Posting dates and descriptions differ between banks, Mint and the APIs
+				matchType = "trans"
+				possibleMatches = duplicates.Candidates(tx, cfg.Duplicates)
 			}
 			if len(possibleMatches) > 0 {
-				for _, matchTx := range possibleMatches {
+				for _, match := range possibleMatches {
+					matchTx := match.Transaction
 
 					compareSet := types.CompareTransSingle{}
-					compareSet.Trans1.Date = tx.Date
+					compareSet.Trans1.Date = string(tx.Date)
 					compareSet.Trans1.Description = tx.Description
 					compareSet.Trans1.Amount = tx.Amount
 					compareSet.Trans1.CurrencyCode = tx.CurrencyCode
 					compareSet.Trans1.AccountName = itx.AccountName
-					compareSet.Trans2.Date = matchTx.Date
+					compareSet.Trans2.Date = string(matchTx.Date)
 					compareSet.Trans2.Description = matchTx.Description
 					compareSet.Trans2.Amount = matchTx.Amount
 					compareSet.Trans2.CurrencyCode = matchTx.CurrencyCode
 					compareSet.Trans2.AccountName = matchTx.AccountName
 					compareSet.Trans2.AccountID = matchTx.AccountID
-					compareSet.Type = "trans"
+					compareSet.Type = matchType
+					compareSet.Score = match.Score
+					compareSet.IsMatch = matchType == "externalID"
 
 					resJSON.TSets.TSingles = append(resJSON.TSets.TSingles, compareSet)
 
@@ -220,145 +294,212 @@ func CheckFunction() func(http.ResponseWriter, *http.Request) {
 	}
 }
 
-func ImportFunction() func(http.ResponseWriter, *http.Request) {
+func ImportFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
 	return func(res http.ResponseWriter, req *http.Request) {
 
-		db.GetNewXML()
-		baseCurrency := strings.ToUpper(os.Getenv("BASE_CURRENCY"))
+		p := types.ImportPostData{}
 
-		var cAccs struct {
-			mu       sync.Mutex
-			accounts []types.Account
+		err := json.NewDecoder(req.Body).Decode(&p)
+		if err != nil {
+			panic(err)
 		}
 
-		var countInt struct {
-			mu         sync.Mutex
-			countDup   int
-			countUncat int
-			countImp   int
+		id, _ := auth.FromContext(req.Context())
+		result, err := Import(p, cfg, id)
+		if rowErrs, ok := err.(importers.RowErrors); ok {
+			writeRowErrors(res, rowErrs)
+			return
+		}
+		if err != nil {
+			errString := fmt.Sprintf("%v \n", err)
+			log.Println(errString)
+			res.WriteHeader(importStatus(err))
+			res.Write([]byte(errString))
+			return
 		}
 
-		p := types.ImportPostData{}
+		if !result.DryRun {
+			analysisTrees.ReAnalyze()
+		}
 
-		err := json.NewDecoder(req.Body).Decode(&p)
-		if err != nil {
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(result); err != nil {
 			panic(err)
 		}
+	}
+}
 
-		dbAccs := accounts.SelectAll()
+// ErrAccountNotWritable is returned for imports into an account the importer
+// can only read
+var ErrAccountNotWritable = errors.New("account is not shared for writing")
 
-		txn := db.DBCon.MustBegin()
-		astmt := types.PrepAccountSt(txn)
-		tstmt := types.PrepTransSt(txn)
+func importStatus(err error) int {
+	if errors.Is(err, ErrAccountNotWritable) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
 
-		for _, itx := range p.TxSet {
-			tx := types.Transaction{}
-			if itx.Amount.IsZero() {
-				continue
+// Import writes a set of import transactions to the DB in one DB transaction,
+// resolving categories and accounts the same way for every import source.
+// Rows only go to accounts id can change, and new accounts belong to id's user.
+// Transactions already in the DB are counted as duplicates and skipped. Each
+// import is recorded as a batch with the transactions and accounts it created,
+// so it can be rolled back. A dry run does the same work but rolls the DB
+// transaction back and lists what would happen to every row.
+func Import(p types.ImportPostData, cfg *config.Config, id auth.Identity) (types.ImportResult, error) {
+
+	result := types.ImportResult{CreatedAccounts: []string{}, DryRun: p.DryRun, Profile: p.Profile}
+
+	chosen := []string{}
+	for _, acc := range p.IdentifiedAccounts {
+		chosen = append(chosen, acc.RefAccountID)
+	}
+	if ok, err := id.CanWriteAccounts(chosen...); err != nil {
+		return result, err
+	} else if !ok {
+		return result, ErrAccountNotWritable
+	}
+
+	// Read every row before touching the DB, so a bad row fails the whole
+	// import instead of leaving it half done
+	txSet, _, rowErrs := importers.Normalize(p.TxSet, p.Options)
+	if rowErrs != nil {
+		return result, rowErrs
+	}
+
+	db.GetNewXML()
+	baseCurrency := cfg.BaseCurrency
+
+	var cAccs struct {
+		mu       sync.Mutex
+		accounts []types.Account
+	}
+
+	var countInt struct {
+		mu         sync.Mutex
+		countDup   int
+		countUncat int
+		countImp   int
+	}
+
+	dbAccs := accounts.SelectAll()
+	writableAccs := accounts.SelectWhere(id.AccountFilter(auth.ViewHousehold, true))
+	// Count identical rows within the file, so each gets its own ID
+	occurrences := map[string]int{}
+
+	// Rows created from here on are checked for duplicates after the commit
+	started := time.Now().Add(-time.Second)
+
+	txn := db.DBCon.MustBegin()
+	astmt := types.PrepAccountSt(txn)
+	tstmt := types.PrepTransSt(txn)
+
+	batchID, err := db.InsertID(txn, `INSERT INTO import_batches (filename, profile) VALUES ($1, $2)`, p.Filename, p.Profile)
+	if err != nil {
+		panic(err)
+	}
+
+	// preview records the outcome of a row for dry runs
+	preview := func(i int, action string, tx types.Transaction) {
+		if p.DryRun {
+			result.Rows = append(result.Rows, types.ImportPreviewRow{Row: i + 1, Action: action, Transaction: tx})
+		}
+	}
+
+	for i, itx := range txSet {
+		tx := types.Transaction{}
+		if itx.Amount.IsZero() {
+			preview(i, "skip", tx)
+			continue
+		}
+		dt, err := date.Parse("2006-01-02", itx.Date)
+		if err != nil {
+			panic(err)
+		}
+		tx.Date = types.Day(dt.String())
+		tx.Description = itx.Description
+		if itx.TransactionType == "debit" {
+			tx.Amount = itx.Amount.Mul(decimal.NewFromInt(-1))
+		} else {
+			tx.Amount = itx.Amount
+		}
+
+		tx.Description = itx.Description
+		if itx.TransactionType == "debit" {
+			tx.Amount = itx.Amount.Mul(decimal.NewFromInt(-1))
+		} else {
+			tx.Amount = itx.Amount
+		}
+		if itx.CurrencyCode == "" {
+			tx.CurrencyCode = "USD"
+		} else {
+			tx.CurrencyCode = itx.CurrencyCode
+		}
+		tx.NormalizedAmount = db.GetNormalizedAmount(tx.CurrencyCode, baseCurrency, string(tx.Date), tx.Amount)
+		tx.OriginalDescription = itx.OriginalDescription
+		tx.TransactionType = itx.TransactionType
+		if tx.TransactionType == "" {
+			tx.TransactionType = "credit"
+			if tx.Amount.IsNegative() {
+				tx.TransactionType = "debit"
 			}
-			dt, err := date.Parse("01/02/2006", itx.Date)
-			if err != nil {
-				dt, err := date.Parse("1/02/2006", itx.Date)
+		}
+		tx.Labels = itx.Labels
+		tx.Notes = itx.Notes
+
+		if itx.Category == "" {
+			countInt.countUncat++
+			tx.Category = 106
+			tx.CategoryName = "Uncategorized"
+		} else {
+			if v, ok := types.MintCatMap[itx.Category]; ok {
+				tx.Category = v
+				var st string
+				query := fmt.Sprintf(`Select sub_category FROM categories WHERE id = %d`, tx.Category)
+				// log.Println(query)
+				err = db.DBCon.Get(&st, query)
 				if err != nil {
-					dt, err := date.Parse("2006-01-02", itx.Date)
-					if err != nil {
-						errString := fmt.Sprintf("Error with Import Date Parse: %v \n", err)
-						log.Println(errString)
-						res.WriteHeader(http.StatusInternalServerError)
-						res.Write([]byte(errString))
-					} else {
-						tx.Date = dt.String()
-					}
-				} else {
-					tx.Date = dt.String()
+					panic(err)
 				}
+				tx.CategoryName = st
 			} else {
-				tx.Date = dt.String()
-			}
-			tx.Description = itx.Description
-			if itx.TransactionType == "debit" {
-				tx.Amount = itx.Amount.Mul(decimal.NewFromInt(-1))
-			} else {
-				tx.Amount = itx.Amount
-			}
-
-			tx.Description = itx.Description
-			if itx.TransactionType == "debit" {
-				tx.Amount = itx.Amount.Mul(decimal.NewFromInt(-1))
-			} else {
-				tx.Amount = itx.Amount
-			}
-			if itx.CurrencyCode == "" {
-				tx.CurrencyCode = "USD"
-			} else {
-				tx.CurrencyCode = itx.CurrencyCode
-			}
-			tx.NormalizedAmount = db.GetNormalizedAmount(tx.CurrencyCode, baseCurrency, tx.Date, tx.Amount)
-
-			if itx.Category == "" {
-				countInt.countUncat++
-				tx.Category = 106
-				tx.CategoryName = "Uncategorized"
-			} else {
-				if v, ok := types.MintCatMap[itx.Category]; ok {
-					tx.Category = v
-					var st string
-					query := fmt.Sprintf(`Select sub_category FROM categories WHERE id = %d`, tx.Category)
-					// log.Println(query)
-					err = db.DBCon.Get(&st, query)
-					if err != nil {
-						panic(err)
-					}
-					tx.CategoryName = st
-				} else {
-					sCat := types.Category{}
-					query := fmt.Sprintf(`SELECT * FROM categories WHERE sub_category = %q`, itx.Category)
-					err = db.DBCon.Get(&sCat, query)
-					if err != nil && err != sql.ErrNoRows {
-						panic(err)
-					}
-					if (types.Category{}) == sCat {
-						v := false
-						for _, rCat := range p.Catres {
-							if rCat.Category == itx.Category {
-								tx.Category = rCat.AssignedCat
-								tx.CategoryName = rCat.AssignedCatName
-								v = true
-								break
-							}
-						}
-						if !v {
-							countInt.countUncat++
-							tx.Category = 106
-							tx.CategoryName = "Uncategorized"
+				sCat := lookupCategory(itx.Category)
+				if (types.Category{}) == sCat {
+					v := false
+					for _, rCat := range p.Catres {
+						if rCat.Category == itx.Category {
+							tx.Category = rCat.AssignedCat
+							tx.CategoryName = rCat.AssignedCatName
+							v = true
+							break
 						}
-					} else {
-						tx.Category = sCat.ID
-						tx.CategoryName = sCat.SubCategory
 					}
+					if !v {
+						countInt.countUncat++
+						tx.Category = 106
+						tx.CategoryName = "Uncategorized"
+					}
+				} else {
+					tx.Category = sCat.ID
+					tx.CategoryName = sCat.SubCategory
 				}
 			}
+		}
 
-			var newTID string
-			for {
-				v := true
-				newTID = strconv.FormatInt(rand.Int63(), 10)
-				possibleMatches := []types.Transaction{}
-				query := fmt.Sprintf(`SELECT * FROM transactions WHERE transaction_id = %q`, newTID)
-				err = db.DBCon.Select(&possibleMatches, query)
-				if err != nil && err != sql.ErrNoRows {
-					panic(err)
-				}
-				if len(possibleMatches) > 0 {
-					v = false
-				}
-				if v {
+		v := false
+		if itx.AccountID != "" {
+			// Journals exported from fin name the account by its ID
+			for _, acc := range writableAccs {
+				if acc.AccountID == itx.AccountID {
+					tx.AccountID = acc.AccountID
+					tx.AccountName = acc.Name
+					v = true
 					break
 				}
 			}
-			tx.TransactionID = newTID
-
-			v := false
+		}
+		if !v {
 			for _, acc := range cAccs.accounts {
 				if acc.Name == itx.AccountName {
 					tx.AccountID = acc.AccountID
@@ -367,78 +508,328 @@ func ImportFunction() func(http.ResponseWriter, *http.Request) {
 					break
 				}
 			}
+		}
+		if !v {
+			v := false
+			for _, acc := range p.IdentifiedAccounts {
+				if acc.ImportKey == itx.AccountName {
+					tx.AccountID = acc.RefAccountID
+					tx.AccountName = acc.RefAccountName
+					v = true
+					break
+				}
+			}
 			if !v {
-				v := false
-				for _, acc := range p.IdentifiedAccounts {
-					if acc.ImportKey == itx.AccountName {
-						tx.AccountID = acc.RefAccountID
-						tx.AccountName = acc.RefAccountName
+				// Reuse an account created by an earlier import with the same name
+				for _, acc := range writableAccs {
+					if acc.Provider == "Import" && acc.Name == itx.AccountName {
+						tx.AccountID = acc.AccountID
+						tx.AccountName = acc.Name
 						v = true
 						break
 					}
 				}
-				if !v {
-					var newID string
-					for {
-						newID = strconv.FormatInt(rand.Int63(), 10)
-						v := true
-						for _, acc := range dbAccs {
-							if acc.AccountID == newID {
-								v = false
-								break
-							}
-						}
-						for _, acc := range cAccs.accounts {
-							if acc.AccountID == newID {
-								v = false
-								break
-							}
+			}
+			if !v {
+				var newID string
+				for {
+					newID = strconv.FormatInt(rand.Int63(), 10)
+					v := true
+					for _, acc := range dbAccs {
+						if acc.AccountID == newID {
+							v = false
+							break
 						}
-						if v {
+					}
+					for _, acc := range cAccs.accounts {
+						if acc.AccountID == newID {
+							v = false
 							break
 						}
 					}
-					accountToCreate := types.Account{}
-					accountToCreate.AccountID = newID
-					tx.AccountID = newID
-					tx.AccountName = itx.AccountName
-					accountToCreate.Name = itx.AccountName
-					accountToCreate.Institution = "Import"
-					accountToCreate.Provider = "Import"
-					cAccs.accounts = append(cAccs.accounts, accountToCreate)
+					if v {
+						break
+					}
 				}
+				accountToCreate := types.Account{}
+				accountToCreate.AccountID = newID
+				tx.AccountID = newID
+				tx.AccountName = itx.AccountName
+				accountToCreate.Name = itx.AccountName
+				accountToCreate.Institution = "Import"
+				accountToCreate.Provider = "Import"
+				accountToCreate.UserID = id.User.ID
+				cAccs.accounts = append(cAccs.accounts, accountToCreate)
 			}
-			possibleMatches := []types.Transaction{}
-			query := fmt.Sprintf(`SELECT * FROM transactions WHERE amount = %q AND date = %q AND account_id = %q `, tx.Amount, tx.Date, tx.AccountID)
-			err = db.DBCon.Select(&possibleMatches, query)
-			if err != nil {
-				panic(err)
-			}
-			if len(possibleMatches) < 1 {
-				tstmt.MustExec(tx)
-				countInt.countImp++
-			} else {
-				countInt.countDup++
+		}
+		// Bank IDs where the file has them, otherwise an ID derived from the
+		// content so the same row always gets the same ID
+		tx.TransactionID = itx.TransactionID
+		if tx.TransactionID == "" {
+			tx.TransactionID = externalTransactionID(itx)
+		}
+		if tx.TransactionID == "" {
+			key := db.ImportContentKey(tx.AccountID, string(tx.Date), tx.Amount, tx.Description)
+			tx.TransactionID = db.ImportTransactionID(key, occurrences[key])
+			occurrences[key]++
+		}
+
+		possibleMatches := []types.Transaction{}
+		err = db.DBCon.Select(&possibleMatches, `SELECT * FROM transactions WHERE transaction_id = $1`, tx.TransactionID)
+		if err != nil {
+			panic(err)
+		}
+		if len(possibleMatches) > 0 {
+			// Imported before, the upsert refreshes the row in place
+			tstmt.MustExec(tx)
+			if itx.TransactionID != "" && tx.Category != 106 {
+				// Recategorized in a journal exported from fin
+				txn.MustExec(`UPDATE transactions SET category = $1, category_name = $2 WHERE transaction_id = $3`,
+					tx.Category, tx.CategoryName, tx.TransactionID)
 			}
+			countInt.countDup++
+			preview(i, "update", tx)
+			continue
 		}
 
-		for _, acc := range cAccs.accounts {
-			astmt.MustExec(acc)
+		// Rows fetched through Plaid or SaltEdge have the providers' IDs
+		err = db.DBCon.Select(&possibleMatches, `SELECT * FROM transactions WHERE amount = $1 AND date = $2 AND account_id = $3`, tx.Amount, tx.Date, tx.AccountID)
+		if err != nil {
+			panic(err)
 		}
+		if len(possibleMatches) < 1 {
+			tstmt.MustExec(tx)
+			txn.MustExec(`INSERT INTO import_batch_transactions (batch_id, transaction_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, batchID, tx.TransactionID)
+			countInt.countImp++
+			preview(i, "import", tx)
+		} else {
+			countInt.countDup++
+			preview(i, "duplicate", tx)
+		}
+	}
+
+	for _, acc := range cAccs.accounts {
+		astmt.MustExec(acc)
+		txn.MustExec(`INSERT INTO import_batch_accounts (batch_id, account_id) VALUES ($1, $2)`, batchID, acc.AccountID)
+		result.CreatedAccounts = append(result.CreatedAccounts, acc.Name)
+	}
 
-		log.Println("duplicate number in import = " + strconv.Itoa(countInt.countDup))
-		log.Println("uncategorized number in import = " + strconv.Itoa(countInt.countUncat))
-		log.Println("total transactions imported = " + strconv.Itoa(countInt.countImp))
+	txn.MustExec(`UPDATE import_batches SET imported = $1, duplicates = $2, uncategorized = $3, created_accounts = $4 WHERE id = $5`,
+		countInt.countImp, countInt.countDup, countInt.countUncat, len(cAccs.accounts), batchID)
 
-		errC := txn.Commit()
-		if errC != nil {
-			panic(errC)
+	log.Println("duplicate number in import = " + strconv.Itoa(countInt.countDup))
+	log.Println("uncategorized number in import = " + strconv.Itoa(countInt.countUncat))
+	log.Println("total transactions imported = " + strconv.Itoa(countInt.countImp))
+
+	result.Imported = countInt.countImp
+	result.Duplicates = countInt.countDup
+	result.Uncategorized = countInt.countUncat
+
+	if p.DryRun {
+		if err := txn.Rollback(); err != nil {
+			panic(err)
 		}
+		return result, nil
+	}
 
-		analysisTrees.ReAnalyze()
+	errC := txn.Commit()
+	if errC != nil {
+		panic(errC)
+	}
+	result.BatchID = batchID
+
+	duplicates.Scan(started, cfg.Duplicates)
+
+	return result, nil
+}
+
+// writeRowErrors answers an import with rows that could not be read, listing
+// all of them so they can be fixed in one go
+func writeRowErrors(res http.ResponseWriter, rowErrs importers.RowErrors) {
+	log.Println("Error with Import: " + rowErrs.Error())
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusUnprocessableEntity)
+	if err := json.NewEncoder(res).Encode(map[string][]types.ImportRowError{"errors": rowErrs}); err != nil {
+		panic(err)
+	}
+}
+
+// lookupCategory matches an imported category name against the app categories.
+// Besides plain sub category names it accepts QIF style "Top:Sub" paths, which
+// match on both levels first and then on the sub category alone.
+func lookupCategory(name string) types.Category {
+	sCat := types.Category{}
+	err := db.DBCon.Get(&sCat, `SELECT * FROM categories WHERE sub_category = $1`, name)
+	if err != nil && err != sql.ErrNoRows {
+		panic(err)
+	}
+	if (types.Category{}) != sCat || !strings.Contains(name, ":") {
+		return sCat
+	}
+
+	parts := strings.Split(name, ":")
+	top := strings.TrimSpace(parts[0])
+	sub := strings.TrimSpace(parts[len(parts)-1])
+	err = db.DBCon.Get(&sCat, `SELECT * FROM categories WHERE top_category = $1 AND sub_category = $2`, top, sub)
+	if err != nil && err != sql.ErrNoRows {
+		panic(err)
+	}
+	if (types.Category{}) != sCat {
+		return sCat
+	}
+
+	err = db.DBCon.Get(&sCat, `SELECT * FROM categories WHERE sub_category = $1`, sub)
+	if err != nil && err != sql.ErrNoRows {
+		panic(err)
+	}
+	return sCat
+}
+
+// externalTransactionID builds a stable transaction ID from a bank-provided ID
+// (e.g. OFX FITID), namespaced by the file's account key since those IDs are
+// only unique per account
+func externalTransactionID(itx types.ImportTransaction) string {
+	if itx.ExternalID == "" {
+		return ""
+	}
+	return "import-" + itx.AccountName + "-" + itx.ExternalID
+}
+
+// parseUpload reads the multipart "file" field and parses it with ParseFile,
+// using the import profile named in the "profile" field and, for CSV files,
+// the "locale", "dateOrder", "dateFormat" and "decimalSeparator" fields
+func parseUpload(req *http.Request) ([]types.ImportTransaction, string, string, int, error) {
+	file, header, err := req.FormFile("file")
+	if err != nil {
+		return nil, "", "", http.StatusBadRequest, fmt.Errorf("Error with File Upload: %v", err)
+	}
+	defer file.Close()
+
+	raw, err := ioutil.ReadAll(file)
+	if err != nil {
+		panic(err)
+	}
+
+	opts := types.ImportOptions{
+		Locale:           req.FormValue("locale"),
+		DateOrder:        req.FormValue("dateOrder"),
+		DateFormat:       req.FormValue("dateFormat"),
+		DecimalSeparator: req.FormValue("decimalSeparator"),
+	}
+	txs, profile, status, err := ParseFile(header.Filename, raw, req.FormValue("profile"), opts)
+	return txs, header.Filename, profile, status, err
+}
+
+// ParseFile reads the transactions in a file, returning the import profile
+// used and, on error, the HTTP status that fits it. CSV files are read with
+// the named import profile, or the best matching profile by header, and opts;
+// rows they can't read come back as importers.RowErrors. Everything else goes
+// through the bank statement parsers.
+func ParseFile(filename string, raw []byte, profileName string, opts types.ImportOptions) ([]types.ImportTransaction, string, int, error) {
+	if profileName == "" && !strings.EqualFold(filepath.Ext(filename), ".csv") {
+		txs, err := importers.Parse(filename, raw)
+		if err != nil {
+			return nil, "", http.StatusUnprocessableEntity, fmt.Errorf("Error with Parse File %s: %v", filename, err)
+		}
+		return txs, "", http.StatusOK, nil
+	}
+
+	var profile types.ImportProfile
+	if profileName != "" {
+		var ok bool
+		profile, ok = importProfiles.Select(profileName)
+		if !ok {
+			return nil, "", http.StatusBadRequest, fmt.Errorf("Error with Import Profile: %q does not exist", profileName)
+		}
+	} else {
+		var ok bool
+		profile, ok = importers.DetectProfile(raw, importProfiles.SelectAll())
+		if !ok {
+			return nil, "", http.StatusUnprocessableEntity, fmt.Errorf("Error with Parse File %s: no import profile matches the CSV header", filename)
+		}
+	}
+
+	txs, _, err := importers.ParseCSV(raw, profile, opts)
+	if rowErrs, ok := err.(importers.RowErrors); ok {
+		return nil, profile.Name, http.StatusUnprocessableEntity, rowErrs
+	}
+	if err != nil {
+		return nil, profile.Name, http.StatusUnprocessableEntity, fmt.Errorf("Error with Parse File %s (profile %s): %v", filename, profile.Name, err)
+	}
+	return txs, profile.Name, http.StatusOK, nil
+}
+
+// ParseFileFunction parses an uploaded bank statement (OFX/QFX, camt.053,
+// MT940 or QIF) or a CSV export, and returns the transactions for the regular
+// check and import steps
+func ParseFileFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		txs, _, _, status, err := parseUpload(req)
+		if rowErrs, ok := err.(importers.RowErrors); ok {
+			writeRowErrors(res, rowErrs)
+			return
+		}
+		if err != nil {
+			errString := fmt.Sprintf("%v \n", err)
+			log.Println(errString)
+			res.WriteHeader(status)
+			res.Write([]byte(errString))
+			return
+		}
 
 		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(txs); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ImportFileFunction parses an uploaded file like ParseFileFunction and imports
+// it in one step, without the interactive category and account matching
+func ImportFileFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
 
+		txs, filename, profile, status, err := parseUpload(req)
+		if rowErrs, ok := err.(importers.RowErrors); ok {
+			writeRowErrors(res, rowErrs)
+			return
+		}
+		if err != nil {
+			errString := fmt.Sprintf("%v \n", err)
+			log.Println(errString)
+			res.WriteHeader(status)
+			res.Write([]byte(errString))
+			return
+		}
+
+		id, _ := auth.FromContext(req.Context())
+		result, err := Import(types.ImportPostData{
+			TxSet:    txs,
+			Filename: filename,
+			Profile:  profile,
+			DryRun:   req.FormValue("dryRun") == "true",
+		}, cfg, id)
+		if rowErrs, ok := err.(importers.RowErrors); ok {
+			writeRowErrors(res, rowErrs)
+			return
+		}
+		if err != nil {
+			errString := fmt.Sprintf("%v \n", err)
+			log.Println(errString)
+			res.WriteHeader(importStatus(err))
+			res.Write([]byte(errString))
+			return
+		}
+
+		if !result.DryRun {
+			analysisTrees.ReAnalyze()
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(result); err != nil {
+			panic(err)
+		}
 	}
 }
 
@@ -452,6 +843,23 @@ func UpsertFunction() func(http.ResponseWriter, *http.Request) {
 			panic(err)
 		}
 
+		// Empty keeps a stored transaction's status, and posts a new one
+		for _, tx := range p {
+			if tx.Status != "" && tx.Status != "pending" && tx.Status != "posted" {
+				errString := fmt.Sprintf("Error with Transaction Upsert: status of %s is %q, not pending or posted \n", tx.TransactionID, tx.Status)
+				log.Println(errString)
+				res.WriteHeader(http.StatusBadRequest)
+				res.Write([]byte(errString))
+				return
+			}
+		}
+
+		id, _ := auth.FromContext(req.Context())
+		if !canWrite(id, p...) {
+			writeForbidden(res, "Transaction Upsert")
+			return
+		}
+
 		txn := db.DBCon.MustBegin()
 		tstmt := types.PrepTransUpsertSt(txn)
 
@@ -0,0 +1,19 @@
+package transactions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpsertRejectsUnknownStatus(t *testing.T) {
+	for _, status := range []string{"cleared", "Posted", "void"} {
+		body := `[{"transaction_id": "t1", "account_id": "acc", "date": "2021-01-02", "amount": -3.5, "status": "` + status + `"}]`
+		res := httptest.NewRecorder()
+		UpsertFunction()(res, httptest.NewRequest("POST", "/api/transactionUpsert", strings.NewReader(body)))
+		if res.Code != http.StatusBadRequest {
+			t.Errorf("status %q: answered %d, want %d", status, res.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"fin-go/auth"
+	"fin-go/config"
+	"fin-go/types"
+
+	"github.com/gorilla/mux"
+)
+
+// writeError reports a user or session error with the status matching it
+func writeError(res http.ResponseWriter, context string, err error) {
+	errString := fmt.Sprintf("Error with %s: %v \n", context, err)
+	log.Println(errString)
+	switch {
+	case errors.Is(err, auth.ErrBadLogin):
+		res.WriteHeader(http.StatusUnauthorized)
+	case errors.Is(err, auth.ErrNotFound):
+		res.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, auth.ErrUserExists), errors.Is(err, auth.ErrLastOwner):
+		res.WriteHeader(http.StatusConflict)
+	case errors.Is(err, auth.ErrShortPassword), errors.Is(err, auth.ErrBadRole), errors.Is(err, errBadRequest):
+		res.WriteHeader(http.StatusBadRequest)
+	default:
+		res.WriteHeader(http.StatusInternalServerError)
+	}
+	res.Write([]byte(errString))
+}
+
+var errBadRequest = errors.New("bad request")
+
+// SessionGetFunction tells the frontend the auth mode and who is logged in
+func SessionGetFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		info := types.SessionInfo{Mode: cfg.Auth.Mode, Scopes: []string{}}
+		if id, ok := auth.FromContext(req.Context()); ok {
+			info.Scopes = id.Scopes
+			if id.User.ID != 0 {
+				info.User = &id.User
+			}
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(info); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func LoginFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		if cfg.Auth.Mode != "local" {
+			writeError(res, "Login", fmt.Errorf("%w: logins are only handled by fin in auth mode local, not %s", errBadRequest, cfg.Auth.Mode))
+			return
+		}
+		login := types.LoginPost{}
+		if err := json.NewDecoder(req.Body).Decode(&login); err != nil {
+			writeError(res, "Login Decode", fmt.Errorf("%w: %v", errBadRequest, err))
+			return
+		}
+
+		token, session, err := auth.Login(cfg.Auth, login.Username, login.Password)
+		if err != nil {
+			writeError(res, "Login", err)
+			return
+		}
+		auth.SetSessionCookie(cfg.Auth, res, token, session.ExpiresAt)
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
+
+func LogoutFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		if cookie, err := req.Cookie(auth.SessionCookie); err == nil {
+			if err := auth.Logout(cookie.Value); err != nil {
+				writeError(res, "Logout", err)
+				return
+			}
+		}
+		auth.ClearSessionCookie(cfg.Auth, res)
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
+
+func GetFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		users, err := auth.SelectUsers()
+		if err != nil {
+			panic(err)
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(users); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func CreateFunction(cfg *config.Config) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		post := types.UserPost{}
+		if err := json.NewDecoder(req.Body).Decode(&post); err != nil {
+			writeError(res, "User Decode", fmt.Errorf("%w: %v", errBadRequest, err))
+			return
+		}
+
+		user, err := auth.CreateUser(cfg.Auth, post.Username, post.Password, post.Role)
+		if err != nil {
+			writeError(res, "User", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(user); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func DeleteFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, err := strconv.Atoi(mux.Vars(req)["id"])
+		if err != nil {
+			writeError(res, "User Delete", fmt.Errorf("%w: %v", errBadRequest, err))
+			return
+		}
+		if err := auth.DeleteUser(id); err != nil {
+			writeError(res, "User Delete", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
+
+// RoleFunction changes a user's role, which takes effect on their next request
+func RoleFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, err := strconv.Atoi(mux.Vars(req)["id"])
+		if err != nil {
+			writeError(res, "User Role", fmt.Errorf("%w: %v", errBadRequest, err))
+			return
+		}
+		post := types.RolePost{}
+		if err := json.NewDecoder(req.Body).Decode(&post); err != nil {
+			writeError(res, "User Role Decode", fmt.Errorf("%w: %v", errBadRequest, err))
+			return
+		}
+		if err := auth.SetRole(id, post.Role); err != nil {
+			writeError(res, "User Role", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
+
+// ConfirmationFunction hands out a confirmation token for one destructive
+// action, see auth.Confirmed
+func ConfirmationFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		post := types.ConfirmationPost{}
+		if err := json.NewDecoder(req.Body).Decode(&post); err != nil {
+			writeError(res, "Confirmation Decode", fmt.Errorf("%w: %v", errBadRequest, err))
+			return
+		}
+		id, _ := auth.FromContext(req.Context())
+		token, expires, err := auth.Confirm(id, post.Action)
+		if err != nil {
+			writeError(res, "Confirmation", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(res).Encode(types.Confirmation{Action: post.Action, Token: token, ExpiresAt: expires}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// PasswordFunction changes the password of whoever is logged in
+func PasswordFunction() func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		id, _ := auth.FromContext(req.Context())
+		if id.User.ID == 0 {
+			writeError(res, "Password", fmt.Errorf("%w: not logged in as a user", errBadRequest))
+			return
+		}
+		if id.Token != nil {
+			writeError(res, "Password", fmt.Errorf("%w: passwords can't be changed with an API token", errBadRequest))
+			return
+		}
+		post := types.PasswordPost{}
+		if err := json.NewDecoder(req.Body).Decode(&post); err != nil {
+			writeError(res, "Password Decode", fmt.Errorf("%w: %v", errBadRequest, err))
+			return
+		}
+
+		if err := auth.ChangePassword(id.User, post.Current, post.New); err != nil {
+			writeError(res, "Password", err)
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,432 @@
+// Package secrets keeps provider access tokens encrypted at rest with
+// envelope encryption: every token is sealed with its own random data key,
+// and that data key is wrapped with the key from TOKEN_KEY or the key file.
+// Rotating the key only rewraps the data keys. Tokens are only opened by the
+// provider packages, right before they are sent to the provider.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"fin-go/config"
+)
+
+// sealedPrefix starts every sealed token, followed by the ID of the key that
+// wrapped its data key, the wrapped data key and the sealed token, each
+// base64 and separated by colons
+const sealedPrefix = "fin-sealed:v1:"
+
+const keySize = 32
+
+var (
+	ErrNoKey      = errors.New("no key for access tokens, see TOKEN_KEY and TOKEN_KEY_FILE")
+	ErrUnknownKey = errors.New("access token was sealed with a key fin doesn't have")
+	ErrDamaged    = errors.New("sealed access token is damaged")
+)
+
+// keyring holds the current key and the ones it replaced, which are only
+// used to open tokens from before a rotation, such as in an older backup
+type keyring struct {
+	mu        sync.RWMutex
+	cfg       config.Secrets
+	current   []byte
+	currentID string
+	previous  map[string][]byte
+}
+
+var ring = &keyring{previous: map[string][]byte{}}
+
+// keyID names a key without giving it away
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
+
+func decodeKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("key is not base64: %v", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key is %d bytes, not %d", len(key), keySize)
+	}
+	return key, nil
+}
+
+// GenerateKey returns a new random key, base64 encoded as TOKEN_KEY and the
+// key file hold it
+func GenerateKey() (string, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func readKeyFile(path string) ([]byte, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := decodeKey(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return key, nil
+}
+
+// writeKeyFile writes a key only the server's user can read
+func writeKeyFile(path, key string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(key+"\n"), 0600)
+}
+
+// retiredKeyPath is where a rotated key is kept next to the key file
+func retiredKeyPath(keyFile, id string) string {
+	return keyFile + "." + id + ".old"
+}
+
+// Init loads the key: TOKEN_KEY if set, else the key file, which is made the
+// first time. Keys retired by Rotate next to the key file are loaded too.
+func Init(cfg config.Secrets) error {
+	ring.mu.Lock()
+	defer ring.mu.Unlock()
+
+	var key []byte
+	var err error
+	switch {
+	case cfg.Key != "":
+		key, err = decodeKey(cfg.Key)
+	case cfg.KeyFile != "":
+		key, err = readKeyFile(cfg.KeyFile)
+		if os.IsNotExist(err) {
+			var generated string
+			if generated, err = GenerateKey(); err == nil {
+				if err = writeKeyFile(cfg.KeyFile, generated); err == nil {
+					log.Println("Made a new key for access tokens in", cfg.KeyFile, "- keep it safe, backups can't be read without it")
+					key, err = decodeKey(generated)
+				}
+			}
+		}
+	default:
+		err = ErrNoKey
+	}
+	if err != nil {
+		return fmt.Errorf("loading the access token key: %v", err)
+	}
+
+	ring.cfg = cfg
+	ring.current = key
+	ring.currentID = keyID(key)
+	ring.previous = map[string][]byte{}
+	if cfg.KeyFile != "" {
+		ring.previous = readRetiredKeys(cfg.KeyFile)
+	}
+	return nil
+}
+
+// readRetiredKeys loads the keys Rotate left next to the key file
+func readRetiredKeys(keyFile string) map[string][]byte {
+	previous := map[string][]byte{}
+	retired, _ := filepath.Glob(retiredKeyPath(keyFile, "*"))
+	for _, path := range retired {
+		old, err := readKeyFile(path)
+		if err != nil {
+			log.Println("Skipping retired access token key:", err)
+			continue
+		}
+		previous[keyID(old)] = old
+	}
+	return previous
+}
+
+// reload reads the key file again, for when `keys rotate` replaced it while
+// the server was running. The key it held so far stays in the ring. It
+// reports whether there was a key file to read.
+func reload() bool {
+	ring.mu.RLock()
+	cfg := ring.cfg
+	ring.mu.RUnlock()
+	if cfg.Key != "" || cfg.KeyFile == "" {
+		return false
+	}
+	key, err := readKeyFile(cfg.KeyFile)
+	if err != nil {
+		log.Println("Reloading the access token key:", err)
+		return false
+	}
+	previous := readRetiredKeys(cfg.KeyFile)
+
+	ring.mu.Lock()
+	defer ring.mu.Unlock()
+	if ring.current != nil && ring.currentID != keyID(key) {
+		previous[ring.currentID] = ring.current
+		log.Println("Access token key was rotated, using the new key")
+	}
+	ring.current = key
+	ring.currentID = keyID(key)
+	ring.previous = previous
+	return true
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns nonce and ciphertext of plain under key
+func encrypt(key, plain, additional []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plain, additional), nil
+}
+
+func decrypt(key, sealed, additional []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrDamaged
+	}
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], additional)
+	if err != nil {
+		return nil, ErrDamaged
+	}
+	return plain, nil
+}
+
+// envelope is a sealed token taken apart
+type envelope struct {
+	keyID      string
+	wrappedKey []byte
+	data       []byte
+}
+
+func (e envelope) String() string {
+	enc := base64.RawURLEncoding.EncodeToString
+	return sealedPrefix + e.keyID + ":" + enc(e.wrappedKey) + ":" + enc(e.data)
+}
+
+// wrapData ties a wrapped data key to the key that wrapped it
+func (e envelope) wrapData() []byte {
+	return []byte(sealedPrefix + e.keyID)
+}
+
+func parse(sealed string) (envelope, error) {
+	parts := strings.Split(strings.TrimPrefix(sealed, sealedPrefix), ":")
+	if len(parts) != 3 {
+		return envelope{}, ErrDamaged
+	}
+	wrapped, err1 := base64.RawURLEncoding.DecodeString(parts[1])
+	data, err2 := base64.RawURLEncoding.DecodeString(parts[2])
+	if err1 != nil || err2 != nil {
+		return envelope{}, ErrDamaged
+	}
+	return envelope{keyID: parts[0], wrappedKey: wrapped, data: data}, nil
+}
+
+// IsSealed reports whether s is a sealed token rather than a plain one
+func IsSealed(s string) bool {
+	return strings.HasPrefix(s, sealedPrefix)
+}
+
+// Seal encrypts an access token. Empty and already sealed tokens are
+// returned as they are, so an item read back from the DB can be written
+// again.
+func Seal(plain string) (string, error) {
+	if plain == "" || IsSealed(plain) {
+		return plain, nil
+	}
+	ring.mu.RLock()
+	key, id := ring.current, ring.currentID
+	ring.mu.RUnlock()
+	if key == nil {
+		return "", ErrNoKey
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	e := envelope{keyID: id}
+	var err error
+	if e.wrappedKey, err = encrypt(key, dataKey, e.wrapData()); err != nil {
+		return "", err
+	}
+	if e.data, err = encrypt(dataKey, []byte(plain), nil); err != nil {
+		return "", err
+	}
+	return e.String(), nil
+}
+
+// keyFor returns the key with an ID, current or retired. A key fin doesn't
+// know yet may be in a key file that was rotated since, so that is read again.
+func keyFor(id string) ([]byte, bool) {
+	if key, ok := ringKey(id); ok {
+		return key, true
+	}
+	if !reload() {
+		return nil, false
+	}
+	return ringKey(id)
+}
+
+func ringKey(id string) ([]byte, bool) {
+	ring.mu.RLock()
+	defer ring.mu.RUnlock()
+	if id == ring.currentID && ring.current != nil {
+		return ring.current, true
+	}
+	key, ok := ring.previous[id]
+	return key, ok
+}
+
+// unwrap returns the data key of a sealed token
+func unwrap(e envelope) ([]byte, error) {
+	key, ok := keyFor(e.keyID)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return decrypt(key, e.wrappedKey, e.wrapData())
+}
+
+// Open decrypts a sealed access token. Only the provider packages should
+// call it, right before using the token.
+func Open(sealed string) (string, error) {
+	if !IsSealed(sealed) {
+		return sealed, nil
+	}
+	e, err := parse(sealed)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := unwrap(e)
+	if err != nil {
+		return "", err
+	}
+	plain, err := decrypt(dataKey, e.data, nil)
+	return string(plain), err
+}
+
+// Readable reports whether a sealed token was sealed under a key fin has,
+// without opening it
+func Readable(sealed string) bool {
+	if !IsSealed(sealed) {
+		return true
+	}
+	e, err := parse(sealed)
+	if err != nil {
+		return false
+	}
+	_, ok := keyFor(e.keyID)
+	return ok
+}
+
+// rewrap wraps a sealed token's data key with another key, leaving the
+// token itself as it was
+func rewrap(sealed string, key []byte) (string, error) {
+	e, err := parse(sealed)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := unwrap(e)
+	if err != nil {
+		return "", err
+	}
+	e.keyID = keyID(key)
+	if e.wrappedKey, err = encrypt(key, dataKey, e.wrapData()); err != nil {
+		return "", err
+	}
+	return e.String(), nil
+}
+
+// Rotate makes a new key and has reseal rewrap every stored token with it,
+// in one transaction, through the function it is handed. With a key file,
+// the new key is written next to it first and replaces it once reseal is
+// done, the old key staying beside it for older backups; a running server
+// picks the new key up when it meets a token sealed with it. A key from
+// TOKEN_KEY can't be replaced here, so the new key is returned to be set
+// there before the next start, and nothing keeps the old one.
+func Rotate(reseal func(rewrap func(string) (string, error)) error) (string, error) {
+	ring.mu.RLock()
+	cfg, oldKey, oldID := ring.cfg, ring.current, ring.currentID
+	ring.mu.RUnlock()
+	if oldKey == nil {
+		return "", ErrNoKey
+	}
+
+	newKey, err := GenerateKey()
+	if err != nil {
+		return "", err
+	}
+	key, _ := decodeKey(newKey)
+
+	fromFile := cfg.Key == ""
+	var pending string
+	if fromFile {
+		pending = cfg.KeyFile + ".new"
+		if err := writeKeyFile(pending, newKey); err != nil {
+			return "", err
+		}
+	}
+
+	err = reseal(func(sealed string) (string, error) {
+		if sealed == "" {
+			return sealed, nil
+		}
+		if !IsSealed(sealed) {
+			var err error
+			if sealed, err = Seal(sealed); err != nil {
+				return "", err
+			}
+		}
+		return rewrap(sealed, key)
+	})
+	if err != nil {
+		if fromFile {
+			os.Remove(pending)
+		}
+		return "", err
+	}
+
+	if fromFile {
+		if err := writeKeyFile(retiredKeyPath(cfg.KeyFile, oldID), base64.StdEncoding.EncodeToString(oldKey)); err != nil {
+			return newKey, fmt.Errorf("tokens use the key in %s now, but keeping the old key failed: %v", pending, err)
+		}
+		if err := os.Rename(pending, cfg.KeyFile); err != nil {
+			return newKey, fmt.Errorf("tokens use the key in %s now, move it to %s: %v", pending, cfg.KeyFile, err)
+		}
+	}
+
+	ring.mu.Lock()
+	ring.previous[oldID] = oldKey
+	ring.current = key
+	ring.currentID = keyID(key)
+	ring.mu.Unlock()
+	return newKey, nil
+}
@@ -0,0 +1,106 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fin-go/config"
+)
+
+// rotateElsewhere rotates the key the way `keys rotate` does from another
+// process, leaving the keyring as the running server had it
+func rotateElsewhere(t *testing.T, stored *string) {
+	t.Helper()
+	ring.mu.RLock()
+	cfg, current, currentID := ring.cfg, ring.current, ring.currentID
+	previous := map[string][]byte{}
+	for id, key := range ring.previous {
+		previous[id] = key
+	}
+	ring.mu.RUnlock()
+
+	_, err := Rotate(func(rewrap func(string) (string, error)) error {
+		var err error
+		*stored, err = rewrap(*stored)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ring.mu.Lock()
+	ring.cfg, ring.current, ring.currentID, ring.previous = cfg, current, currentID, previous
+	ring.mu.Unlock()
+}
+
+func TestRotateKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "token.key")
+	if err := Init(config.Secrets{KeyFile: keyFile}); err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := Seal("access-sandbox-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	older := sealed
+
+	rotateElsewhere(t, &sealed)
+	if _, err := os.Stat(keyFile + ".new"); !os.IsNotExist(err) {
+		t.Errorf("pending key file left behind: %v", err)
+	}
+
+	// The running server hasn't seen the new key, and reads the key file again
+	for _, s := range []string{sealed, older} {
+		if plain, err := Open(s); err != nil || plain != "access-sandbox-1" {
+			t.Errorf("Open = %q, %v", plain, err)
+		}
+	}
+	resealed, err := Seal("access-sandbox-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, _ := parse(resealed); e.keyID != keyID(mustReadKeyFile(t, keyFile)) {
+		t.Errorf("sealed with key %s after the rotation, not the new one", e.keyID)
+	}
+
+	// A fresh start finds the retired key next to the key file
+	if err := Init(config.Secrets{KeyFile: keyFile}); err != nil {
+		t.Fatal(err)
+	}
+	if !Readable(older) {
+		t.Error("token sealed before the rotation is no longer readable")
+	}
+}
+
+func TestUnknownKey(t *testing.T) {
+	dir := t.TempDir()
+	if err := Init(config.Secrets{KeyFile: filepath.Join(dir, "a.key")}); err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := Seal("access-sandbox-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cfg := range []config.Secrets{{KeyFile: filepath.Join(dir, "b.key")}, {Key: key}} {
+		if err := Init(cfg); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Open(sealed); err != ErrUnknownKey {
+			t.Errorf("Open with another key = %v, want %v", err, ErrUnknownKey)
+		}
+	}
+}
+
+func mustReadKeyFile(t *testing.T, path string) []byte {
+	t.Helper()
+	key, err := readKeyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
@@ -1,8 +1,14 @@
 package types
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
+	"fin-go/secrets"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 )
@@ -22,6 +28,8 @@ type Account struct {
 	Currency           string          `json:"currency" db:"currency"`
 	Provider           string          `json:"provider" db:"provider"`
 	RunningTotal       decimal.Decimal `json:"running_total" db:"running_total"`
+	UserID             int             `json:"user_id" db:"user_id"`
+	Sharing            string          `json:"sharing" db:"sharing"`
 	CreatedAt          time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt          time.Time       `json:"updated_at" db:"updated_at"`
 }
@@ -37,6 +45,8 @@ type ItemToken struct {
 	LastRefresh                time.Time `json:"last_refresh" db:"last_refresh"`
 	NextRefreshPossible        time.Time `json:"next_refresh_possible" db:"next_refresh_possible"`
 	LastDownloadedTransactions time.Time `json:"last_downloaded_transactions" db:"last_downloaded_transactions"`
+	SyncCursor                 string    `json:"sync_cursor" db:"sync_cursor"`
+	UserID                     int       `json:"user_id" db:"user_id"`
 	CreatedAt                  time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt                  time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -59,6 +69,7 @@ type CategorySE struct {
 	BottomCategory string    `json:"bottom_category" db:"bottom_category"`
 	LinkToAppCat   int       `json:"link_to_app_cat" db:"link_to_app_cat"`
 	AppCatName     string    `json:"app_cat_name" db:"app_cat_name"`
+	SeedLink       int       `json:"-" db:"seed_link_to_app_cat"`
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -69,13 +80,14 @@ type CategoryPlaid struct {
 	CatID        string    `json:"cat_i_d" db:"cat_i_d"`
 	LinkToAppCat int       `json:"link_to_app_cat" db:"link_to_app_cat"`
 	AppCatName   string    `json:"app_cat_name" db:"app_cat_name"`
+	SeedLink     int       `json:"-" db:"seed_link_to_app_cat"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
 
 type Transaction struct {
 	ID                  int             `json:"id"`
-	Date                string          `json:"date" db:"date"`
+	Date                Day             `json:"date" db:"date"`
 	TransactionID       string          `json:"transaction_id" db:"transaction_id"`
 	Description         string          `json:"description" db:"description"`
 	OriginalDescription string          `json:"original_description" db:"original_description"`
@@ -89,10 +101,40 @@ type Transaction struct {
 	AccountID           string          `json:"account_id" db:"account_id"`
 	Labels              string          `json:"labels" db:"labels"`
 	Notes               string          `json:"notes" db:"notes"`
+	Status              string          `json:"status" db:"status"` // "pending" until the bank posts it, then "posted"
 	CreatedAt           time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt           time.Time       `json:"updated_at" db:"updated_at"`
 }
 
+// Day is a date written YYYY-MM-DD. Both stores hand DATE columns back as
+// times, which would otherwise be scanned as RFC 3339 timestamps.
+type Day string
+
+func (d *Day) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+	case time.Time:
+		*d = Day(v.Format("2006-01-02"))
+	case string:
+		*d = dayOf(v)
+	case []byte:
+		*d = dayOf(string(v))
+	default:
+		return fmt.Errorf("can't scan %T as a day", src)
+	}
+	return nil
+}
+
+// dayOf drops the time from a stored date, such as the midnight SQLite
+// files written by older versions carry
+func dayOf(s string) Day {
+	if len(s) > 10 {
+		s = s[:10]
+	}
+	return Day(s)
+}
+
 var TreeRanges = [8]string{
 	"last30",
 	"thisMonth",
@@ -105,13 +147,16 @@ var TreeRanges = [8]string{
 }
 
 type Tree struct {
-	Name         string    `json:"name" db:"name"`
-	FirstDate    string    `json:"first_date" db:"first_date"`
-	LastDate     string    `json:"last_date" db:"last_date"`
-	Data         string    `json:"data" db:"data"`
-	DataNoInvest string    `json:"data_no_invest" db:"data_no_invest"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	Scope              string    `json:"scope" db:"scope"`
+	Name               string    `json:"name" db:"name"`
+	FirstDate          string    `json:"first_date" db:"first_date"`
+	LastDate           string    `json:"last_date" db:"last_date"`
+	Data               string    `json:"data" db:"data"`
+	DataNoInvest       string    `json:"data_no_invest" db:"data_no_invest"`
+	DataPosted         string    `json:"data_posted" db:"data_posted"`
+	DataNoInvestPosted string    `json:"data_no_invest_posted" db:"data_no_invest_posted"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
 }
 
 type TreeData struct {
@@ -192,6 +237,7 @@ type CompareTransSingle struct {
 	Trans2  CompareTrans `json:"trans2"`
 	IsMatch bool         `json:"isMatch`
 	Type    string       `json:"type"`
+	Score   float64      `json:"score"`
 }
 
 type CompareCatsSingle struct {
@@ -211,12 +257,355 @@ type ImportTransaction struct {
 	Labels              string          `json:"labels"`
 	Notes               string          `json:"notes"`
 	CurrencyCode        string          `json:"currency_code"`
+	ExternalID          string          `json:"externalID"`
+	ValueDate           string          `json:"valueDate"`
+	Counterparty        string          `json:"counterparty"`
+	// TransactionID and AccountID are fin's own IDs, set for rows that were
+	// exported from fin and are being brought back
+	TransactionID string `json:"transactionID"`
+	AccountID     string `json:"accountID"`
+	// RawAmount is the amount as it was sent, parsed later with the import locale
+	RawAmount string `json:"-"`
+}
+
+// UnmarshalJSON accepts the amount either as a JSON number, which always has
+// a decimal point, or as a string in any locale ("1.234,56", "-12,50 €"),
+// kept in RawAmount to be read with the import locale
+func (itx *ImportTransaction) UnmarshalJSON(data []byte) error {
+	type plain ImportTransaction
+	var aux struct {
+		*plain
+		Amount json.RawMessage `json:"amount"`
+	}
+	aux.plain = (*plain)(itx)
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	itx.Amount = decimal.Zero
+	itx.RawAmount = ""
+	raw := strings.TrimSpace(string(aux.Amount))
+	if raw == "" || raw == "null" {
+		return nil
+	}
+	if raw[0] == '"' {
+		return json.Unmarshal(aux.Amount, &itx.RawAmount)
+	}
+	amt, err := decimal.NewFromString(raw)
+	if err != nil {
+		return fmt.Errorf("amount %s is neither a number nor a string", raw)
+	}
+	itx.Amount = amt
+	return nil
+}
+
+// ImportOptions control how dates and amounts sent as text are read. Locale is
+// a language tag such as "en-US" or "de-DE"; DateOrder ("mdy", "dmy" or "ymd")
+// overrides the locale's order of numeric dates; DateFormat is either a Go
+// layout ("02.01.2006") or a pattern like "DD/MM/YYYY" and overrides both.
+type ImportOptions struct {
+	Locale           string `json:"locale"`
+	DateOrder        string `json:"dateOrder"`
+	DateFormat       string `json:"dateFormat"`
+	DecimalSeparator string `json:"decimalSeparator"`
+}
+
+// ImportParseInfo reports how the dates and amounts of an import were read
+type ImportParseInfo struct {
+	DateOrder        string `json:"dateOrder"`
+	DateAmbiguous    bool   `json:"dateAmbiguous"`
+	DecimalSeparator string `json:"decimalSeparator"`
+}
+
+// ImportRowError describes a transaction that could not be read, Row being
+// its 1-based position in the submitted transactions
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+	Error string `json:"error"`
+}
+
+type CheckPostData struct {
+	Options ImportOptions       `json:"options"`
+	TxSet   []ImportTransaction `json:"transactions"`
 }
 
 type ImportPostData struct {
 	Catres             []CompareCatsSingle `json:"catres"`
 	IdentifiedAccounts []MatchingAccount   `json:"identifiedAccounts"`
 	TxSet              []ImportTransaction `json:"transactions"`
+	Options            ImportOptions       `json:"options"`
+	Filename           string              `json:"filename"`
+	Profile            string              `json:"profile"`
+	DryRun             bool                `json:"dryRun"`
+}
+
+type ImportResult struct {
+	BatchID         int64              `json:"batch_id"`
+	DryRun          bool               `json:"dry_run"`
+	Profile         string             `json:"profile"`
+	Imported        int                `json:"imported"`
+	Duplicates      int                `json:"duplicates"`
+	Uncategorized   int                `json:"uncategorized"`
+	CreatedAccounts []string           `json:"created_accounts"`
+	Rows            []ImportPreviewRow `json:"rows,omitempty"`
+}
+
+// ImportPreviewRow is what a dry run would do with one submitted transaction:
+// "import" it, "update" a row imported before, skip it as a "duplicate" of a
+// fetched transaction, or "skip" it for having no amount
+type ImportPreviewRow struct {
+	Row         int         `json:"row"`
+	Action      string      `json:"action"`
+	Transaction Transaction `json:"transaction"`
+}
+
+// DuplicatePair is a pair of transactions that look like the same payment,
+// stored for review. Status is "pending", "dismissed", "confirmed" or "merged".
+type DuplicatePair struct {
+	ID             int             `json:"id"`
+	TransactionID1 string          `json:"transaction_id_1" db:"transaction_id_1"`
+	TransactionID2 string          `json:"transaction_id_2" db:"transaction_id_2"`
+	Score          decimal.Decimal `json:"score" db:"score"`
+	Status         string          `json:"status" db:"status"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+type DuplicatePairDetail struct {
+	DuplicatePair
+	Transaction1 Transaction `json:"transaction_1"`
+	Transaction2 Transaction `json:"transaction_2"`
+}
+
+type ImportBatch struct {
+	ID              int       `json:"id"`
+	Filename        string    `json:"filename" db:"filename"`
+	Profile         string    `json:"profile" db:"profile"`
+	Imported        int       `json:"imported" db:"imported"`
+	Duplicates      int       `json:"duplicates" db:"duplicates"`
+	Uncategorized   int       `json:"uncategorized" db:"uncategorized"`
+	CreatedAccounts int       `json:"created_accounts" db:"created_accounts"`
+	Status          string    `json:"status" db:"status"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type ImportBatchDetail struct {
+	ImportBatch
+	Transactions []Transaction `json:"transactions"`
+	Accounts     []Account     `json:"accounts"`
+}
+
+// ImportProfile describes how to read a bank's CSV export. Columns are given
+// either as a header name or as a zero-based column index. SignConvention is
+// one of "signed" (negative amounts are debits), "inverted" (positive amounts
+// are debits), "type_column" (unsigned amounts with a debit/credit column)
+// or "debit_credit" (separate debit and credit amount columns).
+type ImportProfile struct {
+	ID                        int       `json:"id"`
+	Name                      string    `json:"name" db:"name"`
+	Delimiter                 string    `json:"delimiter" db:"delimiter"`
+	HasHeader                 bool      `json:"has_header" db:"has_header"`
+	SkipRows                  int       `json:"skip_rows" db:"skip_rows"`
+	DateColumn                string    `json:"date_column" db:"date_column"`
+	DescriptionColumn         string    `json:"description_column" db:"description_column"`
+	OriginalDescriptionColumn string    `json:"original_description_column" db:"original_description_column"`
+	AmountColumn              string    `json:"amount_column" db:"amount_column"`
+	DebitColumn               string    `json:"debit_column" db:"debit_column"`
+	CreditColumn              string    `json:"credit_column" db:"credit_column"`
+	TypeColumn                string    `json:"type_column" db:"type_column"`
+	CategoryColumn            string    `json:"category_column" db:"category_column"`
+	AccountColumn             string    `json:"account_column" db:"account_column"`
+	CurrencyColumn            string    `json:"currency_column" db:"currency_column"`
+	LabelsColumn              string    `json:"labels_column" db:"labels_column"`
+	NotesColumn               string    `json:"notes_column" db:"notes_column"`
+	DateFormat                string    `json:"date_format" db:"date_format"`
+	DecimalSeparator          string    `json:"decimal_separator" db:"decimal_separator"`
+	SignConvention            string    `json:"sign_convention" db:"sign_convention"`
+	DefaultCurrency           string    `json:"default_currency" db:"default_currency"`
+	DefaultAccount            string    `json:"default_account" db:"default_account"`
+	CreatedAt                 time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt                 time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type Backup struct {
+	Name          string       `json:"name"`
+	Kind          string       `json:"kind"`
+	CreatedAt     time.Time    `json:"created_at"`
+	SchemaVersion int          `json:"schema_version"`
+	Files         []BackupFile `json:"files"`
+}
+
+type BackupFile struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// Migration is a row of schema_migrations, a schema change applied to the DB
+type Migration struct {
+	Version   int       `json:"version" db:"version"`
+	Name      string    `json:"name" db:"name"`
+	AppliedAt time.Time `json:"applied_at" db:"applied_at"`
+}
+
+// Archive is the portable export of all user data. Rows are linked by their
+// provider IDs and category names rather than by database IDs, so it can be
+// imported into another instance.
+type Archive struct {
+	Format             string            `json:"format"`
+	Version            int               `json:"version"`
+	CreatedAt          time.Time         `json:"created_at"`
+	SchemaVersion      int               `json:"schema_version"`
+	IncludesTokens     bool              `json:"includes_tokens"`
+	Settings           ArchiveSettings   `json:"settings"`
+	Categories         []ArchiveCategory `json:"categories"`
+	PlaidCategories    []CategoryPlaid   `json:"plaid_categories"`
+	SaltEdgeCategories []CategorySE      `json:"saltedge_categories"`
+	ImportProfiles     []ImportProfile   `json:"import_profiles"`
+	Items              []ArchiveItem     `json:"items"`
+	Accounts           []Account         `json:"accounts"`
+	Transactions       []Transaction     `json:"transactions"`
+	ImportBatches      []ArchiveBatch    `json:"import_batches"`
+	DuplicatePairs     []DuplicatePair   `json:"duplicate_pairs"`
+}
+
+type ArchiveSettings struct {
+	BaseCurrency string `json:"base_currency"`
+}
+
+type ArchiveCategory struct {
+	ID                  int    `json:"id"`
+	TopCategory         string `json:"top_category" db:"top_category"`
+	SubCategory         string `json:"sub_category" db:"sub_category"`
+	ExcludeFromAnalysis bool   `json:"exclude_from_analysis" db:"exclude_from_analysis"`
+}
+
+// ArchiveItem is an item token in an archive. AccessToken is the sealed
+// token, see the secrets package, never the token itself.
+type ArchiveItem struct {
+	ItemToken
+	AccessToken string `json:"access_token,omitempty" db:"-"`
+}
+
+type ArchiveBatch struct {
+	ImportBatch
+	TransactionIDs []string `json:"transaction_ids"`
+	AccountIDs     []string `json:"account_ids"`
+}
+
+type ArchiveExportPost struct {
+	Passphrase    string `json:"passphrase"`
+	IncludeTokens bool   `json:"include_tokens"`
+}
+
+type ArchiveImportResult struct {
+	Categories     int `json:"categories"`
+	Items          int `json:"items"`
+	Accounts       int `json:"accounts"`
+	Transactions   int `json:"transactions"`
+	Skipped        int `json:"skipped"`
+	ImportProfiles int `json:"import_profiles"`
+	ImportBatches  int `json:"import_batches"`
+	DuplicatePairs int `json:"duplicate_pairs"`
+}
+
+// User is someone who can log in, see the auth package. Users from a proxy
+// login have no password.
+type User struct {
+	ID           int    `json:"id"`
+	Username     string `json:"username" db:"username"`
+	PasswordHash string `json:"-" db:"password_hash"`
+	// Role caps the scopes of the user's sessions and API tokens
+	Role string `json:"role" db:"role"`
+	// SaltEdgeCustomerID is the user's own SaltEdge customer, made the first
+	// time they link a bank through SaltEdge
+	SaltEdgeCustomerID string    `json:"saltedge_customer_id" db:"saltedge_customer_id"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type Session struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id" db:"user_id"`
+	TokenHash string    `json:"-" db:"token_hash"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// APIToken is a bearer token for scripts. Scopes is a comma separated list.
+type APIToken struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	Scopes     string     `json:"scopes" db:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at" db:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// APITokenCreated is only sent once, when the token is created
+type APITokenCreated struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+type APITokenPost struct {
+	Name        string   `json:"name"`
+	Scopes      []string `json:"scopes"`
+	ExpiresDays int      `json:"expires_days"`
+}
+
+type LoginPost struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// UserPost adds a user, with the default role if Role is empty
+type UserPost struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+type RolePost struct {
+	Role string `json:"role"`
+}
+
+// ConfirmationPost asks for a confirmation token for one destructive action
+type ConfirmationPost struct {
+	Action string `json:"action"`
+}
+
+// Confirmation is sent back in the X-Fin-Confirm header of the destructive
+// request it was asked for
+type Confirmation struct {
+	Action    string    `json:"action"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PasswordPost struct {
+	Current string `json:"current"`
+	New     string `json:"new"`
+}
+
+// SessionInfo tells the frontend whether it needs to log in
+type SessionInfo struct {
+	Mode   string   `json:"mode"`
+	User   *User    `json:"user"`
+	Scopes []string `json:"scopes"`
+}
+
+// AccountSharingPost changes an account's sharing, and its owner if UserID
+// is set (0 gives it to the household)
+type AccountSharingPost struct {
+	AccountID string `json:"account_id"`
+	Provider  string `json:"provider"`
+	Sharing   string `json:"sharing"`
+	UserID    *int   `json:"user_id"`
 }
 
 type GenerateTokenPost struct {
@@ -228,6 +617,13 @@ type CreateTokenPost struct {
 	Name  string `json:"name"`
 }
 
+// LinkPost asks a provider to start linking a bank, or logging into an item
+// again when ItemID is set
+type LinkPost struct {
+	Provider string `json:"provider"`
+	ItemID   string `json:"item_id"`
+}
+
 type EcbFXRate struct {
 	Date struct {
 		Value string `xml:"value,attr"`
@@ -382,6 +778,10 @@ type ConnectionResponse struct {
 	} `json:"meta"`
 }
 
+type ConnectionShowResponse struct {
+	Data SEConnection `json:"data"`
+}
+
 type TransactionsResponse struct {
 	Data []SETransaction `json:"data"`
 	Meta struct {
@@ -397,16 +797,28 @@ type CreateRefreshResponse struct {
 	} `json:"data"`
 }
 
+type CustomerResponse struct {
+	Data struct {
+		ID         string `json:"id"`
+		Identifier string `json:"identifier"`
+	} `json:"data"`
+}
+
 func PrepTransSt(txn *sqlx.Tx) *sqlx.NamedStmt {
-	tquery := `INSERT INTO transactions('date', transaction_id, description, amount, normalized_amount, category,
-				category_name, account_name, currency_code, account_id)
-				VALUES(:date, :transaction_id, :description, :amount, :normalized_amount, :category,
-				:category_name, :account_name, :currency_code, :account_id) 
+	tquery := `INSERT INTO transactions("date", transaction_id, description, original_description, amount, normalized_amount,
+				transaction_type, category, category_name, account_name, currency_code, account_id, labels, notes, status)
+				VALUES(:date, :transaction_id, :description, :original_description, :amount, :normalized_amount,
+				:transaction_type, :category, :category_name, :account_name, :currency_code, :account_id, :labels, :notes, COALESCE(NULLIF(:status, ''), 'posted')) 
 				ON CONFLICT (transaction_id) DO UPDATE SET
-				'date' = excluded.'date',
+				"date" = excluded."date",
 				description = excluded.description,
+				original_description = CASE WHEN excluded.original_description = '' THEN transactions.original_description ELSE excluded.original_description END,
 				amount = excluded.amount,
-				normalized_amount = excluded.normalized_amount`
+				normalized_amount = excluded.normalized_amount,
+				transaction_type = excluded.transaction_type,
+				labels = CASE WHEN excluded.labels = '' THEN transactions.labels ELSE excluded.labels END,
+				notes = CASE WHEN excluded.notes = '' THEN transactions.notes ELSE excluded.notes END,
+				status = COALESCE(NULLIF(:status, ''), transactions.status)`
 	tstmt, err := txn.PrepareNamed(tquery)
 	if err != nil {
 		panic(err)
@@ -415,17 +827,22 @@ func PrepTransSt(txn *sqlx.Tx) *sqlx.NamedStmt {
 }
 
 func PrepTransUpsertSt(txn *sqlx.Tx) *sqlx.NamedStmt {
-	tquery := `INSERT INTO transactions('date', transaction_id, description, amount, normalized_amount, category,
-				category_name, account_name, currency_code, account_id)
-				VALUES(:date, :transaction_id, :description, :amount, :normalized_amount, :category,
-				:category_name, :account_name, :currency_code, :account_id) 
+	tquery := `INSERT INTO transactions("date", transaction_id, description, original_description, amount, normalized_amount,
+				transaction_type, category, category_name, account_name, currency_code, account_id, labels, notes, status)
+				VALUES(:date, :transaction_id, :description, :original_description, :amount, :normalized_amount,
+				:transaction_type, :category, :category_name, :account_name, :currency_code, :account_id, :labels, :notes, COALESCE(NULLIF(:status, ''), 'posted')) 
 				ON CONFLICT (transaction_id) DO UPDATE SET
-				'date' = excluded.'date',
+				"date" = excluded."date",
 				description = excluded.description,
+				original_description = excluded.original_description,
 				amount = excluded.amount,
 				normalized_amount = excluded.normalized_amount,
+				transaction_type = excluded.transaction_type,
 				category = excluded.category,
-				category_name = excluded.category_name`
+				category_name = excluded.category_name,
+				labels = excluded.labels,
+				notes = excluded.notes,
+				status = COALESCE(NULLIF(:status, ''), transactions.status)`
 	tstmt, err := txn.PrepareNamed(tquery)
 	if err != nil {
 		panic(err)
@@ -433,11 +850,14 @@ func PrepTransUpsertSt(txn *sqlx.Tx) *sqlx.NamedStmt {
 	return tstmt
 }
 
+// PrepAccountSt adds or updates an account. New accounts of an item belong to
+// the item's owner, others to the account's UserID.
 func PrepAccountSt(txn *sqlx.Tx) *sqlx.NamedStmt {
-	aquery := `INSERT INTO accounts(name, institution, provider, account_id, item_id, type, 'limit', available, balance, currency, subtype)
-				VALUES(:name, :institution, :provider, :account_id, :item_id, :type, :limit, :available, :balance, :currency, :subtype) 
+	aquery := `INSERT INTO accounts(name, institution, provider, account_id, item_id, type, "limit", available, balance, currency, subtype, user_id)
+				VALUES(:name, :institution, :provider, :account_id, :item_id, :type, :limit, :available, :balance, :currency, :subtype,
+				COALESCE((SELECT user_id FROM item_tokens WHERE item_id = :item_id AND provider = :provider), :user_id)) 
 				ON CONFLICT (account_id, provider) DO UPDATE SET
-				'limit' = excluded.'limit',
+				"limit" = excluded."limit",
 				available = excluded.available,
 				balance = excluded.balance`
 	astmt, err := txn.PrepareNamed(aquery)
@@ -448,7 +868,7 @@ func PrepAccountSt(txn *sqlx.Tx) *sqlx.NamedStmt {
 }
 
 func PrepAccountUpsertIgnoreSt(txn *sqlx.Tx) *sqlx.NamedStmt {
-	aquery := `INSERT INTO accounts(name, institution, provider, account_id, item_id, type, 'limit', available, balance, currency, subtype, ignore_transactions)
+	aquery := `INSERT INTO accounts(name, institution, provider, account_id, item_id, type, "limit", available, balance, currency, subtype, ignore_transactions)
 				VALUES(:name, :institution, :provider, :account_id, :item_id, :type, :limit, :available, :balance, :currency, :subtype, :ignore_transactions) 
 				ON CONFLICT (account_id, provider) DO UPDATE SET
 				ignore_transactions = excluded.ignore_transactions`
@@ -460,7 +880,7 @@ func PrepAccountUpsertIgnoreSt(txn *sqlx.Tx) *sqlx.NamedStmt {
 }
 
 func PrepAccountUpsertNameSt(txn *sqlx.Tx) *sqlx.NamedStmt {
-	aquery := `INSERT INTO accounts(name, institution, provider, account_id, item_id, type, 'limit', available, balance, currency, subtype, ignore_transactions)
+	aquery := `INSERT INTO accounts(name, institution, provider, account_id, item_id, type, "limit", available, balance, currency, subtype, ignore_transactions)
 				VALUES(:name, :institution, :provider, :account_id, :item_id, :type, :limit, :available, :balance, :currency, :subtype, :ignore_transactions) 
 				ON CONFLICT (account_id, provider) DO UPDATE SET
 				name = excluded.name`
@@ -471,9 +891,25 @@ func PrepAccountUpsertNameSt(txn *sqlx.Tx) *sqlx.NamedStmt {
 	return astmt
 }
 
-func PrepItemSt(txn *sqlx.Tx) *sqlx.NamedStmt {
-	iquery := `INSERT INTO item_tokens(institution, provider, interactive, last_refresh, next_refresh_possible, item_id, needs_re_login, access_token, last_downloaded_transactions)
-				VALUES(:institution, :provider, :interactive, :last_refresh, :next_refresh_possible, :item_id, :needs_re_login, :access_token, :last_downloaded_transactions) 
+// ItemStmt writes item tokens, sealing their access token on the way so it
+// is never stored in the clear, see the secrets package
+type ItemStmt struct {
+	stmt *sqlx.NamedStmt
+}
+
+// MustExec seals the item's access token and writes the item
+func (s ItemStmt) MustExec(item ItemToken) sql.Result {
+	sealed, err := secrets.Seal(item.AccessToken)
+	if err != nil {
+		panic(err)
+	}
+	item.AccessToken = sealed
+	return s.stmt.MustExec(item)
+}
+
+func PrepItemSt(txn *sqlx.Tx) ItemStmt {
+	iquery := `INSERT INTO item_tokens(institution, provider, interactive, last_refresh, next_refresh_possible, item_id, needs_re_login, access_token, last_downloaded_transactions, user_id)
+				VALUES(:institution, :provider, :interactive, :last_refresh, :next_refresh_possible, :item_id, :needs_re_login, :access_token, :last_downloaded_transactions, :user_id) 
 				ON CONFLICT (item_id, provider) DO UPDATE SET
 				interactive = excluded.interactive,
 				last_refresh = excluded.last_refresh,
@@ -484,29 +920,69 @@ func PrepItemSt(txn *sqlx.Tx) *sqlx.NamedStmt {
 	if err != nil {
 		panic(err)
 	}
-	return istmt
+	return ItemStmt{istmt}
 }
 
-func PrepItemStOnlyTx(txn *sqlx.Tx) *sqlx.NamedStmt {
-	iquery := `INSERT INTO item_tokens(institution, provider, interactive, last_refresh, next_refresh_possible, item_id, needs_re_login, access_token, last_downloaded_transactions)
-				VALUES(:institution, :provider, :interactive, :last_refresh, :next_refresh_possible, :item_id, :needs_re_login, :access_token, :last_downloaded_transactions) 
+func PrepItemStOnlyTx(txn *sqlx.Tx) ItemStmt {
+	iquery := `INSERT INTO item_tokens(institution, provider, interactive, last_refresh, next_refresh_possible, item_id, needs_re_login, access_token, last_downloaded_transactions, sync_cursor)
+				VALUES(:institution, :provider, :interactive, :last_refresh, :next_refresh_possible, :item_id, :needs_re_login, :access_token, :last_downloaded_transactions, :sync_cursor) 
 				ON CONFLICT (item_id, provider) DO UPDATE SET
-				last_downloaded_transactions = excluded.last_downloaded_transactions`
+				last_downloaded_transactions = excluded.last_downloaded_transactions,
+				sync_cursor = excluded.sync_cursor`
 	istmt, err := txn.PrepareNamed(iquery)
 	if err != nil {
 		panic(err)
 	}
-	return istmt
+	return ItemStmt{istmt}
+}
+
+func PrepImportProfileSt(txn *sqlx.Tx) *sqlx.NamedStmt {
+	pquery := `INSERT INTO import_profiles(name, delimiter, has_header, skip_rows, date_column, description_column,
+				original_description_column, amount_column, debit_column, credit_column, type_column, category_column,
+				account_column, currency_column, labels_column, notes_column, date_format, decimal_separator,
+				sign_convention, default_currency, default_account)
+				VALUES(:name, :delimiter, :has_header, :skip_rows, :date_column, :description_column,
+				:original_description_column, :amount_column, :debit_column, :credit_column, :type_column, :category_column,
+				:account_column, :currency_column, :labels_column, :notes_column, :date_format, :decimal_separator,
+				:sign_convention, :default_currency, :default_account)
+				ON CONFLICT (name) DO UPDATE SET
+				delimiter = excluded.delimiter,
+				has_header = excluded.has_header,
+				skip_rows = excluded.skip_rows,
+				date_column = excluded.date_column,
+				description_column = excluded.description_column,
+				original_description_column = excluded.original_description_column,
+				amount_column = excluded.amount_column,
+				debit_column = excluded.debit_column,
+				credit_column = excluded.credit_column,
+				type_column = excluded.type_column,
+				category_column = excluded.category_column,
+				account_column = excluded.account_column,
+				currency_column = excluded.currency_column,
+				labels_column = excluded.labels_column,
+				notes_column = excluded.notes_column,
+				date_format = excluded.date_format,
+				decimal_separator = excluded.decimal_separator,
+				sign_convention = excluded.sign_convention,
+				default_currency = excluded.default_currency,
+				default_account = excluded.default_account`
+	pstmt, err := txn.PrepareNamed(pquery)
+	if err != nil {
+		panic(err)
+	}
+	return pstmt
 }
 
 func PrepTreeSt(txn *sqlx.Tx) *sqlx.NamedStmt {
-	iquery := `INSERT INTO analysis_trees(name, first_date, last_date, data, data_no_invest)
-				VALUES(:name, :first_date, :last_date, :data, :data_no_invest) 
-				ON CONFLICT (name) DO UPDATE SET
+	iquery := `INSERT INTO analysis_trees(scope, name, first_date, last_date, data, data_no_invest, data_posted, data_no_invest_posted)
+				VALUES(:scope, :name, :first_date, :last_date, :data, :data_no_invest, :data_posted, :data_no_invest_posted) 
+				ON CONFLICT (scope, name) DO UPDATE SET
 				first_date = excluded.first_date,
 				last_date = excluded.last_date,
 				data = excluded.data,
-				data_no_invest = excluded.data_no_invest`
+				data_no_invest = excluded.data_no_invest,
+				data_posted = excluded.data_posted,
+				data_no_invest_posted = excluded.data_no_invest_posted`
 	istmt, err := txn.PrepareNamed(iquery)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayScan(t *testing.T) {
+	tests := []struct {
+		src  interface{}
+		want Day
+	}{
+		{time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), "2021-01-02"},
+		{"2021-01-02", "2021-01-02"},
+		{"2021-01-02T00:00:00Z", "2021-01-02"},
+		{[]byte("2021-01-02 00:00:00+00:00"), "2021-01-02"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		var d Day
+		if err := d.Scan(tt.src); err != nil || d != tt.want {
+			t.Errorf("Scan(%v) = %q, %v, want %q", tt.src, d, err, tt.want)
+		}
+	}
+	var d Day
+	if err := d.Scan(42); err == nil {
+		t.Error("Scan(42) succeeded")
+	}
+}
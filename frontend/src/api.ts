@@ -6,6 +6,14 @@ const client = axios.create({
   timeout: 60 * 4 * 1000, // wait 4 min for the long import calls
 });
 
+// With auth turned on the backend answers 401 until someone logs in
+client.interceptors.response.use(undefined, (error) => {
+  if (error.response && error.response.status === 401 && window.location.pathname !== '/login') {
+    window.location.assign('/login');
+  }
+  return Promise.reject(error);
+});
+
 export default {
   async execute(method: any, resource: any, data: any = '') {
     return client({
@@ -16,6 +24,18 @@ export default {
       return req.data;
     });
   },
+  // Destructive routes need a one-off confirmation token for their action
+  async executeConfirmed(action: string, method: any, resource: any, data: any = '') {
+    const confirmation = await this.execute('post', '/api/confirmations', { action });
+    return client({
+      method,
+      url: resource,
+      data,
+      headers: { 'X-Fin-Confirm': confirmation.token },
+    }).then((req) => {
+      return req.data;
+    });
+  },
   getTransactions() {
     return this.execute('get', '/api/transactions');
   },
@@ -25,11 +45,94 @@ export default {
   createTransaction(data: any) {
     return this.execute('post', '/api/transactions', data);
   },
-  async importTransactions(data: any) {
+  parseImportFile(file: any) {
+    const form = new FormData();
+    form.append('file', file);
+    return this.execute('post', '/api/parseImportFile', form);
+  },
+  importFile(file: any, profile?: string) {
+    const form = new FormData();
+    form.append('file', file);
+    if (profile) {
+      form.append('profile', profile);
+    }
+    return this.execute('post', '/api/importFile', form);
+  },
+  getImportBatches() {
+    return this.execute('get', '/api/importBatches');
+  },
+  getImportBatch(id: any) {
+    return this.execute('get', `/api/importBatches/${id}`);
+  },
+  rollbackImportBatch(id: any) {
+    return this.executeConfirmed('rollbackImport', 'post', `/api/importBatches/${id}/rollback`);
+  },
+  // Export is a download, so this only builds the link for an <a href>
+  exportUrl(format: string = 'csv', filters: any = {}) {
+    const params = new URLSearchParams({ format });
+    Object.keys(filters).forEach((key) => {
+      const value = filters[key];
+      if (value !== undefined && value !== null && value !== '') {
+        params.append(key, Array.isArray(value) ? value.join(',') : String(value));
+      }
+    });
+    return `/api/export?${params.toString()}`;
+  },
+  getBackups() {
+    return this.execute('get', '/api/backups');
+  },
+  createBackup() {
+    return this.execute('post', '/api/backups');
+  },
+  deleteBackup(name: string) {
+    return this.executeConfirmed('deleteBackup', 'delete', `/api/backups/${name}`);
+  },
+  restoreBackup(name: string) {
+    return this.executeConfirmed('restoreBackup', 'post', `/api/backups/${name}/restore`);
+  },
+  // The archive comes back as a Blob to be saved by the caller
+  exportArchive(passphrase: string = '', includeTokens: boolean = false) {
+    return client({
+      method: 'post',
+      url: '/api/archive/export',
+      data: { passphrase, include_tokens: includeTokens },
+      responseType: 'blob',
+    }).then((req) => {
+      return req.data;
+    });
+  },
+  importArchive(file: any, passphrase: string = '') {
+    const form = new FormData();
+    form.append('file', file);
+    if (passphrase) {
+      form.append('passphrase', passphrase);
+    }
+    return this.executeConfirmed('importArchive', 'post', '/api/archive/import', form);
+  },
+  getDuplicates(status: string = 'pending') {
+    return this.execute('get', `/api/duplicates?status=${status}`);
+  },
+  scanDuplicates() {
+    return this.execute('post', '/api/duplicates/scan');
+  },
+  resolveDuplicate(id: any, action: string, keep: string = '') {
+    return this.execute('post', `/api/duplicates/${id}/${action}`, keep ? { keep } : undefined);
+  },
+  getImportProfiles() {
+    return this.execute('get', '/api/importProfiles');
+  },
+  upsertImportProfile(data: any) {
+    return this.execute('post', '/api/importProfiles', data);
+  },
+  deleteImportProfile(name: string) {
+    return this.execute('delete', `/api/importProfiles/${encodeURIComponent(name)}`);
+  },
+  async importTransactions(data: any, options: any = null, filename: string = '') {
     const bus = new EventEmitter();
     let lock = false;
     const identifiedAccounts: any = [];
-    const res = await this.execute('post', `/api/checkTransactions`, data);
+    const checkData = options ? { options, transactions: data } : data;
+    const res = await this.execute('post', `/api/checkTransactions`, checkData);
     // console.log(res.trans)
     if (res.trans.transSets != null) {
       for (const compareSet of res.trans.transSets) {
@@ -89,6 +192,10 @@ export default {
     importData.catres = catres;
     importData.identifiedAccounts = identifiedAccounts;
     importData.transactions = data;
+    importData.filename = filename;
+    if (options) {
+      importData.options = options;
+    }
 
     await this.execute('post', `/api/importTransactions`, importData);
     return;
@@ -162,7 +269,7 @@ export default {
     return this.execute('put', `/api/itemTokens/${id}`, data);
   },
   deleteItemToken(id: any) {
-    return this.execute('delete', `/api/itemTokens/${id}`);
+    return this.executeConfirmed('deleteItemToken', 'delete', `/api/itemTokens/${id}`);
   },
   getSaltEdgeCategories() {
     return this.execute('get', '/api/saltEdge_Categories');
@@ -177,10 +284,10 @@ export default {
     return this.execute('get', `/api/saltEdgeCreateInteractive/`);
   },
   resetDB() {
-    return this.execute('get', `/api/resetDB`);
+    return this.executeConfirmed('resetDB', 'post', `/api/resetDB`);
   },
   resetDBFull() {
-    return this.execute('get', `/api/resetDBFull`);
+    return this.executeConfirmed('resetDBFull', 'post', `/api/resetDBFull`);
   },
   getTrees() {
     return this.execute('get', `/api/analysisTrees`);
@@ -188,4 +295,28 @@ export default {
   customAnalyze(data: any) {
     return this.execute('post', `/api/customTree`, data);
   },
+  getSession() {
+    return this.execute('get', '/api/session');
+  },
+  login(username: string, password: string) {
+    return this.execute('post', '/api/session', { username, password });
+  },
+  logout() {
+    return this.execute('delete', '/api/session');
+  },
+  changePassword(current: string, newPassword: string) {
+    return this.execute('post', '/api/password', { current, new: newPassword });
+  },
+  getApiTokens() {
+    return this.execute('get', '/api/apiTokens');
+  },
+  createApiToken(name: string, scopes: string[], expiresDays: number = 0) {
+    return this.execute('post', '/api/apiTokens', { name, scopes, expires_days: expiresDays });
+  },
+  revokeApiToken(id: any) {
+    return this.execute('delete', `/api/apiTokens/${id}`);
+  },
+  setUserRole(id: any, role: string) {
+    return this.execute('post', `/api/users/${id}/role`, { role });
+  },
 };
@@ -36,6 +36,11 @@ const router = new Router({
       name: 'accounts',
       component: () => import('./views/Accounts.vue'),
     },
+    {
+      path: '/login',
+      name: 'login',
+      component: () => import('./views/Login.vue'),
+    },
   ],
 });
 